@@ -0,0 +1,126 @@
+// Package analytics: 완료 시각을 바탕으로 한 생산성 지표 계산 (소요 시간 백분위, 주간 처리량, 번다운, 연속 완료일)
+// DB 조회는 repository에서 하고, 여기서는 이미 읽어온 할 일 목록으로 계산만 합니다.
+// 날짜 경계(하루, 주)는 모두 호출한 쪽이 넘겨준 시간대(loc) 기준입니다.
+package analytics
+
+import (
+	"math"
+	"sort"
+	"time"
+
+	"go_study/model"
+)
+
+// StartOfDay: loc 기준 그날 0시
+func StartOfDay(t time.Time, loc *time.Location) time.Time {
+	t = t.In(loc)
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, loc)
+}
+
+// StartOfWeek: loc 기준 그 주 월요일 0시
+func StartOfWeek(t time.Time, loc *time.Location) time.Time {
+	day := StartOfDay(t, loc)
+	offset := (int(day.Weekday()) + 6) % 7 // 월요일=0 ... 일요일=6
+	return day.AddDate(0, 0, -offset)
+}
+
+// LeadTimes: [from, to) 사이에 완료된 할 일의 소요 시간 백분위 (nearest-rank)
+func LeadTimes(todos []model.Todo, from, to time.Time) model.LeadTime {
+	var secs []float64
+	for _, t := range todos {
+		if !completedIn(t, from, to) {
+			continue
+		}
+		secs = append(secs, math.Max(0, t.CompletedAt.Sub(t.CreatedAt).Seconds()))
+	}
+	lt := model.LeadTime{Count: len(secs)}
+	if len(secs) == 0 {
+		return lt
+	}
+	sort.Float64s(secs)
+	lt.P50, lt.P75, lt.P90, lt.P95 = percentile(secs, 50), percentile(secs, 75), percentile(secs, 90), percentile(secs, 95)
+	lt.Max = secs[len(secs)-1]
+	return lt
+}
+
+// WeeklyThroughput: start(월요일 0시)부터 weeks주 동안 주마다 완료한 개수
+func WeeklyThroughput(todos []model.Todo, start time.Time, weeks int) []model.WeekCount {
+	counts := make([]model.WeekCount, weeks)
+	for i := range counts {
+		counts[i].WeekStart = start.AddDate(0, 0, 7*i).Format(time.DateOnly)
+	}
+	for _, t := range todos {
+		if t.CompletedAt == nil || t.CompletedAt.Before(start) {
+			continue
+		}
+		// 서머타임이 있는 시간대도 있으므로 시간 차이가 아니라 날짜로 주를 계산
+		week := int(StartOfDay(*t.CompletedAt, start.Location()).Sub(start).Hours()/24+0.5) / 7
+		if week < len(counts) {
+			counts[week].Completed++
+		}
+	}
+	return counts
+}
+
+// Burndown: start(0시)부터 days일 동안 매일 끝날 때 남아 있던(만들어졌고 아직 완료되지 않은) 할 일 수
+func Burndown(todos []model.Todo, start time.Time, days int) []model.BurndownPoint {
+	points := make([]model.BurndownPoint, days)
+	for i := range points {
+		day := start.AddDate(0, 0, i)
+		end := day.AddDate(0, 0, 1)
+		points[i].Date = day.Format(time.DateOnly)
+		for _, t := range todos {
+			if t.CreatedAt.Before(end) && (t.CompletedAt == nil || !t.CompletedAt.Before(end)) {
+				points[i].Remaining++
+			}
+		}
+	}
+	return points
+}
+
+// CompletionStreaks: 완료한 날들로 연속 일수 계산 (today는 loc 기준 오늘 0시)
+// 오늘 아직 완료한 게 없어도 어제까지 이어졌으면 현재 연속 기록으로 칩니다.
+func CompletionStreaks(todos []model.Todo, today time.Time) model.Streaks {
+	loc := today.Location()
+	days := map[time.Time]bool{}
+	for _, t := range todos {
+		if t.CompletedAt != nil {
+			days[StartOfDay(*t.CompletedAt, loc)] = true
+		}
+	}
+	if len(days) == 0 {
+		return model.Streaks{}
+	}
+	sorted := make([]time.Time, 0, len(days))
+	for d := range days {
+		sorted = append(sorted, d)
+	}
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].Before(sorted[j]) })
+
+	var s model.Streaks
+	run := 0
+	for i, d := range sorted {
+		if i > 0 && sorted[i-1].AddDate(0, 0, 1).Equal(d) {
+			run++
+		} else {
+			run = 1
+		}
+		s.Longest = max(s.Longest, run)
+	}
+	last := sorted[len(sorted)-1]
+	s.LastCompleted = last.Format(time.DateOnly)
+	if last.Equal(today) || last.Equal(today.AddDate(0, 0, -1)) {
+		s.Current = run
+	}
+	return s
+}
+
+func completedIn(t model.Todo, from, to time.Time) bool {
+	return t.CompletedAt != nil && !t.CompletedAt.Before(from) && t.CompletedAt.Before(to)
+}
+
+// 정렬된 값에서 p 백분위 (nearest-rank: 값 중 하나를 그대로 돌려줌)
+func percentile(sorted []float64, p float64) float64 {
+	rank := int(math.Ceil(p / 100 * float64(len(sorted))))
+	return sorted[max(rank, 1)-1]
+}
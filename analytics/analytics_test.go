@@ -0,0 +1,97 @@
+package analytics
+
+import (
+	"testing"
+	"time"
+
+	"go_study/model"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var seoul = time.FixedZone("KST", 9*60*60)
+
+// 서울 시각으로 할 일 만들기 (completed가 0이면 미완료)
+func todo(created, completed string) model.Todo {
+	t := model.Todo{CreatedAt: mustTime(created)}
+	if completed != "" {
+		c := mustTime(completed)
+		t.Done, t.CompletedAt = true, &c
+	}
+	return t
+}
+
+func mustTime(s string) time.Time {
+	t, err := time.ParseInLocation("2006-01-02 15:04", s, seoul)
+	if err != nil {
+		panic(err)
+	}
+	return t
+}
+
+func TestStartOfWeek(t *testing.T) {
+	// 2025-01-05는 일요일 → 그 주 월요일은 2024-12-30
+	assert.Equal(t, mustTime("2024-12-30 00:00"), StartOfWeek(mustTime("2025-01-05 23:30"), seoul))
+	assert.Equal(t, mustTime("2025-01-06 00:00"), StartOfWeek(mustTime("2025-01-06 00:00"), seoul))
+	// UTC로는 1월 5일 15시지만 서울은 1월 6일(월)
+	assert.Equal(t, mustTime("2025-01-06 00:00"), StartOfWeek(time.Date(2025, 1, 5, 15, 0, 0, 0, time.UTC), seoul))
+}
+
+func TestLeadTimes(t *testing.T) {
+	todos := []model.Todo{
+		todo("2025-01-06 09:00", "2025-01-06 10:00"), // 1시간
+		todo("2025-01-06 09:00", "2025-01-06 11:00"), // 2시간
+		todo("2025-01-06 09:00", "2025-01-06 12:00"), // 3시간
+		todo("2025-01-06 09:00", "2025-01-07 09:00"), // 24시간
+		todo("2025-01-06 09:00", ""),                 // 미완료는 제외
+		todo("2024-12-01 09:00", "2024-12-02 09:00"), // 기간 밖
+	}
+	lt := LeadTimes(todos, mustTime("2025-01-06 00:00"), mustTime("2025-01-13 00:00"))
+	assert.Equal(t, 4, lt.Count)
+	assert.Equal(t, 2*3600.0, lt.P50)
+	assert.Equal(t, 3*3600.0, lt.P75)
+	assert.Equal(t, 24*3600.0, lt.P95)
+	assert.Equal(t, 24*3600.0, lt.Max)
+
+	assert.Equal(t, model.LeadTime{}, LeadTimes(nil, mustTime("2025-01-06 00:00"), mustTime("2025-01-13 00:00")))
+}
+
+func TestWeeklyThroughputAndBurndown(t *testing.T) {
+	start := mustTime("2025-01-06 00:00")
+	todos := []model.Todo{
+		todo("2025-01-06 09:00", "2025-01-07 09:00"),
+		todo("2025-01-06 09:00", "2025-01-12 23:59"), // 일요일 밤 → 첫째 주
+		todo("2025-01-07 09:00", "2025-01-13 00:00"), // 월요일 0시 → 둘째 주
+		todo("2025-01-08 09:00", ""),
+	}
+	assert.Equal(t, []model.WeekCount{
+		{WeekStart: "2025-01-06", Completed: 2},
+		{WeekStart: "2025-01-13", Completed: 1},
+	}, WeeklyThroughput(todos, start, 2))
+
+	points := Burndown(todos, start, 3)
+	assert.Equal(t, []model.BurndownPoint{
+		{Date: "2025-01-06", Remaining: 2}, // 1월 6일에 만든 2개
+		{Date: "2025-01-07", Remaining: 2}, // +1 생성, -1 완료
+		{Date: "2025-01-08", Remaining: 3},
+	}, points)
+}
+
+func TestCompletionStreaks(t *testing.T) {
+	todos := []model.Todo{
+		todo("2025-01-01 09:00", "2025-01-01 10:00"),
+		todo("2025-01-01 09:00", "2025-01-02 10:00"),
+		todo("2025-01-01 09:00", "2025-01-03 10:00"),
+		todo("2025-01-01 09:00", "2025-01-03 20:00"), // 같은 날 두 번은 하루로
+		todo("2025-01-01 09:00", "2025-01-06 10:00"),
+		todo("2025-01-01 09:00", "2025-01-07 10:00"),
+	}
+	// 오늘(8일) 아직 완료가 없어도 어제까지 이어졌으면 현재 연속 기록
+	s := CompletionStreaks(todos, mustTime("2025-01-08 00:00"))
+	assert.Equal(t, model.Streaks{Current: 2, Longest: 3, LastCompleted: "2025-01-07"}, s)
+
+	// 이틀 넘게 쉬면 현재 기록은 0
+	s = CompletionStreaks(todos, mustTime("2025-01-10 00:00"))
+	assert.Equal(t, 0, s.Current)
+	assert.Equal(t, model.Streaks{}, CompletionStreaks(nil, mustTime("2025-01-10 00:00")))
+}
@@ -0,0 +1,235 @@
+// Package app: 저장소, 핸들러, 백그라운드 작업을 설정대로 엮어서 하나의 서버로 만드는 곳 (의존성 주입)
+// main은 DB를 열고 마이그레이션한 뒤 New → Start → Router 실행만 합니다. 테스트도 같은 라우터를 씁니다.
+package app
+
+import (
+	"os"
+	"path/filepath"
+
+	"go_study/backup"
+	"go_study/cluster"
+	"go_study/config"
+	"go_study/cron"
+	"go_study/global"
+	"go_study/handler"
+	"go_study/healthcheck"
+	"go_study/jobs"
+	"go_study/middleware"
+	"go_study/model"
+	"go_study/outbox"
+	"go_study/replication"
+	"go_study/repository"
+	"go_study/webhook"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+// App: 서버 하나를 이루는 구성 요소
+type App struct {
+	Config   *config.Config   // 시작할 때의 설정 (다시 읽은 값은 Reloader.Current())
+	Reloader *config.Reloader // 설정 파일 변경 감지 → 바로 적용되는 항목 교체
+	DB       *gorm.DB
+	Router   *gin.Engine
+
+	Queue      *jobs.Queue
+	Dispatcher *outbox.Dispatcher
+	Monitor    *cluster.Monitor
+	Replica    *replication.Replica // 복제 모드가 아니면 nil
+	Backups    *backup.Manager
+
+	repos    repos
+	handlers handlers
+}
+
+type repos struct {
+	todos         *repository.SQLiteRepository
+	idempotency   *repository.SQLiteIdempotencyRepository
+	calendarToken *repository.SQLiteCalendarTokenRepository
+	audits        *repository.SQLiteAuditRepository
+	webhooks      *repository.SQLiteWebhookRepository
+	outbox        *repository.SQLiteOutboxRepository
+	jobs          *repository.SQLiteJobRepository
+	replication   *repository.SQLiteReplicationRepository
+	cluster       *repository.SQLiteClusterRepository
+}
+
+type handlers struct {
+	todo        *handler.TodoHandler
+	calendar    *handler.CalendarHandler
+	caldav      *handler.CalDAVHandler
+	sync        *handler.SyncHandler
+	audit       *handler.AuditHandler
+	history     *handler.HistoryHandler
+	analytics   *handler.AnalyticsHandler
+	webhook     *handler.WebhookHandler
+	queue       *handler.QueueHandler
+	backup      *handler.BackupHandler
+	health      *handler.HealthHandler
+	cluster     *handler.ClusterHandler
+	replication *handler.ReplicationHandler
+	config      *handler.ConfigHandler
+	promote     gin.HandlerFunc
+}
+
+// New: 설정대로 구성 요소를 만들고 라우터를 준비 (백그라운드 작업은 Start에서 시작)
+func New(db *gorm.DB, cfg *config.Config) *App {
+	a := &App{Config: cfg, Reloader: config.NewReloader(cfg), DB: db}
+
+	// 1. Repository 생성 (인터페이스 구현체)
+	a.repos = repos{
+		todos:         repository.NewSQLiteRepository(db),
+		idempotency:   repository.NewSQLiteIdempotencyRepository(db),
+		calendarToken: repository.NewSQLiteCalendarTokenRepository(db),
+		audits:        repository.NewSQLiteAuditRepository(db),
+		webhooks:      repository.NewSQLiteWebhookRepository(db),
+		outbox:        repository.NewSQLiteOutboxRepository(db),
+		jobs:          repository.NewSQLiteJobRepository(db),
+		replication:   repository.NewSQLiteReplicationRepository(db),
+		cluster:       repository.NewSQLiteClusterRepository(db),
+	}
+
+	// 🧰 DB 기반 백그라운드 작업 큐 (Active 서버에서 실행, 재시작해도 유지)
+	// DB를 공유하는 Standby는 Active가 죽어서 밀린 작업을 이어받음 (복제 모드의 Standby DB는 Active와 다르므로 제외)
+	a.Queue = jobs.NewQueue(a.repos.jobs, jobs.Options{
+		Workers:           cfg.Jobs.Workers,
+		PollInterval:      cfg.Jobs.PollInterval,
+		VisibilityTimeout: cfg.Jobs.VisibilityTimeout,
+		MaxAttempts:       cfg.Jobs.MaxAttempts,
+		BaseBackoff:       cfg.Jobs.BaseBackoff,
+		MaxBackoff:        cfg.Jobs.MaxBackoff,
+		StandbyTakeover:   !cfg.Replication.Enabled,
+	})
+
+	// 📮 할 일 변경과 같은 트랜잭션에 저장된 outbox 이벤트를 구독자에게 (Active 서버에서만)
+	// 웹훅은 구독자 중 하나: 이벤트를 전송 대기열로 옮기고, 실제 전송은 webhook.Worker가 함
+	a.Dispatcher = outbox.NewDispatcher(a.repos.outbox, cfg.Outbox.PollInterval, cfg.Outbox.MaxAttempts)
+	a.Dispatcher.Subscribe("webhook", webhook.NewPublisher(a.repos.webhooks).HandleOutbox)
+
+	a.Backups = backup.NewManager(db, cfg.Backup.Dir)
+
+	// 🩺 readiness 점검: 역할, DB, /data 여유 공간, 로그 디렉터리 쓰기
+	checker := healthcheck.New(cfg.Health.CheckTimeout)
+	checker.Add("role", healthcheck.Role())
+	checker.Add("database", healthcheck.Database(db))
+	checker.Add("disk", healthcheck.DiskSpace(filepath.Dir(cfg.Database.File), cfg.Health.MinFreeMB<<20))
+	checker.Add("log_dir", healthcheck.Writable(filepath.Dir(cfg.Log.Path)))
+
+	// 🤝 서버 간 heartbeat (누가 Active인지, split-brain이면 임기가 작은 쪽이 Standby로)
+	nodeName := cfg.Cluster.NodeName
+	if nodeName == "" {
+		nodeName, _ = os.Hostname()
+	}
+	a.restoreTerm()
+	a.Monitor = cluster.New(cluster.Options{
+		NodeName:    nodeName,
+		Peers:       cfg.Cluster.Peers,
+		Interval:    cfg.Cluster.HeartbeatInterval,
+		PeerTimeout: cfg.Cluster.PeerTimeout,
+		Token:       cfg.Cluster.Token,
+	})
+
+	// 🔁 복제 모드: Standby는 자기 DB에 Active의 변경분을 변경 순번 순서대로 적용
+	if cfg.Replication.Enabled {
+		source := cfg.Replication.Source
+		a.Replica = replication.NewReplica(a.repos.replication, replication.Options{
+			Source: func() string {
+				if source != "" {
+					return source
+				}
+				return a.Monitor.ActiveURL()
+			},
+			Token:     cfg.Cluster.Token,
+			BatchSize: cfg.Replication.BatchSize,
+			LongPoll:  cfg.Replication.LongPoll,
+			MaxLag:    cfg.Replication.MaxLag,
+		})
+		checker.AddDetail("replication", a.Replica.Check)
+	}
+
+	// 2. Handler 생성 (의존성 주입) ⭐
+	a.handlers = handlers{
+		todo:        handler.NewTodoHandler(a.repos.todos, a.repos.audits, a.Queue),
+		calendar:    handler.NewCalendarHandler(a.repos.todos, a.repos.calendarToken),
+		caldav:      handler.NewCalDAVHandler(a.repos.todos, a.repos.calendarToken, a.repos.audits),
+		sync:        handler.NewSyncHandler(a.repos.todos, a.repos.audits),
+		audit:       handler.NewAuditHandler(a.repos.audits),
+		history:     handler.NewHistoryHandler(a.repos.todos, a.repos.audits, cfg.Undo.Window),
+		analytics:   handler.NewAnalyticsHandler(a.repos.todos),
+		webhook:     handler.NewWebhookHandler(a.repos.webhooks),
+		queue:       handler.NewQueueHandler(a.repos.jobs),
+		backup:      handler.NewBackupHandler(a.Backups, a.backupKeep),
+		health:      handler.NewHealthHandler(checker),
+		cluster:     handler.NewClusterHandler(a.Monitor),
+		replication: handler.NewReplicationHandler(a.repos.replication, a.Replica),
+		config:      handler.NewConfigHandler(a.Reloader),
+	}
+	// 복제 모드에서는 남은 변경분을 받고 나서 승격
+	a.handlers.promote = a.handlers.todo.PromoteToActive
+	if a.Replica != nil {
+		a.handlers.promote = a.handlers.replication.Promote
+	}
+
+	a.Router = a.routes()
+	a.Reloader.OnReload(a.applyConfig)
+	return a
+}
+
+// restoreTerm: 저장된 임기를 이어받고, 이후 임기가 바뀔 때마다 저장
+// 재시작한 서버가 임기 0부터 다시 세면 다음 승격 때 옛 Active보다 작은 임기를 받아 split-brain 해소가 뒤집힐 수 있습니다.
+// 역할(global.SetActive)은 이 다음에 정해야 저장된 임기 + 1을 받습니다.
+func (a *App) restoreTerm() {
+	if term, err := a.repos.cluster.LoadTerm(); err != nil {
+		middleware.Log.Error("cluster term load failed: " + err.Error())
+	} else {
+		global.ObserveTerm(term)
+	}
+	global.OnTermChange(func(term int64) {
+		if err := a.repos.cluster.SaveTerm(term); err != nil {
+			middleware.Log.Error("cluster term save failed: " + err.Error())
+		}
+	})
+}
+
+// backupKeep: 지금 설정의 백업 보관 개수
+func (a *App) backupKeep() int {
+	return a.Reloader.Current().Backup.Keep
+}
+
+// applyConfig: 설정을 다시 읽었을 때 바로 적용되는 항목 반영 (config.IsReloadable)
+// 백업 보관 개수는 쓸 때마다 Reloader.Current()에서 읽으므로 따로 할 일이 없습니다.
+func (a *App) applyConfig(old, cfg *config.Config) {
+	if old.Log.Level != cfg.Log.Level {
+		if err := middleware.SetLogLevel(cfg.Log.Level); err != nil {
+			middleware.Log.Error("log level change failed: " + err.Error())
+		}
+	}
+	if old.Backup.Interval != cfg.Backup.Interval {
+		a.Queue.Every(model.JobBackup, cfg.Backup.Interval, nil)
+	}
+}
+
+// Start: heartbeat, 복제, 작업 큐, outbox, 웹훅 전송 시작 (모두 백그라운드, Active 전용 작업은 Standby에서 쉼)
+func (a *App) Start() {
+	cfg := a.Config
+	a.Monitor.Start()
+	if a.Replica != nil {
+		a.Replica.Start()
+	}
+
+	cron.RegisterStatsJob(a.Queue, a.repos.todos)
+	cron.RegisterDailyReportJob(a.Queue, a.repos.todos)
+	cron.RegisterIdempotencyCleanupJob(a.Queue, a.repos.idempotency)
+	cron.RegisterOutboxCleanupJob(a.Queue, a.repos.outbox, cfg.Outbox.Retention)
+	cron.RegisterQueueCleanupJob(a.Queue, a.repos.jobs, cfg.Jobs.Retention)
+	cron.RegisterBackupJob(a.Queue, a.Backups, a.Reloader.Current().Backup.Interval, a.backupKeep)
+	a.Queue.Start()
+	a.Dispatcher.Start()
+	webhook.NewWorker(a.repos.webhooks, webhook.Options{
+		MaxAttempts:  cfg.Webhook.MaxAttempts,
+		BaseBackoff:  cfg.Webhook.BaseBackoff,
+		MaxBackoff:   cfg.Webhook.MaxBackoff,
+		Timeout:      cfg.Webhook.Timeout,
+		PollInterval: cfg.Webhook.PollInterval,
+	}).Start()
+}
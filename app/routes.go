@@ -0,0 +1,157 @@
+package app
+
+import (
+	"go_study/middleware"
+
+	"github.com/gin-gonic/gin"
+
+	_ "go_study/docs"
+
+	swaggerFiles "github.com/swaggo/files"
+	ginSwagger "github.com/swaggo/gin-swagger"
+)
+
+// routes: 모든 API 경로 등록
+func (a *App) routes() *gin.Engine {
+	h := a.handlers
+
+	// Gin 라우팅 설정
+	// Default()는 기본 로거를 포함하므로, 우리가 만든 걸 쓰려면 New()로 빈 깡통을 만듦
+	r := gin.New()
+
+	// 🛡️ [추가] 보안 헤더, 다른 출처 프런트엔드용 CORS, 쿠키 세션용 CSRF (정적 파일에도 적용되도록 먼저 등록)
+	// 설정 파일을 다시 읽으면 다음 요청부터 바로 적용
+	r.Use(middleware.SecurityHeaders(a.Reloader.Current))
+	r.Use(middleware.CORS(a.Reloader.Current))
+	r.Use(middleware.CSRF(a.Reloader.Current))
+
+	// 🚀 [추가] 정적 파일(HTML/CSS) 서빙 설정
+	// "./static" 폴더를 "/view"라는 주소로 연결하거나, 파일 하나를 특정 주소에 연결
+	r.Static("/static", "./static")          // static 폴더 공개
+	r.StaticFile("/", "./static/index.html") // 루트(/) 접속 시 index.html 보여주기
+
+	// 미들웨어 부착
+	r.Use(gin.Recovery())
+	r.Use(middleware.RequestID()) // 로그와 감사 기록을 요청 단위로 묶기 위한 X-Request-ID
+	r.Use(middleware.ZapLogger())
+
+	// 🚀 [추가] 모바일 재시도로 인한 중복 생성 방지 (Idempotency-Key 헤더)
+	idempotent := middleware.Idempotency(a.repos.idempotency, a.Config.Idempotency.TTL)
+
+	// 📖 [추가] Standby 동작 방식: reject(전부 503) 또는 read_only(읽기는 처리, 쓰기는 Active로 안내)
+	standbyGuard := middleware.StandbyGuard(a.Config.Standby.Mode, a.Monitor.ActiveURL)
+
+	// 이제 핸들러가 메소드이므로 인스턴스(todoHandler)를 통해 호출합니다.
+	api := r.Group("/todos")
+	api.Use(standbyGuard)
+	{
+		api.GET("", h.todo.GetTodos)
+		api.POST("", idempotent, h.todo.AddTodo)
+		api.GET("/export", h.todo.ExportTodos)
+		api.POST("/import", h.todo.ImportTodos)
+		api.PATCH("/:id", h.todo.ToggleTodoStatus)
+		api.DELETE("/:id", h.todo.DeleteTodo)
+		api.POST("/:id/restore", h.todo.RestoreTodo)
+		api.GET("/:id/history", h.history.GetHistory)
+		api.POST("/:id/revert", h.history.RevertTodo)
+	}
+
+	// ↩️ [추가] 요청자의 마지막 변경 취소
+	r.POST("/undo", standbyGuard, h.history.Undo)
+
+	// 🔄 [추가] 오프라인 우선 클라이언트용 델타 동기화
+	syncGroup := r.Group("/sync")
+	syncGroup.Use(standbyGuard)
+	{
+		syncGroup.GET("", h.sync.Pull)
+		syncGroup.POST("", idempotent, h.sync.Push)
+	}
+
+	// 🔔 [추가] 웹훅 구독 관리와 전송 기록
+	hooks := r.Group("/webhooks")
+	hooks.Use(standbyGuard)
+	{
+		hooks.GET("", h.webhook.ListWebhooks)
+		hooks.POST("", h.webhook.CreateWebhook)
+		hooks.GET("/:id", h.webhook.GetWebhook)
+		hooks.PATCH("/:id", h.webhook.UpdateWebhook)
+		hooks.DELETE("/:id", h.webhook.DeleteWebhook)
+		hooks.GET("/:id/deliveries", h.webhook.ListDeliveries)
+		hooks.GET("/:id/deliveries/:delivery", h.webhook.GetDelivery)
+		hooks.POST("/:id/deliveries/:delivery/redeliver", h.webhook.Redeliver)
+	}
+
+	// 📅 [추가] 달력 앱 구독용 ICS 피드 (/calendar/{token}.ics)
+	r.GET("/calendar/:file", standbyGuard, h.calendar.GetFeed)
+
+	// 📅 [추가] iOS 미리 알림 / Thunderbird 동기화용 CalDAV (/caldav/{token}/todos/)
+	dav := r.Group("/caldav/:token", standbyGuard, h.caldav.RequireToken)
+	{
+		dav.Handle("OPTIONS", "/", h.caldav.Options)
+		dav.Handle("PROPFIND", "/", h.caldav.PropFindHome)
+		dav.Handle("OPTIONS", "/todos/", h.caldav.Options)
+		dav.Handle("PROPFIND", "/todos/", h.caldav.PropFindCalendar)
+		dav.Handle("REPORT", "/todos/", h.caldav.Report)
+		dav.Handle("OPTIONS", "/todos/:name", h.caldav.Options)
+		dav.Handle("PROPFIND", "/todos/:name", h.caldav.PropFindResource)
+		dav.GET("/todos/:name", h.caldav.GetResource)
+		dav.PUT("/todos/:name", h.caldav.PutResource)
+		dav.DELETE("/todos/:name", h.caldav.DeleteResource)
+	}
+
+	r.POST("/reports", idempotent, h.todo.GenerateDailyReport)
+	r.GET("/dashboard", h.todo.GetDashboard)
+	r.GET("/analytics", h.analytics.GetAnalytics)
+
+	// healthcheck, active-stanby 구조 (nginx용: Active + DB)
+	r.GET("/health", h.todo.HealthCheck)
+	// 🩺 [추가] 프로브 분리: live(프로세스 생존), ready(트래픽 받을 준비), details(점검별 상태)
+	r.GET("/health/live", h.health.Live)
+	r.GET("/health/ready", h.health.Ready)
+	r.GET("/health/details", h.health.Details)
+
+	// 🤝 서버 간 통신: Active를 강등시키거나 모든 할 일을 받아갈 수 있으므로 cluster.token 필수
+	// (peer나 복제를 쓰면 설정 검증에서 토큰을 요구하고, 토큰이 없는 서버는 이 경로를 전부 거절)
+	peer := r.Group("", middleware.RequirePeerToken(a.Config.Cluster.Token))
+	{
+		peer.POST("/cluster/heartbeat", h.cluster.Heartbeat)
+		peer.GET("/replication/changes", h.replication.GetChanges)
+	}
+
+	// 🚀 [추가] 관리자용 승격 API (Admin 그룹으로 묶는 게 좋음)
+	admin := r.Group("/admin")
+	// 🔐 [추가] server.tls.client_ca_file이 있으면 /admin은 클라이언트 인증서 필수 (mutual TLS, CN 허용 목록)
+	if a.Config.Server.TLS.Enabled && a.Config.Server.TLS.ClientCAFile != "" {
+		admin.Use(middleware.RequireClientCert(a.Reloader.Current))
+	}
+	admin.Use(middleware.AuditAdmin(a.repos.audits))
+	{
+		admin.POST("/promote", h.promote)
+		admin.POST("/demote", h.todo.DemoteToStandby)
+		admin.GET("/cluster", h.cluster.GetCluster)
+		admin.GET("/replication", h.replication.GetStatus)
+		admin.GET("/config", h.config.GetConfig)
+		admin.GET("/config/reload", h.config.GetReloadStatus)
+		admin.POST("/config/reload", h.config.ReloadConfig)
+
+		// 💾 [추가] 온라인 백업 / 목록
+		admin.POST("/backup", h.backup.CreateBackup)
+		admin.GET("/backups", h.backup.ListBackups)
+
+		admin.GET("/calendar-tokens", h.calendar.ListTokens)
+		admin.POST("/calendar-tokens", h.calendar.CreateToken)
+		admin.DELETE("/calendar-tokens/:id", h.calendar.DeleteToken)
+
+		// 🔍 [추가] 감사 로그 조회 / 내보내기
+		admin.GET("/audit", h.audit.ListEvents)
+		admin.GET("/audit/export", h.audit.ExportEvents)
+
+		// 🧰 [추가] 작업 큐 조회 / 실패 작업 재시도
+		admin.GET("/queue", h.queue.GetQueue)
+		admin.GET("/queue/:id", h.queue.GetJob)
+		admin.POST("/queue/:id/retry", h.queue.RetryJob)
+	}
+	r.GET("/swagger/*any", ginSwagger.WrapHandler(swaggerFiles.Handler))
+
+	return r
+}
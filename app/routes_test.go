@@ -0,0 +1,65 @@
+package app
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"go_study/config"
+	"go_study/global"
+	"go_study/middleware"
+	"go_study/migrate"
+
+	"github.com/gin-gonic/gin"
+	"github.com/glebarez/sqlite"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+// 서버 간 경로는 /admin의 클라이언트 인증서와 상관없이 cluster.token이 있어야 함
+func TestRoutes_PeerRoutesRequireClusterToken(t *testing.T) {
+	defer global.SetStandby()
+	for _, token := range []string{"s3cret", ""} {
+		dir := t.TempDir()
+		cfg := config.Default()
+		cfg.Database.File = filepath.Join(dir, "todos.db")
+		cfg.Log.Path = filepath.Join(dir, "server.log")
+		cfg.Backup.Dir = filepath.Join(dir, "backups")
+		cfg.Cluster.Token = token
+
+		db, err := gorm.Open(sqlite.Open(cfg.Database.File), &gorm.Config{Logger: logger.Default.LogMode(logger.Silent)})
+		require.NoError(t, err)
+		_, err = migrate.New(db, migrate.Options{}).Up(context.Background())
+		require.NoError(t, err)
+		gin.SetMode(gin.TestMode)
+		middleware.Log = zap.NewNop()
+		global.SetActive()
+		router := New(db, cfg).Router
+
+		send := func(method, path, header string) int {
+			req := httptest.NewRequest(method, path, strings.NewReader(`{"name":"app-2"}`))
+			req.Header.Set("Content-Type", "application/json")
+			if header != "" {
+				req.Header.Set("X-Cluster-Token", header)
+			}
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+			return w.Code
+		}
+		for _, route := range [][2]string{{"POST", "/cluster/heartbeat"}, {"GET", "/replication/changes?since=0"}} {
+			assert.Equal(t, http.StatusUnauthorized, send(route[0], route[1], ""), "%s %q", route[1], token)
+			assert.Equal(t, http.StatusUnauthorized, send(route[0], route[1], "guess"), "%s %q", route[1], token)
+			want := http.StatusOK
+			if token == "" {
+				want = http.StatusUnauthorized
+			}
+			assert.Equal(t, want, send(route[0], route[1], token), "%s %q", route[1], token)
+		}
+	}
+}
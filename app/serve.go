@@ -0,0 +1,65 @@
+package app
+
+import (
+	"net"
+	"net/http"
+	"time"
+
+	"go_study/certs"
+	"go_study/middleware"
+)
+
+// Serve: server.port에서 요청을 받음 (돌아오지 않음)
+// server.tls.enabled면 직접 HTTPS + HTTP/2로 서빙하고, 인증서 파일이 바뀌면 server.tls.reload_interval 안에 새 인증서로 바꿉니다.
+// server.tls.redirect_port가 있으면 그 주소로 오는 HTTP 요청은 HTTPS 주소로 보냅니다.
+func (a *App) Serve() error {
+	cfg := a.Config.Server
+	srv := &http.Server{
+		Addr:              cfg.Port,
+		Handler:           a.Router,
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+	if !cfg.TLS.Enabled {
+		return srv.ListenAndServe()
+	}
+
+	reloader, err := certs.NewReloader(cfg.TLS.CertFile, cfg.TLS.KeyFile, cfg.TLS.ClientCAFile)
+	if err != nil {
+		return err
+	}
+	reloader.Start(cfg.TLS.ReloadInterval)
+	srv.TLSConfig = reloader.TLSConfig()
+	middleware.Log.Info("🔐 HTTPS (HTTP/2) on " + cfg.Port + ", certificate expires " + reloader.NotAfter().Format(time.RFC3339))
+
+	if cfg.TLS.RedirectPort != "" {
+		redirect := &http.Server{
+			Addr:              cfg.TLS.RedirectPort,
+			Handler:           redirectToHTTPS(cfg.Port),
+			ReadHeaderTimeout: 10 * time.Second,
+		}
+		go func() {
+			middleware.Log.Info("↪️ HTTP → HTTPS redirect on " + cfg.TLS.RedirectPort)
+			if err := redirect.ListenAndServe(); err != nil {
+				middleware.Log.Error("redirect listener stopped: " + err.Error())
+			}
+		}()
+	}
+
+	// 인증서는 TLSConfig.GetCertificate에서 가져오므로 파일 이름은 비워 둠
+	return srv.ListenAndServeTLS("", "")
+}
+
+// redirectToHTTPS: 같은 호스트의 HTTPS 주소로 308 (메소드와 본문을 유지하도록 301 대신 308)
+func redirectToHTTPS(httpsAddr string) http.Handler {
+	_, port, _ := net.SplitHostPort(httpsAddr)
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		host := r.Host
+		if h, _, err := net.SplitHostPort(host); err == nil {
+			host = h
+		}
+		if port != "" && port != "443" {
+			host = net.JoinHostPort(host, port)
+		}
+		http.Redirect(w, r, "https://"+host+r.URL.RequestURI(), http.StatusPermanentRedirect)
+	})
+}
@@ -0,0 +1,287 @@
+// Package backup: 서버를 멈추지 않고 SQLite DB의 일관된 스냅샷을 만드는 백업과, 서버를 멈춘 상태에서 하는 복원
+// 백업은 VACUUM INTO로 만들기 때문에 쓰기가 진행 중이어도 어느 한 시점의 완전한 DB가 됩니다.
+package backup
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"go_study/migrate"
+	"go_study/model"
+
+	"github.com/glebarez/sqlite"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+// ErrDatabaseInUse: 복원할 DB를 다른 쪽이 쓰고 있음 (서버가 실행 중이거나 마이그레이션 중)
+var ErrDatabaseInUse = errors.New("database is in use")
+
+const sqliteHeader = "SQLite format 3\x00"
+
+// 백업 파일 이름: todos-20250102T150405.000Z.db (UTC)
+const (
+	filePrefix = "todos-"
+	fileSuffix = ".db"
+	timeLayout = "20060102T150405.000Z"
+)
+
+// Manager: 백업 디렉터리 관리
+type Manager struct {
+	db  *gorm.DB
+	dir string
+	mu  sync.Mutex // 백업/정리가 겹치지 않도록
+}
+
+// 생성자 (dir: 백업 파일을 둘 디렉터리, 없으면 만듦)
+func NewManager(db *gorm.DB, dir string) *Manager {
+	return &Manager{db: db, dir: dir}
+}
+
+// Dir: 백업 디렉터리
+func (m *Manager) Dir() string {
+	return m.dir
+}
+
+// Create: 지금 시점의 스냅샷 백업 만들기
+// 임시 파일에 쓴 뒤 이름을 바꾸므로, 목록에는 완성된 백업만 보입니다.
+func (m *Manager) Create(ctx context.Context) (model.BackupInfo, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if err := os.MkdirAll(m.dir, 0o755); err != nil {
+		return model.BackupInfo{}, err
+	}
+	now := time.Now().UTC()
+	name := filePrefix + now.Format(timeLayout) + fileSuffix
+	final := filepath.Join(m.dir, name)
+	tmp := final + ".tmp"
+	os.Remove(tmp) // 이전에 실패하고 남은 임시 파일
+
+	if err := m.db.WithContext(ctx).Exec("VACUUM INTO ?", tmp).Error; err != nil {
+		os.Remove(tmp)
+		return model.BackupInfo{}, fmt.Errorf("vacuum into %s: %w", tmp, err)
+	}
+	if err := os.Rename(tmp, final); err != nil {
+		os.Remove(tmp)
+		return model.BackupInfo{}, err
+	}
+	st, err := os.Stat(final)
+	if err != nil {
+		return model.BackupInfo{}, err
+	}
+	return model.BackupInfo{Name: name, SizeBytes: st.Size(), CreatedAt: now}, nil
+}
+
+// List: 백업 목록 (최신순)
+func (m *Manager) List() ([]model.BackupInfo, error) {
+	entries, err := os.ReadDir(m.dir)
+	if errors.Is(err, os.ErrNotExist) {
+		return []model.BackupInfo{}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	list := []model.BackupInfo{}
+	for _, e := range entries {
+		createdAt, ok := parseName(e.Name())
+		if !ok || e.IsDir() {
+			continue
+		}
+		fi, err := e.Info()
+		if err != nil {
+			continue
+		}
+		list = append(list, model.BackupInfo{Name: e.Name(), SizeBytes: fi.Size(), CreatedAt: createdAt})
+	}
+	sort.Slice(list, func(i, j int) bool { return list[i].CreatedAt.After(list[j].CreatedAt) })
+	return list, nil
+}
+
+// 백업 파일 이름이면 만든 시각
+func parseName(name string) (time.Time, bool) {
+	if !strings.HasPrefix(name, filePrefix) || !strings.HasSuffix(name, fileSuffix) {
+		return time.Time{}, false
+	}
+	t, err := time.Parse(timeLayout, strings.TrimSuffix(strings.TrimPrefix(name, filePrefix), fileSuffix))
+	return t, err == nil
+}
+
+// Prune: 최신 keep개만 남기고 삭제 (keep <= 0이면 아무것도 지우지 않음, 지운 이름 반환)
+func (m *Manager) Prune(keep int) ([]string, error) {
+	if keep <= 0 {
+		return nil, nil
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	list, err := m.List()
+	if err != nil || len(list) <= keep {
+		return nil, err
+	}
+	var removed []string
+	for _, b := range list[keep:] {
+		if err := os.Remove(filepath.Join(m.dir, b.Name)); err != nil {
+			return removed, err
+		}
+		removed = append(removed, b.Name)
+	}
+	return removed, nil
+}
+
+// Verify: SQLite 파일이 온전한지 확인 (PRAGMA integrity_check + todos 테이블 존재)
+func Verify(path string) error {
+	if _, err := os.Stat(path); err != nil {
+		return err
+	}
+	db, err := gorm.Open(sqlite.Open("file:"+path+"?mode=ro"), &gorm.Config{Logger: logger.Discard})
+	if err != nil {
+		return err
+	}
+	sqlDB, err := db.DB()
+	if err != nil {
+		return err
+	}
+	defer sqlDB.Close()
+
+	var results []string
+	if err := db.Raw("PRAGMA integrity_check").Scan(&results).Error; err != nil {
+		return fmt.Errorf("integrity check: %w", err)
+	}
+	if len(results) != 1 || results[0] != "ok" {
+		return fmt.Errorf("integrity check failed: %s", strings.Join(results, "; "))
+	}
+	if !db.Migrator().HasTable("todos") {
+		return errors.New("not a todo database: table todos is missing")
+	}
+	return nil
+}
+
+// Restore: 백업 파일로 DB 파일 교체 (서버를 멈춘 상태에서 실행)
+// 1. 백업을 검증하고 2. DB 옆에 임시 파일로 복사한 뒤 3. 기존 DB는 *.before-restore-<시각>으로 남기고 4. 이름을 바꿔 교체합니다.
+// 3~4는 기존 DB에 배타 잠금을 잡은 채로 하고, 다른 연결이 쓰는 중이거나 마이그레이션 잠금이 잡혀 있으면 ErrDatabaseInUse로 거부합니다.
+// 반환값은 기존 DB를 옮겨둔 경로 (기존 DB가 없었으면 빈 값)
+func Restore(backupPath, dbPath string) (string, error) {
+	if err := Verify(backupPath); err != nil {
+		return "", fmt.Errorf("backup %s is not usable: %w", backupPath, err)
+	}
+
+	tmp := dbPath + ".restore-tmp"
+	if err := copyFile(backupPath, tmp); err != nil {
+		os.Remove(tmp)
+		return "", err
+	}
+	// 복사본도 한 번 더 확인 (디스크 문제로 깨졌을 수 있음)
+	if err := Verify(tmp); err != nil {
+		os.Remove(tmp)
+		return "", fmt.Errorf("copied file is not usable: %w", err)
+	}
+
+	previous := ""
+	err := withExclusiveLock(dbPath, func() (err error) {
+		previous, err = swap(tmp, dbPath)
+		return err
+	})
+	if err != nil {
+		os.Remove(tmp)
+	}
+	return previous, err
+}
+
+// withExclusiveLock: 기존 DB에 배타 잠금(BEGIN EXCLUSIVE)을 잡은 동안 fn 실행 (기존 DB가 없으면 그냥 실행)
+// busy_timeout을 0으로 열기 때문에 다른 연결이 읽거나 쓰는 중이면 기다리지 않고 바로 거부합니다.
+func withExclusiveLock(dbPath string, fn func() error) error {
+	if _, err := os.Stat(dbPath); errors.Is(err, os.ErrNotExist) || !isSQLite(dbPath) {
+		// SQLite 파일이 아니면(깨져서 복원하는 경우) 그 파일로 일하는 서버도 없음
+		return fn()
+	}
+	db, err := gorm.Open(sqlite.Open(dbPath+"?_pragma=busy_timeout(0)"), &gorm.Config{Logger: logger.Discard})
+	if err != nil {
+		return err
+	}
+	sqlDB, err := db.DB()
+	if err != nil {
+		return err
+	}
+	defer sqlDB.Close()
+
+	return db.Connection(func(conn *gorm.DB) error {
+		if err := conn.Exec("BEGIN EXCLUSIVE").Error; err != nil {
+			return fmt.Errorf("%w: %v", ErrDatabaseInUse, err)
+		}
+		// 아무것도 쓰지 않았으므로 파일 이름이 바뀐 뒤에 풀어도 저널이 생기지 않음
+		defer conn.Exec("ROLLBACK")
+
+		// 헤더만 멀쩡하고 안이 깨졌으면 잠금 기록을 읽을 수 없으니 파일 잠금만으로 판단
+		if holder, err := migrate.New(conn, migrate.Options{}).Holder(); err == nil && holder != "" {
+			return fmt.Errorf("%w: migration lock held by %s", ErrDatabaseInUse, holder)
+		}
+		return fn()
+	})
+}
+
+// isSQLite: 빈 파일이거나 SQLite 헤더로 시작하는지
+func isSQLite(path string) bool {
+	f, err := os.Open(path)
+	if err != nil {
+		return false
+	}
+	defer f.Close()
+	header := make([]byte, len(sqliteHeader))
+	n, _ := io.ReadFull(f, header)
+	return n == 0 || string(header[:n]) == sqliteHeader
+}
+
+// swap: 기존 DB(와 저널 파일)를 옆으로 옮기고 tmp를 그 자리로
+func swap(tmp, dbPath string) (string, error) {
+	previous := ""
+	if _, err := os.Stat(dbPath); err == nil {
+		previous = dbPath + ".before-restore-" + time.Now().UTC().Format(timeLayout)
+		if err := os.Rename(dbPath, previous); err != nil {
+			return "", err
+		}
+	}
+	// 기존 DB의 WAL/공유 메모리 파일이 새 DB에 적용되면 안 됨
+	for _, ext := range []string{"-wal", "-shm", "-journal"} {
+		if _, err := os.Stat(dbPath + ext); err == nil {
+			if previous != "" {
+				os.Rename(dbPath+ext, previous+ext)
+			} else {
+				os.Remove(dbPath + ext)
+			}
+		}
+	}
+	if err := os.Rename(tmp, dbPath); err != nil {
+		return previous, err
+	}
+	return previous, nil
+}
+
+func copyFile(src, dst string) error {
+	in, err := os.Open(src)
+	if err != nil {
+		return err
+	}
+	defer in.Close()
+	out, err := os.OpenFile(dst, os.O_CREATE|os.O_TRUNC|os.O_WRONLY, 0o644)
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(out, in); err != nil {
+		out.Close()
+		return err
+	}
+	if err := out.Sync(); err != nil {
+		out.Close()
+		return err
+	}
+	return out.Close()
+}
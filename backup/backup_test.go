@@ -0,0 +1,129 @@
+package backup
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"go_study/model"
+
+	"github.com/glebarez/sqlite"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+)
+
+func openDB(t *testing.T, path string) *gorm.DB {
+	db, err := gorm.Open(sqlite.Open(path), &gorm.Config{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	db.AutoMigrate(&model.Todo{})
+	t.Cleanup(func() {
+		sqlDB, _ := db.DB()
+		sqlDB.Close()
+	})
+	return db
+}
+
+func TestManager_CreateListPrune(t *testing.T) {
+	dir := t.TempDir()
+	db := openDB(t, filepath.Join(dir, "todos.db"))
+	db.Create(&model.Todo{Task: "A"})
+	m := NewManager(db, filepath.Join(dir, "backups"))
+
+	// 1. 디렉터리가 없으면 빈 목록
+	list, err := m.List()
+	assert.NoError(t, err)
+	assert.Empty(t, list)
+
+	// 2. 백업 3개 (이름이 밀리초 단위라 겹치지 않게)
+	var names []string
+	for i := 0; i < 3; i++ {
+		info, err := m.Create(context.Background())
+		assert.NoError(t, err)
+		assert.Positive(t, info.SizeBytes)
+		names = append(names, info.Name)
+		time.Sleep(2 * time.Millisecond)
+	}
+	// 백업이 아닌 파일은 무시
+	os.WriteFile(filepath.Join(m.Dir(), "notes.txt"), []byte("x"), 0o644)
+	list, _ = m.List()
+	assert.Len(t, list, 3)
+	assert.Equal(t, names[2], list[0].Name)
+	assert.NoError(t, Verify(filepath.Join(m.Dir(), list[0].Name)))
+
+	// 3. 최신 2개만 남김
+	removed, err := m.Prune(2)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{names[0]}, removed)
+	list, _ = m.List()
+	assert.Len(t, list, 2)
+}
+
+func TestRestore(t *testing.T) {
+	dir := t.TempDir()
+	src := openDB(t, filepath.Join(dir, "todos.db"))
+	src.Create(&model.Todo{Task: "from backup"})
+	info, err := NewManager(src, dir).Create(context.Background())
+	assert.NoError(t, err)
+	backupPath := filepath.Join(dir, info.Name)
+
+	// 1. 깨진 파일은 복원하지 않고 기존 DB도 건드리지 않음
+	target := filepath.Join(dir, "restored.db")
+	os.WriteFile(target, []byte("old"), 0o644)
+	broken := filepath.Join(dir, "broken.db")
+	os.WriteFile(broken, []byte("definitely not sqlite"), 0o644)
+	_, err = Restore(broken, target)
+	assert.Error(t, err)
+	old, _ := os.ReadFile(target)
+	assert.Equal(t, "old", string(old))
+
+	// 2. 정상 백업으로 교체, 기존 DB는 옆에 남음
+	previous, err := Restore(backupPath, target)
+	assert.NoError(t, err)
+	old, _ = os.ReadFile(previous)
+	assert.Equal(t, "old", string(old))
+
+	var todos []model.Todo
+	openDB(t, target).Find(&todos)
+	assert.Len(t, todos, 1)
+	assert.Equal(t, "from backup", todos[0].Task)
+}
+
+func TestRestore_RefusesDatabaseInUse(t *testing.T) {
+	dir := t.TempDir()
+	src := openDB(t, filepath.Join(dir, "todos.db"))
+	src.Create(&model.Todo{Task: "from backup"})
+	info, _ := NewManager(src, dir).Create(context.Background())
+	backupPath := filepath.Join(dir, info.Name)
+
+	target := filepath.Join(dir, "live.db")
+	live := openDB(t, target)
+	live.Create(&model.Todo{Task: "live"})
+
+	// 1. 다른 연결(실행 중인 서버)이 쓰는 중이면 거부하고 기존 DB를 건드리지 않음
+	tx := live.Begin()
+	tx.Create(&model.Todo{Task: "in flight"})
+	_, err := Restore(backupPath, target)
+	assert.ErrorIs(t, err, ErrDatabaseInUse)
+	tx.Rollback()
+	var count int64
+	live.Model(&model.Todo{}).Count(&count)
+	assert.Equal(t, int64(1), count)
+	_, err = os.Stat(target + ".restore-tmp")
+	assert.True(t, os.IsNotExist(err))
+
+	// 2. 마이그레이션 잠금이 잡혀 있어도 거부
+	live.Exec("CREATE TABLE schema_migrations_lock (id integer PRIMARY KEY, holder text, acquired_at datetime)")
+	live.Exec("INSERT INTO schema_migrations_lock (id, holder, acquired_at) VALUES (1, 'app-2:1', ?)", time.Now().UTC())
+	_, err = Restore(backupPath, target)
+	assert.ErrorIs(t, err, ErrDatabaseInUse)
+	assert.ErrorContains(t, err, "app-2:1")
+
+	// 3. 잠금이 풀리면 복원
+	live.Exec("DELETE FROM schema_migrations_lock")
+	_, err = Restore(backupPath, target)
+	assert.NoError(t, err)
+}
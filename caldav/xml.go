@@ -0,0 +1,270 @@
+// Package caldav: CalDAV(RFC 4791) / WebDAV(RFC 4918) 요청 XML 해석과 multistatus 응답 작성
+// HTTP 처리(라우팅, 저장소 접근)는 handler 패키지에서 하고, 여기서는 XML만 다룹니다.
+package caldav
+
+import (
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"strings"
+)
+
+// XML 네임스페이스
+const (
+	NSDAV    = "DAV:"
+	NSCalDAV = "urn:ietf:params:xml:ns:caldav"
+	NSCS     = "http://calendarserver.org/ns/" // getctag (Apple 확장)
+)
+
+// 응답에서 쓰는 접두어
+var prefixes = map[string]string{
+	NSDAV:    "d",
+	NSCalDAV: "c",
+	NSCS:     "cs",
+}
+
+// PropName: 네임스페이스 + 로컬 이름 (예: {DAV:}getetag)
+type PropName = xml.Name
+
+// 자주 쓰는 속성 이름
+var (
+	PropResourceType         = PropName{Space: NSDAV, Local: "resourcetype"}
+	PropDisplayName          = PropName{Space: NSDAV, Local: "displayname"}
+	PropGetETag              = PropName{Space: NSDAV, Local: "getetag"}
+	PropGetContentType       = PropName{Space: NSDAV, Local: "getcontenttype"}
+	PropCurrentUserPrincipal = PropName{Space: NSDAV, Local: "current-user-principal"}
+	PropPrivilegeSet         = PropName{Space: NSDAV, Local: "current-user-privilege-set"}
+	PropSupportedReportSet   = PropName{Space: NSDAV, Local: "supported-report-set"}
+	PropCalendarHomeSet      = PropName{Space: NSCalDAV, Local: "calendar-home-set"}
+	PropSupportedComponents  = PropName{Space: NSCalDAV, Local: "supported-calendar-component-set"}
+	PropCalendarData         = PropName{Space: NSCalDAV, Local: "calendar-data"}
+	PropGetCTag              = PropName{Space: NSCS, Local: "getctag"}
+)
+
+// ---------------------------------------------------------------
+// 요청 해석
+// ---------------------------------------------------------------
+
+// 이름만 필요한 임의의 XML 요소
+type anyElement struct {
+	XMLName xml.Name
+}
+
+type propList struct {
+	Props []anyElement `xml:",any"`
+}
+
+func (p *propList) names() []PropName {
+	if p == nil {
+		return nil
+	}
+	names := make([]PropName, 0, len(p.Props))
+	for _, e := range p.Props {
+		names = append(names, e.XMLName)
+	}
+	return names
+}
+
+// PropFind: PROPFIND 요청 본문
+// AllProp이 true면 서버가 아는 모든 속성을 돌려줍니다. (본문이 비어 있는 경우 포함)
+type PropFind struct {
+	AllProp bool
+	Props   []PropName
+}
+
+// ParsePropFind: PROPFIND 본문 해석
+func ParsePropFind(r io.Reader) (PropFind, error) {
+	var req struct {
+		XMLName xml.Name  `xml:"DAV: propfind"`
+		AllProp *struct{} `xml:"DAV: allprop"`
+		Prop    *propList `xml:"DAV: prop"`
+	}
+	if err := xml.NewDecoder(r).Decode(&req); err != nil {
+		if errors.Is(err, io.EOF) {
+			return PropFind{AllProp: true}, nil
+		}
+		return PropFind{}, err
+	}
+	if req.AllProp != nil || req.Prop == nil {
+		return PropFind{AllProp: true}, nil
+	}
+	return PropFind{Props: req.Prop.names()}, nil
+}
+
+// Report: REPORT 요청 본문 (calendar-query 또는 calendar-multiget)
+type Report struct {
+	Kind  string     // "calendar-query" | "calendar-multiget"
+	Props []PropName // 돌려줄 속성 (비어 있으면 getetag + calendar-data)
+	Hrefs []string   // multiget 대상
+
+	// calendar-query 필터 중 이 서버가 이해하는 부분
+	Component        string // VTODO / VEVENT ... (comp-filter 두 번째 단계)
+	OnlyNotCompleted bool   // prop-filter COMPLETED + is-not-defined (미완료 항목만)
+}
+
+// 필터 XML 구조: <c:filter><c:comp-filter name="VCALENDAR"><c:comp-filter name="VTODO"> ...
+type compFilter struct {
+	Name        string       `xml:"name,attr"`
+	CompFilters []compFilter `xml:"urn:ietf:params:xml:ns:caldav comp-filter"`
+	PropFilters []struct {
+		Name         string    `xml:"name,attr"`
+		IsNotDefined *struct{} `xml:"urn:ietf:params:xml:ns:caldav is-not-defined"`
+	} `xml:"urn:ietf:params:xml:ns:caldav prop-filter"`
+}
+
+// ErrUnsupportedReport: 지원하지 않는 REPORT 종류 (sync-collection 등)
+var ErrUnsupportedReport = errors.New("unsupported report")
+
+// ParseReport: REPORT 본문 해석
+func ParseReport(r io.Reader) (Report, error) {
+	var req struct {
+		XMLName xml.Name
+		Prop    *propList `xml:"DAV: prop"`
+		Hrefs   []string  `xml:"DAV: href"`
+		Filter  *struct {
+			CompFilter compFilter `xml:"urn:ietf:params:xml:ns:caldav comp-filter"`
+		} `xml:"urn:ietf:params:xml:ns:caldav filter"`
+	}
+	if err := xml.NewDecoder(r).Decode(&req); err != nil {
+		return Report{}, err
+	}
+	if req.XMLName.Space != NSCalDAV ||
+		(req.XMLName.Local != "calendar-query" && req.XMLName.Local != "calendar-multiget") {
+		return Report{}, ErrUnsupportedReport
+	}
+
+	rep := Report{Kind: req.XMLName.Local, Props: req.Prop.names()}
+	for _, h := range req.Hrefs {
+		rep.Hrefs = append(rep.Hrefs, strings.TrimSpace(h))
+	}
+	if req.Filter != nil {
+		// VCALENDAR 아래의 첫 번째 comp-filter만 봄
+		for _, cf := range req.Filter.CompFilter.CompFilters {
+			rep.Component = strings.ToUpper(cf.Name)
+			for _, pf := range cf.PropFilters {
+				if strings.EqualFold(pf.Name, "COMPLETED") && pf.IsNotDefined != nil {
+					rep.OnlyNotCompleted = true
+				}
+			}
+			break
+		}
+	}
+	if len(rep.Props) == 0 {
+		rep.Props = []PropName{PropGetETag, PropCalendarData}
+	}
+	return rep, nil
+}
+
+// ---------------------------------------------------------------
+// 응답 작성
+// ---------------------------------------------------------------
+
+// Response: multistatus 안의 리소스 하나
+// Found에는 값이 있는 속성(이미 XML로 만든 내용), NotFound에는 모르는 속성 이름을 담습니다.
+type Response struct {
+	Href     string
+	Found    map[PropName]string
+	NotFound []PropName
+	Status   int // 0이 아니면 propstat 대신 상태만 돌려줌 (예: multiget의 404)
+}
+
+// NewResponse: 요청된 속성 목록에 맞춰 Found / NotFound를 나눔
+// available은 이 리소스가 가진 모든 속성(값은 XML 조각), allProp이면 전부 돌려줍니다.
+func NewResponse(href string, available map[PropName]string, requested []PropName, allProp bool) Response {
+	resp := Response{Href: href, Found: map[PropName]string{}}
+	if allProp {
+		resp.Found = available
+		return resp
+	}
+	for _, name := range requested {
+		if v, ok := available[name]; ok {
+			resp.Found[name] = v
+		} else {
+			resp.NotFound = append(resp.NotFound, name)
+		}
+	}
+	return resp
+}
+
+// MultiStatus: 207 응답 본문 작성
+func MultiStatus(responses []Response) string {
+	var b strings.Builder
+	b.WriteString(`<?xml version="1.0" encoding="utf-8"?>` + "\n")
+	b.WriteString(`<d:multistatus xmlns:d="DAV:" xmlns:c="urn:ietf:params:xml:ns:caldav" xmlns:cs="http://calendarserver.org/ns/">`)
+	for _, r := range responses {
+		b.WriteString("<d:response><d:href>")
+		b.WriteString(Escape(r.Href))
+		b.WriteString("</d:href>")
+		if r.Status != 0 {
+			b.WriteString("<d:status>" + statusLine(r.Status) + "</d:status>")
+		} else {
+			if len(r.Found) > 0 {
+				b.WriteString("<d:propstat><d:prop>")
+				for _, name := range sortedNames(r.Found) {
+					writeElement(&b, name, r.Found[name])
+				}
+				b.WriteString("</d:prop><d:status>" + statusLine(200) + "</d:status></d:propstat>")
+			}
+			if len(r.NotFound) > 0 {
+				b.WriteString("<d:propstat><d:prop>")
+				for _, name := range r.NotFound {
+					writeElement(&b, name, "")
+				}
+				b.WriteString("</d:prop><d:status>" + statusLine(404) + "</d:status></d:propstat>")
+			}
+		}
+		b.WriteString("</d:response>")
+	}
+	b.WriteString("</d:multistatus>\n")
+	return b.String()
+}
+
+// Href: <d:href> 요소 (current-user-principal 같은 속성 값용)
+func Href(path string) string {
+	return "<d:href>" + Escape(path) + "</d:href>"
+}
+
+// Escape: XML 텍스트 이스케이프
+func Escape(s string) string {
+	var b strings.Builder
+	xml.EscapeText(&b, []byte(s))
+	return b.String()
+}
+
+// 속성 하나를 <접두어:이름>값</접두어:이름> 으로 씀 (모르는 네임스페이스는 xmlns를 직접 붙임)
+func writeElement(b *strings.Builder, name PropName, inner string) {
+	tag := name.Local
+	attr := ""
+	if p, ok := prefixes[name.Space]; ok {
+		tag = p + ":" + name.Local
+	} else if name.Space != "" {
+		attr = ` xmlns="` + Escape(name.Space) + `"`
+	}
+	if inner == "" {
+		b.WriteString("<" + tag + attr + "/>")
+		return
+	}
+	b.WriteString("<" + tag + attr + ">" + inner + "</" + tag + ">")
+}
+
+// 응답 순서를 일정하게 (테스트와 디버깅 편의)
+func sortedNames(m map[PropName]string) []PropName {
+	names := make([]PropName, 0, len(m))
+	for name := range m {
+		names = append(names, name)
+	}
+	sort.Slice(names, func(i, j int) bool {
+		if names[i].Space != names[j].Space {
+			return names[i].Space < names[j].Space
+		}
+		return names[i].Local < names[j].Local
+	})
+	return names
+}
+
+func statusLine(code int) string {
+	return fmt.Sprintf("HTTP/1.1 %d %s", code, http.StatusText(code))
+}
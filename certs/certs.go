@@ -0,0 +1,185 @@
+// Package certs: 서버가 직접 HTTPS로 서빙할 때 쓰는 인증서 (nginx 없이 단독 실행)
+// 인증서/키/클라이언트 CA 파일을 주기적으로 확인해서 바뀌었으면 다시 읽으므로, 인증서를 갱신해도 재시작하지 않아도 됩니다.
+// 새 파일을 읽지 못하면(키와 인증서가 짝이 안 맞거나 쓰는 도중이면) 이전 인증서를 계속 씁니다.
+package certs
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"sync"
+	"time"
+)
+
+// Reloader: 현재 인증서와 클라이언트 CA
+type Reloader struct {
+	certFile string
+	keyFile  string
+	caFile   string // 비어 있으면 클라이언트 인증서를 요청하지 않음
+
+	mu        sync.RWMutex
+	cert      *tls.Certificate
+	clientCAs *x509.CertPool
+	stamp     string // 마지막으로 읽은 파일들의 크기/수정 시각
+	loadedAt  time.Time
+}
+
+// NewReloader: 파일을 한 번 읽어서 만듦 (처음부터 읽지 못하면 에러)
+func NewReloader(certFile, keyFile, caFile string) (*Reloader, error) {
+	r := &Reloader{certFile: certFile, keyFile: keyFile, caFile: caFile}
+	if _, err := r.Reload(); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+// Start: interval마다 파일이 바뀌었는지 확인 (0이면 다시 읽지 않음)
+func (r *Reloader) Start(interval time.Duration) {
+	if interval <= 0 {
+		return
+	}
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for range ticker.C {
+			if changed, err := r.Reload(); err != nil {
+				log.Printf("⚠️ [TLS] 인증서를 다시 읽지 못해 이전 인증서를 계속 사용: %v\n", err)
+			} else if changed {
+				log.Printf("🔐 [TLS] 인증서 교체: %s (만료 %s)\n", r.certFile, r.NotAfter().Format(time.RFC3339))
+			}
+		}
+	}()
+}
+
+// Reload: 파일이 마지막으로 읽은 뒤 바뀌었으면 다시 읽음 (바뀌지 않았으면 false, nil)
+func (r *Reloader) Reload() (bool, error) {
+	stamp, err := r.fileStamp()
+	if err != nil {
+		return false, err
+	}
+	r.mu.RLock()
+	same := stamp == r.stamp
+	r.mu.RUnlock()
+	if same {
+		return false, nil
+	}
+
+	cert, err := tls.LoadX509KeyPair(r.certFile, r.keyFile)
+	if err != nil {
+		return false, fmt.Errorf("load %s / %s: %w", r.certFile, r.keyFile, err)
+	}
+	if cert.Leaf == nil && len(cert.Certificate) > 0 {
+		cert.Leaf, _ = x509.ParseCertificate(cert.Certificate[0])
+	}
+	var pool *x509.CertPool
+	if r.caFile != "" {
+		pem, err := os.ReadFile(r.caFile)
+		if err != nil {
+			return false, err
+		}
+		pool = x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(pem) {
+			return false, fmt.Errorf("%s: no PEM certificates", r.caFile)
+		}
+	}
+
+	r.mu.Lock()
+	r.cert, r.clientCAs, r.stamp, r.loadedAt = &cert, pool, stamp, time.Now()
+	r.mu.Unlock()
+	return true, nil
+}
+
+// fileStamp: 파일들의 크기와 수정 시각 (이 값이 바뀌면 다시 읽음)
+func (r *Reloader) fileStamp() (string, error) {
+	var stamp string
+	for _, path := range []string{r.certFile, r.keyFile, r.caFile} {
+		if path == "" {
+			continue
+		}
+		st, err := os.Stat(path)
+		if err != nil {
+			return "", err
+		}
+		stamp += fmt.Sprintf("%s:%d:%d;", path, st.Size(), st.ModTime().UnixNano())
+	}
+	return stamp, nil
+}
+
+// Certificate: 지금 쓰는 서버 인증서
+func (r *Reloader) Certificate() *tls.Certificate {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return r.cert
+}
+
+// NotAfter: 지금 쓰는 서버 인증서의 만료 시각
+func (r *Reloader) NotAfter() time.Time {
+	if c := r.Certificate(); c != nil && c.Leaf != nil {
+		return c.Leaf.NotAfter
+	}
+	return time.Time{}
+}
+
+// GetCertificate: tls.Config.GetCertificate (연결마다 현재 인증서)
+func (r *Reloader) GetCertificate(*tls.ClientHelloInfo) (*tls.Certificate, error) {
+	if c := r.Certificate(); c != nil {
+		return c, nil
+	}
+	return nil, errors.New("no certificate loaded")
+}
+
+// TLSConfig: HTTP/2를 켠 서버 설정
+// 클라이언트 CA가 있으면 인증서를 요청하지만(VerifyClientCertIfGiven) 없어도 연결은 받습니다.
+// 인증서가 꼭 필요한 경로(/admin)는 middleware.RequireClientCert가, 서버 간 경로는 middleware.RequirePeerToken이 막습니다.
+func (r *Reloader) TLSConfig() *tls.Config {
+	base := &tls.Config{
+		MinVersion:     tls.VersionTLS12,
+		NextProtos:     []string{"h2", "http/1.1"},
+		GetCertificate: r.GetCertificate,
+	}
+	if r.caFile == "" {
+		return base
+	}
+	base.ClientAuth = tls.VerifyClientCertIfGiven
+	// CA 파일도 다시 읽을 수 있도록 연결마다 현재 CA로 설정을 만듦
+	base.GetConfigForClient = func(*tls.ClientHelloInfo) (*tls.Config, error) {
+		r.mu.RLock()
+		pool := r.clientCAs
+		r.mu.RUnlock()
+		cfg := base.Clone()
+		cfg.GetConfigForClient = nil
+		cfg.ClientCAs = pool
+		return cfg, nil
+	}
+	return base
+}
+
+// ClientTLSConfig: 서버에 접속하는 CLI용 (caFile로 서버 인증서 확인, certFile/keyFile은 mutual TLS용 클라이언트 인증서)
+// 모두 비어 있으면 nil (시스템 기본값)
+func ClientTLSConfig(caFile, certFile, keyFile string) (*tls.Config, error) {
+	if caFile == "" && certFile == "" && keyFile == "" {
+		return nil, nil
+	}
+	cfg := &tls.Config{MinVersion: tls.VersionTLS12}
+	if caFile != "" {
+		pem, err := os.ReadFile(caFile)
+		if err != nil {
+			return nil, err
+		}
+		cfg.RootCAs = x509.NewCertPool()
+		if !cfg.RootCAs.AppendCertsFromPEM(pem) {
+			return nil, fmt.Errorf("%s: no PEM certificates", caFile)
+		}
+	}
+	if certFile != "" || keyFile != "" {
+		cert, err := tls.LoadX509KeyPair(certFile, keyFile)
+		if err != nil {
+			return nil, err
+		}
+		cfg.Certificates = []tls.Certificate{cert}
+	}
+	return cfg, nil
+}
@@ -0,0 +1,139 @@
+package certs
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// issue: parent(nil이면 자체 서명)가 서명한 인증서와 키를 PEM 파일로 저장
+func issue(t *testing.T, dir, name string, parent *x509.Certificate, parentKey *ecdsa.PrivateKey, isCA bool) (*x509.Certificate, *ecdsa.PrivateKey, string, string) {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	serial, _ := rand.Int(rand.Reader, big.NewInt(1<<62))
+	tmpl := &x509.Certificate{
+		SerialNumber: serial,
+		Subject:      pkix.Name{CommonName: name},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		DNSNames:     []string{"localhost"},
+		IPAddresses:  []net.IP{net.ParseIP("127.0.0.1")},
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth, x509.ExtKeyUsageClientAuth},
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+	}
+	if isCA {
+		tmpl.IsCA, tmpl.BasicConstraintsValid = true, true
+		tmpl.KeyUsage |= x509.KeyUsageCertSign
+	}
+	if parent == nil {
+		parent, parentKey = tmpl, key
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, parent, &key.PublicKey, parentKey)
+	require.NoError(t, err)
+	cert, _ := x509.ParseCertificate(der)
+
+	keyDER, _ := x509.MarshalECPrivateKey(key)
+	certFile, keyFile := filepath.Join(dir, name+".crt"), filepath.Join(dir, name+".key")
+	require.NoError(t, os.WriteFile(certFile, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}), 0o600))
+	require.NoError(t, os.WriteFile(keyFile, pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER}), 0o600))
+	return cert, key, certFile, keyFile
+}
+
+func TestReloader_ServesHTTP2AndReloads(t *testing.T) {
+	dir := t.TempDir()
+	ca, caKey, caFile, _ := issue(t, dir, "ca", nil, nil, true)
+	_, _, certFile, keyFile := issue(t, dir, "server", ca, caKey, false)
+
+	r, err := NewReloader(certFile, keyFile, caFile)
+	require.NoError(t, err)
+	first := r.Certificate().Leaf.SerialNumber
+
+	srv := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		cn := ""
+		if len(req.TLS.VerifiedChains) > 0 {
+			cn = req.TLS.VerifiedChains[0][0].Subject.CommonName
+		}
+		w.Header().Set("X-Proto", req.Proto)
+		w.Header().Set("X-Client-CN", cn)
+	}))
+	srv.TLS = r.TLSConfig()
+	srv.EnableHTTP2 = true
+	srv.StartTLS()
+	defer srv.Close()
+
+	get := func(clientCfg *tls.Config) (*http.Response, error) {
+		hc := &http.Client{Transport: &http.Transport{TLSClientConfig: clientCfg, ForceAttemptHTTP2: true}}
+		resp, err := hc.Get(srv.URL)
+		if err == nil {
+			resp.Body.Close()
+		}
+		return resp, err
+	}
+
+	// 1. CA로 서버 인증서 확인, HTTP/2, 클라이언트 인증서 없이도 연결됨
+	clientCfg, err := ClientTLSConfig(caFile, "", "")
+	require.NoError(t, err)
+	resp, err := get(clientCfg)
+	require.NoError(t, err)
+	assert.Equal(t, "HTTP/2.0", resp.Header.Get("X-Proto"))
+	assert.Empty(t, resp.Header.Get("X-Client-CN"))
+
+	// 2. CA가 서명한 클라이언트 인증서는 확인된 체인으로 전달
+	_, _, adminCert, adminKey := issue(t, dir, "ops-admin", ca, caKey, false)
+	clientCfg, err = ClientTLSConfig(caFile, adminCert, adminKey)
+	require.NoError(t, err)
+	resp, err = get(clientCfg)
+	require.NoError(t, err)
+	assert.Equal(t, "ops-admin", resp.Header.Get("X-Client-CN"))
+
+	// 3. 다른 CA가 서명한 클라이언트 인증서는 인정하지 않음 (클라이언트가 보내지 않거나, 보내면 handshake 실패)
+	other, otherKey, _, _ := issue(t, t.TempDir(), "other-ca", nil, nil, true)
+	_, _, strangerCert, strangerKey := issue(t, dir, "stranger", other, otherKey, false)
+	clientCfg, err = ClientTLSConfig(caFile, strangerCert, strangerKey)
+	require.NoError(t, err)
+	if resp, err = get(clientCfg); err == nil {
+		assert.Empty(t, resp.Header.Get("X-Client-CN"))
+	}
+
+	// 4. 파일이 그대로면 다시 읽지 않음
+	changed, err := r.Reload()
+	require.NoError(t, err)
+	assert.False(t, changed)
+
+	// 5. 깨진 파일로 바뀌면 이전 인증서를 계속 씀
+	require.NoError(t, os.WriteFile(certFile, []byte("garbage"), 0o600))
+	_, err = r.Reload()
+	assert.Error(t, err)
+	assert.Equal(t, first, r.Certificate().Leaf.SerialNumber)
+
+	// 6. 새 인증서로 바뀌면 다음 연결부터 새 인증서
+	issue(t, dir, "server", ca, caKey, false)
+	changed, err = r.Reload()
+	require.NoError(t, err)
+	assert.True(t, changed)
+	second := r.Certificate().Leaf.SerialNumber
+	assert.NotEqual(t, first, second)
+
+	clientCfg, _ = ClientTLSConfig(caFile, "", "")
+	hc := &http.Client{Transport: &http.Transport{TLSClientConfig: clientCfg}}
+	resp, err = hc.Get(srv.URL)
+	require.NoError(t, err)
+	resp.Body.Close()
+	assert.Equal(t, second, resp.TLS.PeerCertificates[0].SerialNumber)
+}
@@ -0,0 +1,217 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+
+	"go_study/model"
+)
+
+// peer끼리 주고받는 요청(heartbeat, 변경분)의 공유 토큰 헤더 (서버의 cluster.TokenHeader)
+const clusterTokenHeader = "X-Cluster-Token"
+
+// 서버마다 결과가 다른 요청(역할, 헬스 체크)은 failover하면 엉뚱한 서버의 답을 받으므로
+// 첫 번째 주소(또는 Node로 고른 서버)에만 보냅니다.
+func (c *Client) first() *Client {
+	return c.Node(c.baseURLs[0])
+}
+
+// Promote: POST /admin/promote (이 서버를 Active로, 복제 모드면 남은 변경분을 받은 뒤)
+func (c *Client) Promote(ctx context.Context) error {
+	return c.first().do(ctx, http.MethodPost, "/admin/promote", nil, nil, nil)
+}
+
+// Demote: POST /admin/demote (이 서버를 Standby로)
+func (c *Client) Demote(ctx context.Context) error {
+	return c.first().do(ctx, http.MethodPost, "/admin/demote", nil, nil, nil)
+}
+
+// Health: GET /health (nginx용: Active이고 DB가 살아 있으면 nil)
+func (c *Client) Health(ctx context.Context) error {
+	return c.first().do(ctx, http.MethodGet, "/health", nil, nil, nil)
+}
+
+// Live: GET /health/live
+func (c *Client) Live(ctx context.Context) (model.LiveStatus, error) {
+	var status model.LiveStatus
+	err := c.first().do(ctx, http.MethodGet, "/health/live", nil, nil, &status)
+	return status, err
+}
+
+// Ready: GET /health/ready (준비가 안 됐으면 503 에러와 함께 어떤 점검이 실패했는지 담긴 리포트)
+func (c *Client) Ready(ctx context.Context) (model.HealthReport, error) {
+	var report model.HealthReport
+	err := c.first().do(ctx, http.MethodGet, "/health/ready", nil, nil, &report)
+	var apiErr *APIError
+	if errors.As(err, &apiErr) && apiErr.Data != nil {
+		json.Unmarshal(apiErr.Data, &report)
+	}
+	return report, err
+}
+
+// HealthDetails: GET /health/details
+func (c *Client) HealthDetails(ctx context.Context) (model.HealthReport, error) {
+	var report model.HealthReport
+	err := c.first().do(ctx, http.MethodGet, "/health/details", nil, nil, &report)
+	return report, err
+}
+
+// ClusterStatus: GET /admin/cluster
+func (c *Client) ClusterStatus(ctx context.Context) (model.ClusterStatus, error) {
+	var status model.ClusterStatus
+	err := c.first().do(ctx, http.MethodGet, "/admin/cluster", nil, nil, &status)
+	return status, err
+}
+
+// Heartbeat: POST /cluster/heartbeat (서버 간 통신용, token은 cluster.token)
+func (c *Client) Heartbeat(ctx context.Context, token string, self model.NodeStatus) (model.NodeStatus, error) {
+	var peer model.NodeStatus
+	err := c.first().do(ctx, http.MethodPost, "/cluster/heartbeat", nil, self, &peer, WithHeader(clusterTokenHeader, token))
+	return peer, err
+}
+
+// ReplicationStatus: GET /admin/replication (복제 모드가 아니면 404)
+func (c *Client) ReplicationStatus(ctx context.Context) (model.ReplicationStatus, error) {
+	var status model.ReplicationStatus
+	err := c.first().do(ctx, http.MethodGet, "/admin/replication", nil, nil, &status)
+	return status, err
+}
+
+// ReplicationChanges: GET /replication/changes (Active에서 since 이후 변경분, wait만큼 long-poll)
+func (c *Client) ReplicationChanges(ctx context.Context, token string, since int64, limit int, wait time.Duration) (model.ChangeBatch, error) {
+	var batch model.ChangeBatch
+	q := url.Values{"since": {strconv.FormatInt(since, 10)}}
+	if limit > 0 {
+		q.Set("limit", strconv.Itoa(limit))
+	}
+	if wait > 0 {
+		q.Set("wait", wait.String())
+	}
+	err := c.do(ctx, http.MethodGet, "/replication/changes", q, nil, &batch, WithHeader(clusterTokenHeader, token))
+	return batch, err
+}
+
+// CreateBackup: POST /admin/backup
+func (c *Client) CreateBackup(ctx context.Context) (model.BackupInfo, error) {
+	var info model.BackupInfo
+	err := c.do(ctx, http.MethodPost, "/admin/backup", nil, nil, &info)
+	return info, err
+}
+
+// ListBackups: GET /admin/backups
+func (c *Client) ListBackups(ctx context.Context) ([]model.BackupInfo, error) {
+	var infos []model.BackupInfo
+	err := c.do(ctx, http.MethodGet, "/admin/backups", nil, nil, &infos)
+	return infos, err
+}
+
+// ListCalendarTokens: GET /admin/calendar-tokens
+func (c *Client) ListCalendarTokens(ctx context.Context) ([]model.CalendarToken, error) {
+	var tokens []model.CalendarToken
+	err := c.do(ctx, http.MethodGet, "/admin/calendar-tokens", nil, nil, &tokens)
+	return tokens, err
+}
+
+// CreateCalendarToken: POST /admin/calendar-tokens
+func (c *Client) CreateCalendarToken(ctx context.Context, name string) (model.CalendarToken, error) {
+	var token model.CalendarToken
+	err := c.do(ctx, http.MethodPost, "/admin/calendar-tokens", nil, model.CreateCalendarTokenInput{Name: name}, &token)
+	return token, err
+}
+
+// DeleteCalendarToken: DELETE /admin/calendar-tokens/{id}
+func (c *Client) DeleteCalendarToken(ctx context.Context, id uint) error {
+	return c.do(ctx, http.MethodDelete, fmt.Sprintf("/admin/calendar-tokens/%d", id), nil, nil, nil)
+}
+
+// CalendarFeed: GET /calendar/{token}.ics (events면 마감일을 VEVENT로도), 다 읽은 뒤 Close 필요
+func (c *Client) CalendarFeed(ctx context.Context, token string, events bool) (io.ReadCloser, error) {
+	q := url.Values{}
+	if events {
+		q.Set("events", "true")
+	}
+	return c.stream(ctx, "/calendar/"+url.PathEscape(token)+".ics", q)
+}
+
+// AuditFilter: 감사 로그 조회 조건 (비어 있으면 전체)
+type AuditFilter struct {
+	Actor     string
+	Action    string
+	EntityID  string
+	RequestID string
+	From      time.Time // 이 시각 이후 (포함)
+	To        time.Time // 이 시각 이전 (미포함)
+}
+
+func (f AuditFilter) values() url.Values {
+	q := url.Values{}
+	for key, v := range map[string]string{"actor": f.Actor, "action": f.Action, "entity_id": f.EntityID, "request_id": f.RequestID} {
+		if v != "" {
+			q.Set(key, v)
+		}
+	}
+	if !f.From.IsZero() {
+		q.Set("from", f.From.Format(time.RFC3339))
+	}
+	if !f.To.IsZero() {
+		q.Set("to", f.To.Format(time.RFC3339))
+	}
+	return q
+}
+
+// ListAuditEvents: GET /admin/audit (최신순)
+func (c *Client) ListAuditEvents(ctx context.Context, filter AuditFilter, page Page) ([]model.AuditEvent, error) {
+	var events []model.AuditEvent
+	err := c.do(ctx, http.MethodGet, "/admin/audit", page.apply(filter.values()), nil, &events)
+	return events, err
+}
+
+// ExportAuditEvents: GET /admin/audit/export (format: csv | jsonl), 다 읽은 뒤 Close 필요
+func (c *Client) ExportAuditEvents(ctx context.Context, format string, filter AuditFilter) (io.ReadCloser, error) {
+	q := filter.values()
+	if format != "" {
+		q.Set("format", format)
+	}
+	return c.stream(ctx, "/admin/audit/export", q)
+}
+
+// JobFilter: 작업 큐 조회 조건 (비어 있으면 전체)
+type JobFilter struct {
+	Status string // queued | running | succeeded | failed
+	Type   string // 예: report.daily
+}
+
+// Queue: GET /admin/queue (상태별 개수와 작업 목록)
+func (c *Client) Queue(ctx context.Context, filter JobFilter, page Page) (model.QueueOverview, error) {
+	var overview model.QueueOverview
+	q := url.Values{}
+	if filter.Status != "" {
+		q.Set("status", filter.Status)
+	}
+	if filter.Type != "" {
+		q.Set("type", filter.Type)
+	}
+	err := c.do(ctx, http.MethodGet, "/admin/queue", page.apply(q), nil, &overview)
+	return overview, err
+}
+
+// GetJob: GET /admin/queue/{id}
+func (c *Client) GetJob(ctx context.Context, id uint) (model.Job, error) {
+	var job model.Job
+	err := c.do(ctx, http.MethodGet, fmt.Sprintf("/admin/queue/%d", id), nil, nil, &job)
+	return job, err
+}
+
+// RetryJob: POST /admin/queue/{id}/retry (실패한 작업만, 아니면 409)
+func (c *Client) RetryJob(ctx context.Context, id uint) (model.Job, error) {
+	var job model.Job
+	err := c.do(ctx, http.MethodPost, fmt.Sprintf("/admin/queue/%d/retry", id), nil, nil, &job)
+	return job, err
+}
@@ -0,0 +1,330 @@
+// Package client: Todo API를 Go에서 호출하기 위한 클라이언트
+// 응답의 표준 포맷(model.WebResponse)을 풀어서 data를 타입이 있는 값으로 돌려주고, 실패는 *APIError로 돌려줍니다.
+//
+// 여러 서버 주소를 주면(WithFailover) Standby가 503으로 거절하거나 연결이 안 될 때 다음 서버로 넘어가고,
+// 모든 서버가 거절하면 잠시 기다렸다가(승격 중일 수 있으므로) 다시 시도합니다.
+package client
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"go_study/model"
+)
+
+// APIError: 서버가 2xx가 아닌 상태로 응답한 경우
+type APIError struct {
+	StatusCode int
+	Message    string
+	Data       json.RawMessage // 에러 응답에 담긴 data (예: 503 Standby의 Active 주소, 409 충돌 시 서버 값)
+	URL        string          // 마지막으로 시도한 주소
+}
+
+func (e *APIError) Error() string {
+	if e.Message == "" {
+		return fmt.Sprintf("api error: %d %s", e.StatusCode, http.StatusText(e.StatusCode))
+	}
+	return fmt.Sprintf("api error: %d %s", e.StatusCode, e.Message)
+}
+
+// StatusCode: err가 *APIError면 HTTP 상태 코드 (아니면 0)
+func StatusCode(err error) int {
+	var apiErr *APIError
+	if errors.As(err, &apiErr) {
+		return apiErr.StatusCode
+	}
+	return 0
+}
+
+// IsNotFound: 404 응답인지
+func IsNotFound(err error) bool {
+	return StatusCode(err) == http.StatusNotFound
+}
+
+// Client: Todo API 클라이언트 (여러 고루틴에서 같이 써도 안전)
+type Client struct {
+	baseURLs   []string
+	preferred  atomic.Int32 // 마지막으로 성공한 주소 (다음 요청은 여기부터)
+	httpClient *http.Client
+	token      string
+	actor      string
+
+	retries  int           // 모든 주소가 실패했을 때 다시 도는 횟수
+	backoff  time.Duration // 다시 돌기 전 대기 (매번 2배)
+	failover bool          // false면 503/연결 실패여도 다른 주소로 넘어가지 않음 (특정 서버 관리용)
+}
+
+// Option: 생성자 옵션
+type Option func(*Client)
+
+// WithHTTPClient: 직접 만든 http.Client 사용 (기본: 30초 제한)
+func WithHTTPClient(hc *http.Client) Option {
+	return func(c *Client) { c.httpClient = hc }
+}
+
+// WithToken: 모든 요청에 Authorization: Bearer <token> 헤더 (앞단 프록시 인증용)
+func WithToken(token string) Option {
+	return func(c *Client) { c.token = token }
+}
+
+// WithActor: 모든 요청에 X-Actor 헤더 (감사 로그의 요청자, POST /undo에는 필수)
+func WithActor(actor string) Option {
+	return func(c *Client) { c.actor = actor }
+}
+
+// WithFailover: baseURL 다음에 시도할 서버 주소들 (예: Active/Standby 두 서버를 nginx 없이 직접 부를 때)
+func WithFailover(urls ...string) Option {
+	return func(c *Client) {
+		for _, u := range urls {
+			c.baseURLs = append(c.baseURLs, strings.TrimRight(u, "/"))
+		}
+	}
+}
+
+// WithRetry: 모든 주소가 503/연결 실패일 때 다시 도는 횟수와 첫 대기 시간 (기본 3번, 200ms부터 2배씩)
+func WithRetry(retries int, backoff time.Duration) Option {
+	return func(c *Client) {
+		c.retries = retries
+		c.backoff = backoff
+	}
+}
+
+// 생성자 (baseURL 예: http://localhost:8080)
+func New(baseURL string, opts ...Option) *Client {
+	c := &Client{
+		baseURLs:   []string{strings.TrimRight(baseURL, "/")},
+		httpClient: &http.Client{Timeout: 30 * time.Second},
+		retries:    3,
+		backoff:    200 * time.Millisecond,
+		failover:   true,
+	}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c
+}
+
+// BaseURLs: 시도하는 서버 주소 (순서대로)
+func (c *Client) BaseURLs() []string {
+	return append([]string(nil), c.baseURLs...)
+}
+
+// Node: 같은 설정으로 한 서버만 부르는 클라이언트 (승격/강등, 헬스 체크처럼 서버마다 결과가 다른 요청용)
+// 503이어도 다른 서버로 넘어가거나 다시 시도하지 않습니다.
+func (c *Client) Node(baseURL string) *Client {
+	return &Client{
+		baseURLs:   []string{strings.TrimRight(baseURL, "/")},
+		httpClient: c.httpClient,
+		token:      c.token,
+		actor:      c.actor,
+	}
+}
+
+// RequestOption: 요청 하나에만 붙는 옵션
+type RequestOption func(*http.Request)
+
+// WithIdempotencyKey: Idempotency-Key 헤더 (POST /todos, /reports, /sync)
+// 키가 있으면 연결이 끊긴 요청도 안전하게 다시 보냅니다.
+func WithIdempotencyKey(key string) RequestOption {
+	return func(r *http.Request) { r.Header.Set("Idempotency-Key", key) }
+}
+
+// WithHeader: 임의의 헤더 (예: X-Timezone)
+func WithHeader(key, value string) RequestOption {
+	return func(r *http.Request) { r.Header.Set(key, value) }
+}
+
+// request: 보낼 요청 하나 (주소를 바꿔가며 다시 만들 수 있도록 바디는 바이트로)
+type request struct {
+	method      string
+	path        string
+	query       url.Values
+	body        []byte
+	contentType string
+	opts        []RequestOption
+}
+
+func newRequest(method, path string, query url.Values, body interface{}, opts []RequestOption) (request, error) {
+	r := request{method: method, path: path, query: query, opts: opts}
+	if body != nil {
+		buf, err := json.Marshal(body)
+		if err != nil {
+			return r, err
+		}
+		r.body = buf
+		r.contentType = "application/json"
+	}
+	return r, nil
+}
+
+func (c *Client) build(ctx context.Context, base string, r request) (*http.Request, error) {
+	u := base + r.path
+	if len(r.query) > 0 {
+		u += "?" + r.query.Encode()
+	}
+	var body io.Reader
+	if r.body != nil {
+		body = bytes.NewReader(r.body)
+	}
+	req, err := http.NewRequestWithContext(ctx, r.method, u, body)
+	if err != nil {
+		return nil, err
+	}
+	if r.contentType != "" {
+		req.Header.Set("Content-Type", r.contentType)
+	}
+	req.Header.Set("Accept", "application/json")
+	if c.token != "" {
+		req.Header.Set("Authorization", "Bearer "+c.token)
+	}
+	if c.actor != "" {
+		req.Header.Set("X-Actor", c.actor)
+	}
+	for _, opt := range r.opts {
+		opt(req)
+	}
+	return req, nil
+}
+
+// send: 요청을 보내고 2xx 응답을 그대로 돌려줌 (아니면 *APIError, 바디는 닫힘)
+// 503과 연결 실패는 다음 주소로, 모든 주소가 실패하면 backoff만큼 기다렸다가 다시 돕니다.
+func (c *Client) send(ctx context.Context, r request) (*http.Response, error) {
+	n := len(c.baseURLs)
+	start := int(c.preferred.Load())
+	backoff := c.backoff
+	var lastErr error
+
+	for round := 0; ; round++ {
+		for i := 0; i < n; i++ {
+			idx := (start + i) % n
+			req, err := c.build(ctx, c.baseURLs[idx], r)
+			if err != nil {
+				return nil, err
+			}
+
+			resp, err := c.httpClient.Do(req)
+			if err != nil {
+				if ctx.Err() != nil || !c.failover || !retryableTransportError(err, req) {
+					return nil, err
+				}
+				lastErr = err
+				continue
+			}
+			if resp.StatusCode >= 200 && resp.StatusCode <= 299 {
+				c.preferred.Store(int32(idx))
+				return resp, nil
+			}
+
+			apiErr := decodeError(resp)
+			apiErr.URL = c.baseURLs[idx]
+			resp.Body.Close()
+			if resp.StatusCode != http.StatusServiceUnavailable || !c.failover {
+				return nil, apiErr
+			}
+			lastErr = apiErr
+		}
+
+		if !c.failover || round >= c.retries {
+			return nil, lastErr
+		}
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-time.After(backoff):
+		}
+		backoff *= 2
+	}
+}
+
+// retryableTransportError: 연결 실패를 다른 주소로 다시 보내도 되는지
+// 연결 자체가 안 된 경우는 서버가 요청을 받지 못했으므로 항상 안전하고,
+// 그 밖의 실패(응답 도중 끊김 등)는 두 번 처리돼도 괜찮은 요청만 다시 보냅니다.
+func retryableTransportError(err error, req *http.Request) bool {
+	var opErr *net.OpError
+	if errors.As(err, &opErr) && opErr.Op == "dial" {
+		return true
+	}
+	switch req.Method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodPut, http.MethodDelete:
+		return true
+	}
+	return req.Header.Get("Idempotency-Key") != ""
+}
+
+func decodeError(resp *http.Response) *APIError {
+	apiErr := &APIError{StatusCode: resp.StatusCode}
+	var envelope struct {
+		Message string          `json:"message"`
+		Error   string          `json:"error"` // Standby reject 모드의 503 본문
+		Data    json.RawMessage `json:"data"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&envelope); err == nil {
+		apiErr.Message = envelope.Message
+		if apiErr.Message == "" {
+			apiErr.Message = envelope.Error
+		}
+		if len(envelope.Data) > 0 && string(envelope.Data) != "null" {
+			apiErr.Data = envelope.Data
+		}
+	}
+	return apiErr
+}
+
+// do: WebResponse로 감싼 응답의 data를 out에 풀기 (out이 nil이면 버림)
+func (c *Client) do(ctx context.Context, method, path string, query url.Values, body, out interface{}, opts ...RequestOption) error {
+	r, err := newRequest(method, path, query, body, opts)
+	if err != nil {
+		return err
+	}
+	resp, err := c.send(ctx, r)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if out == nil {
+		return nil
+	}
+	return decodeData(resp.Body, out)
+}
+
+// decodeData: WebResponse의 data만 out에 풀기
+func decodeData(body io.Reader, out interface{}) error {
+	return json.NewDecoder(body).Decode(&model.WebResponse{Data: out})
+}
+
+// doRaw: WebResponse로 감싸지 않고 그대로 주는 응답 (예: PATCH /todos/{id})
+func (c *Client) doRaw(ctx context.Context, method, path string, body, out interface{}) error {
+	r, err := newRequest(method, path, nil, body, nil)
+	if err != nil {
+		return err
+	}
+	resp, err := c.send(ctx, r)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	return json.NewDecoder(resp.Body).Decode(out)
+}
+
+// stream: 파일 형태 응답 (내보내기, ICS), 다 읽은 뒤 Close 필요
+func (c *Client) stream(ctx context.Context, path string, query url.Values) (io.ReadCloser, error) {
+	r, err := newRequest(http.MethodGet, path, query, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := c.send(ctx, r)
+	if err != nil {
+		return nil, err
+	}
+	return resp.Body, nil
+}
@@ -0,0 +1,297 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"go_study/app"
+	"go_study/config"
+	"go_study/global"
+	"go_study/handler"
+	"go_study/middleware"
+	"go_study/migrate"
+	"go_study/model"
+
+	"github.com/gin-gonic/gin"
+	"github.com/glebarez/sqlite"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+// 실제 라우터(app.New)를 임시 파일 DB로 띄움 (백그라운드 작업은 시작하지 않음)
+func newTestServer(t *testing.T) *httptest.Server {
+	dir := t.TempDir()
+	cfg := config.Default()
+	cfg.Database.File = filepath.Join(dir, "todos.db")
+	cfg.Log.Path = filepath.Join(dir, "server.log")
+	cfg.Backup.Dir = filepath.Join(dir, "backups")
+
+	db, err := gorm.Open(sqlite.Open(cfg.Database.File), &gorm.Config{Logger: logger.Default.LogMode(logger.Silent)})
+	require.NoError(t, err)
+	_, err = migrate.New(db, migrate.Options{}).Up(context.Background())
+	require.NoError(t, err)
+
+	gin.SetMode(gin.TestMode)
+	middleware.Log = zap.NewNop()
+	global.SetActive()
+	t.Cleanup(global.SetStandby)
+
+	srv := httptest.NewServer(app.New(db, cfg).Router)
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+// 항상 Standby처럼 503을 돌려주는 서버 (몇 번 불렸는지 셈)
+func newStandbyStub(t *testing.T, hits *atomic.Int32) *httptest.Server {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		hits.Add(1)
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusServiceUnavailable)
+		json.NewEncoder(w).Encode(model.WebResponse{Code: http.StatusServiceUnavailable, Message: "standby"})
+	}))
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+func TestClient_TodoRoutes(t *testing.T) {
+	srv := newTestServer(t)
+	c := New(srv.URL, WithActor("tester"))
+	ctx := context.Background()
+
+	// 1. 생성 (같은 Idempotency-Key면 같은 결과)
+	due := time.Now().Add(24 * time.Hour).UTC().Truncate(time.Second)
+	a, err := c.CreateTodo(ctx, model.CreateTodoInput{Task: "보고서 +work", Priority: 1, DueAt: &due}, WithIdempotencyKey("k1"))
+	require.NoError(t, err)
+	again, err := c.CreateTodo(ctx, model.CreateTodoInput{Task: "보고서 +work", Priority: 1, DueAt: &due}, WithIdempotencyKey("k1"))
+	require.NoError(t, err)
+	assert.Equal(t, a.ID, again.ID)
+	b, err := c.CreateTodo(ctx, model.CreateTodoInput{Task: "장보기"})
+	require.NoError(t, err)
+
+	_, err = c.CreateTodo(ctx, model.CreateTodoInput{})
+	assert.Equal(t, http.StatusBadRequest, StatusCode(err))
+
+	// 2. 수정 / 토글 / 필터
+	task := "보고서 마무리 +work"
+	updated, err := c.UpdateTodo(ctx, a.ID, model.UpdateTodoInput{Task: &task, ClearDue: true})
+	require.NoError(t, err)
+	assert.Equal(t, task, updated.Task)
+	assert.Nil(t, updated.DueAt)
+	toggled, err := c.ToggleTodo(ctx, b.ID)
+	require.NoError(t, err)
+	assert.True(t, toggled.Done)
+
+	done := true
+	list, err := c.ListTodos(ctx, TodoFilter{Done: &done})
+	require.NoError(t, err)
+	require.Len(t, list, 1)
+	assert.Equal(t, b.ID, list[0].ID)
+	list, _ = c.ListTodos(ctx, TodoFilter{Query: "work"})
+	assert.Len(t, list, 1)
+
+	// 3. 이력 / 되돌리기 / undo
+	history, err := c.History(ctx, a.ID)
+	require.NoError(t, err)
+	assert.Len(t, history, 2)
+	reverted, err := c.Revert(ctx, a.ID, 1)
+	require.NoError(t, err)
+	assert.Equal(t, "보고서 +work", reverted.Task)
+	undo, err := c.Undo(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, a.ID, undo.TodoID)
+
+	// 4. 삭제 / 복구 / 없는 항목
+	require.NoError(t, c.DeleteTodo(ctx, b.ID))
+	restored, err := c.RestoreTodo(ctx, b.ID)
+	require.NoError(t, err)
+	assert.Equal(t, b.ID, restored.ID)
+	assert.True(t, IsNotFound(c.DeleteTodo(ctx, 999)))
+
+	// 5. 내보내기 / 가져오기(미리보기)
+	body, err := c.ExportTodos(ctx, "csv", TodoFilter{})
+	require.NoError(t, err)
+	csv, _ := io.ReadAll(body)
+	body.Close()
+	assert.Equal(t, 3, strings.Count(string(csv), "\n"))
+	imported, err := c.ImportTodos(ctx, "todotxt", strings.NewReader("새 일\n장보기\n"), true)
+	require.NoError(t, err)
+	assert.True(t, imported.DryRun)
+	assert.Equal(t, 1, imported.Imported)
+	assert.Len(t, imported.Duplicates, 1)
+
+	// 6. 동기화
+	pull, err := c.SyncPull(ctx, "")
+	require.NoError(t, err)
+	assert.True(t, pull.Full)
+	assert.Len(t, pull.Changed, 2)
+	push, err := c.SyncPush(ctx, model.SyncPushInput{Changes: []model.SyncChange{{ClientID: "c1", Task: "오프라인"}}})
+	require.NoError(t, err)
+	assert.Equal(t, handler.SyncCreated, push.Results[0].Status)
+
+	// 7. 집계 / 리포트
+	dash, err := c.Dashboard(ctx, 7)
+	require.NoError(t, err)
+	assert.Equal(t, 7, dash.Days)
+	_, err = c.Analytics(ctx, AnalyticsOptions{TZ: "Asia/Seoul", Weeks: 4})
+	require.NoError(t, err)
+	job, err := c.GenerateReport(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, model.JobDailyReport, job.Type)
+}
+
+func TestClient_AdminRoutes(t *testing.T) {
+	srv := newTestServer(t)
+	c := New(srv.URL)
+	ctx := context.Background()
+
+	// 1. 웹훅
+	hook, err := c.CreateWebhook(ctx, model.CreateWebhookInput{URL: "https://example.com/hook", Events: []string{"*"}})
+	require.NoError(t, err)
+	assert.NotEmpty(t, hook.Secret)
+	off := false
+	updated, err := c.UpdateWebhook(ctx, hook.ID, model.UpdateWebhookInput{Active: &off})
+	require.NoError(t, err)
+	assert.False(t, updated.Active)
+	hooks, _ := c.ListWebhooks(ctx)
+	assert.Len(t, hooks, 1)
+	deliveries, err := c.ListDeliveries(ctx, hook.ID, Page{Limit: 10})
+	require.NoError(t, err)
+	assert.Empty(t, deliveries)
+	require.NoError(t, c.DeleteWebhook(ctx, hook.ID))
+	_, err = c.GetWebhook(ctx, hook.ID)
+	assert.True(t, IsNotFound(err))
+
+	// 2. 달력 토큰과 피드
+	token, err := c.CreateCalendarToken(ctx, "phone")
+	require.NoError(t, err)
+	feed, err := c.CalendarFeed(ctx, token.Token, true)
+	require.NoError(t, err)
+	ics, _ := io.ReadAll(feed)
+	feed.Close()
+	assert.Contains(t, string(ics), "BEGIN:VCALENDAR")
+	tokens, _ := c.ListCalendarTokens(ctx)
+	assert.Len(t, tokens, 1)
+	require.NoError(t, c.DeleteCalendarToken(ctx, token.ID))
+
+	// 3. 감사 로그 (관리자 API 호출이 남음)
+	events, err := c.ListAuditEvents(ctx, AuditFilter{Action: model.AuditAdmin}, Page{Limit: 5})
+	require.NoError(t, err)
+	assert.NotEmpty(t, events)
+
+	// 4. 작업 큐
+	job, err := c.GenerateReport(ctx)
+	require.NoError(t, err)
+	overview, err := c.Queue(ctx, JobFilter{Type: model.JobDailyReport}, Page{})
+	require.NoError(t, err)
+	assert.Len(t, overview.Jobs, 1)
+	got, err := c.GetJob(ctx, job.ID)
+	require.NoError(t, err)
+	assert.Equal(t, model.JobQueued, got.Status)
+	_, err = c.RetryJob(ctx, job.ID)
+	assert.Equal(t, http.StatusConflict, StatusCode(err))
+
+	// 5. 백업
+	info, err := c.CreateBackup(ctx)
+	require.NoError(t, err)
+	infos, _ := c.ListBackups(ctx)
+	require.Len(t, infos, 1)
+	assert.Equal(t, info.Name, infos[0].Name)
+
+	// 6. 역할 / 헬스 체크 / 클러스터
+	ready, err := c.Ready(ctx)
+	require.NoError(t, err)
+	assert.True(t, ready.Ready)
+	require.NoError(t, c.Demote(ctx))
+	ready, err = c.Ready(ctx)
+	assert.Equal(t, http.StatusServiceUnavailable, StatusCode(err))
+	assert.False(t, ready.Ready) // 503이어도 리포트는 채워짐
+	live, err := c.Live(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, "standby", live.Role)
+	require.NoError(t, c.Promote(ctx))
+	require.NoError(t, c.Health(ctx))
+	status, err := c.ClusterStatus(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, "active", status.Self.Role)
+	_, err = c.ReplicationStatus(ctx)
+	assert.True(t, IsNotFound(err))
+}
+
+// Standby가 503으로 거절하면 다음 주소로, 이후 요청은 성공한 주소부터
+func TestClient_FailoverFromStandby(t *testing.T) {
+	var hits atomic.Int32
+	standby := newStandbyStub(t, &hits)
+	active := newTestServer(t)
+	c := New(standby.URL, WithFailover(active.URL), WithRetry(0, 0))
+	ctx := context.Background()
+
+	todo, err := c.CreateTodo(ctx, model.CreateTodoInput{Task: "failover"})
+	require.NoError(t, err)
+	assert.NotZero(t, todo.ID)
+	assert.Equal(t, int32(1), hits.Load())
+
+	_, err = c.ListTodos(ctx, TodoFilter{})
+	require.NoError(t, err)
+	assert.Equal(t, int32(1), hits.Load())
+
+	// 서버마다 결과가 다른 요청은 넘어가지 않음
+	_, err = c.Node(standby.URL).Live(ctx)
+	assert.Equal(t, http.StatusServiceUnavailable, StatusCode(err))
+}
+
+// 연결이 안 되는 주소는 건너뜀
+func TestClient_FailoverOnConnectionRefused(t *testing.T) {
+	dead := httptest.NewServer(http.NotFoundHandler())
+	dead.Close()
+	active := newTestServer(t)
+
+	c := New(dead.URL, WithFailover(active.URL), WithRetry(0, 0))
+	_, err := c.CreateTodo(context.Background(), model.CreateTodoInput{Task: "x"})
+	assert.NoError(t, err)
+}
+
+// 모든 서버가 Standby면 기다렸다가 다시 시도 (승격되면 성공)
+func TestClient_RetriesUntilPromoted(t *testing.T) {
+	srv := newTestServer(t)
+	global.SetStandby()
+	go func() {
+		time.Sleep(100 * time.Millisecond)
+		global.SetActive()
+	}()
+
+	c := New(srv.URL, WithRetry(10, 20*time.Millisecond))
+	todo, err := c.CreateTodo(context.Background(), model.CreateTodoInput{Task: "after promotion"})
+	require.NoError(t, err)
+	assert.NotZero(t, todo.ID)
+}
+
+func TestClient_GivesUp(t *testing.T) {
+	var hits atomic.Int32
+	standby := newStandbyStub(t, &hits)
+
+	// 1. 재시도 횟수를 다 쓰면 마지막 503
+	c := New(standby.URL, WithRetry(2, time.Millisecond))
+	_, err := c.ListTodos(context.Background(), TodoFilter{})
+	assert.Equal(t, http.StatusServiceUnavailable, StatusCode(err))
+	assert.Equal(t, int32(3), hits.Load())
+
+	// 2. context가 끝나면 기다리지 않음
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	slow := New(standby.URL, WithRetry(100, time.Second))
+	start := time.Now()
+	_, err = slow.ListTodos(ctx, TodoFilter{})
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+	assert.Less(t, time.Since(start), time.Second)
+}
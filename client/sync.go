@@ -0,0 +1,27 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+
+	"go_study/model"
+)
+
+// SyncPull: GET /sync?since=<token> (빈 token이면 전체)
+func (c *Client) SyncPull(ctx context.Context, since string) (model.SyncPullResult, error) {
+	var result model.SyncPullResult
+	q := url.Values{}
+	if since != "" {
+		q.Set("since", since)
+	}
+	err := c.do(ctx, http.MethodGet, "/sync", q, nil, &result)
+	return result, err
+}
+
+// SyncPush: POST /sync (오프라인에서 쌓인 변경 일괄 반영)
+func (c *Client) SyncPush(ctx context.Context, input model.SyncPushInput, opts ...RequestOption) (model.SyncPushResult, error) {
+	var result model.SyncPushResult
+	err := c.do(ctx, http.MethodPost, "/sync", nil, input, &result, opts...)
+	return result, err
+}
@@ -0,0 +1,167 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+
+	"go_study/model"
+)
+
+// TodoFilter: 목록 / 내보내기 조건 (비어 있으면 전체)
+type TodoFilter struct {
+	Done  *bool  // nil이면 완료 여부 상관없이
+	Query string // task에 포함된 문자열
+}
+
+func (f TodoFilter) values() url.Values {
+	q := url.Values{}
+	if f.Done != nil {
+		q.Set("done", strconv.FormatBool(*f.Done))
+	}
+	if f.Query != "" {
+		q.Set("q", f.Query)
+	}
+	return q
+}
+
+func todoPath(id uint) string {
+	return fmt.Sprintf("/todos/%d", id)
+}
+
+// ListTodos: GET /todos
+func (c *Client) ListTodos(ctx context.Context, filter TodoFilter) ([]model.Todo, error) {
+	var todos []model.Todo
+	err := c.do(ctx, http.MethodGet, "/todos", filter.values(), nil, &todos)
+	return todos, err
+}
+
+// CreateTodo: POST /todos
+func (c *Client) CreateTodo(ctx context.Context, input model.CreateTodoInput, opts ...RequestOption) (model.Todo, error) {
+	var todo model.Todo
+	err := c.do(ctx, http.MethodPost, "/todos", nil, input, &todo, opts...)
+	return todo, err
+}
+
+// ToggleTodo: PATCH /todos/{id} (바디 없이: 완료 여부 반전)
+func (c *Client) ToggleTodo(ctx context.Context, id uint) (model.Todo, error) {
+	var todo model.Todo
+	err := c.doRaw(ctx, http.MethodPatch, todoPath(id), nil, &todo)
+	return todo, err
+}
+
+// UpdateTodo: PATCH /todos/{id} (보낸 필드만 수정)
+func (c *Client) UpdateTodo(ctx context.Context, id uint, input model.UpdateTodoInput) (model.Todo, error) {
+	var todo model.Todo
+	err := c.doRaw(ctx, http.MethodPatch, todoPath(id), input, &todo)
+	return todo, err
+}
+
+// DeleteTodo: DELETE /todos/{id}
+func (c *Client) DeleteTodo(ctx context.Context, id uint) error {
+	return c.do(ctx, http.MethodDelete, todoPath(id), nil, nil, nil)
+}
+
+// RestoreTodo: POST /todos/{id}/restore
+func (c *Client) RestoreTodo(ctx context.Context, id uint) (model.Todo, error) {
+	var todo model.Todo
+	err := c.do(ctx, http.MethodPost, todoPath(id)+"/restore", nil, nil, &todo)
+	return todo, err
+}
+
+// ExportTodos: GET /todos/export (format: json | csv | todotxt), 다 읽은 뒤 Close 필요
+func (c *Client) ExportTodos(ctx context.Context, format string, filter TodoFilter) (io.ReadCloser, error) {
+	q := filter.values()
+	if format != "" {
+		q.Set("format", format)
+	}
+	return c.stream(ctx, "/todos/export", q)
+}
+
+// ImportTodos: POST /todos/import (format: json | csv | todotxt, dryRun이면 저장하지 않고 결과만)
+func (c *Client) ImportTodos(ctx context.Context, format string, file io.Reader, dryRun bool) (model.ImportResult, error) {
+	var result model.ImportResult
+	body, err := io.ReadAll(file)
+	if err != nil {
+		return result, err
+	}
+	q := url.Values{"dry_run": {strconv.FormatBool(dryRun)}}
+	if format != "" {
+		q.Set("format", format)
+	}
+	r := request{method: http.MethodPost, path: "/todos/import", query: q, body: body, contentType: "text/plain"}
+	resp, err := c.send(ctx, r)
+	if err != nil {
+		return result, err
+	}
+	defer resp.Body.Close()
+	err = decodeData(resp.Body, &result)
+	return result, err
+}
+
+// History: GET /todos/{id}/history
+func (c *Client) History(ctx context.Context, id uint) ([]model.RevisionEntry, error) {
+	var entries []model.RevisionEntry
+	err := c.do(ctx, http.MethodGet, todoPath(id)+"/history", nil, nil, &entries)
+	return entries, err
+}
+
+// Revert: POST /todos/{id}/revert?rev=N
+func (c *Client) Revert(ctx context.Context, id uint, rev int) (model.Todo, error) {
+	var todo model.Todo
+	q := url.Values{"rev": {strconv.Itoa(rev)}}
+	err := c.do(ctx, http.MethodPost, todoPath(id)+"/revert", q, nil, &todo)
+	return todo, err
+}
+
+// Undo: POST /undo (WithActor로 요청자를 정해둬야 함)
+func (c *Client) Undo(ctx context.Context) (model.UndoResult, error) {
+	var result model.UndoResult
+	err := c.do(ctx, http.MethodPost, "/undo", nil, nil, &result)
+	return result, err
+}
+
+// GenerateReport: POST /reports (작업 큐에 넣고 작업을 돌려줌)
+func (c *Client) GenerateReport(ctx context.Context, opts ...RequestOption) (model.Job, error) {
+	var job model.Job
+	err := c.do(ctx, http.MethodPost, "/reports", nil, nil, &job, opts...)
+	return job, err
+}
+
+// Dashboard: GET /dashboard?days=N (0이면 서버 기본값)
+func (c *Client) Dashboard(ctx context.Context, days int) (model.DashboardData, error) {
+	var data model.DashboardData
+	q := url.Values{}
+	if days > 0 {
+		q.Set("days", strconv.Itoa(days))
+	}
+	err := c.do(ctx, http.MethodGet, "/dashboard", q, nil, &data)
+	return data, err
+}
+
+// AnalyticsOptions: GET /analytics 조건 (비어 있으면 서버 기본값)
+type AnalyticsOptions struct {
+	TZ      string // 예: Asia/Seoul
+	Weeks   int
+	Project string // 예: +work
+}
+
+// Analytics: GET /analytics
+func (c *Client) Analytics(ctx context.Context, opts AnalyticsOptions) (model.AnalyticsResult, error) {
+	var result model.AnalyticsResult
+	q := url.Values{}
+	if opts.TZ != "" {
+		q.Set("tz", opts.TZ)
+	}
+	if opts.Weeks > 0 {
+		q.Set("weeks", strconv.Itoa(opts.Weeks))
+	}
+	if opts.Project != "" {
+		q.Set("project", opts.Project)
+	}
+	err := c.do(ctx, http.MethodGet, "/analytics", q, nil, &result)
+	return result, err
+}
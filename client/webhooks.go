@@ -0,0 +1,85 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+
+	"go_study/model"
+)
+
+func webhookPath(id uint) string {
+	return fmt.Sprintf("/webhooks/%d", id)
+}
+
+// Page: 목록 페이지 (0이면 서버 기본값)
+type Page struct {
+	Limit  int
+	Offset int
+}
+
+func (p Page) apply(q url.Values) url.Values {
+	if p.Limit > 0 {
+		q.Set("limit", strconv.Itoa(p.Limit))
+	}
+	if p.Offset > 0 {
+		q.Set("offset", strconv.Itoa(p.Offset))
+	}
+	return q
+}
+
+// ListWebhooks: GET /webhooks
+func (c *Client) ListWebhooks(ctx context.Context) ([]model.Webhook, error) {
+	var hooks []model.Webhook
+	err := c.do(ctx, http.MethodGet, "/webhooks", nil, nil, &hooks)
+	return hooks, err
+}
+
+// CreateWebhook: POST /webhooks (서명 키는 이 응답에서만 보임)
+func (c *Client) CreateWebhook(ctx context.Context, input model.CreateWebhookInput) (model.WebhookWithSecret, error) {
+	var hook model.WebhookWithSecret
+	err := c.do(ctx, http.MethodPost, "/webhooks", nil, input, &hook)
+	return hook, err
+}
+
+// GetWebhook: GET /webhooks/{id}
+func (c *Client) GetWebhook(ctx context.Context, id uint) (model.Webhook, error) {
+	var hook model.Webhook
+	err := c.do(ctx, http.MethodGet, webhookPath(id), nil, nil, &hook)
+	return hook, err
+}
+
+// UpdateWebhook: PATCH /webhooks/{id} (보낸 필드만 수정)
+func (c *Client) UpdateWebhook(ctx context.Context, id uint, input model.UpdateWebhookInput) (model.Webhook, error) {
+	var hook model.Webhook
+	err := c.do(ctx, http.MethodPatch, webhookPath(id), nil, input, &hook)
+	return hook, err
+}
+
+// DeleteWebhook: DELETE /webhooks/{id}
+func (c *Client) DeleteWebhook(ctx context.Context, id uint) error {
+	return c.do(ctx, http.MethodDelete, webhookPath(id), nil, nil, nil)
+}
+
+// ListDeliveries: GET /webhooks/{id}/deliveries
+func (c *Client) ListDeliveries(ctx context.Context, webhookID uint, page Page) ([]model.WebhookDelivery, error) {
+	var deliveries []model.WebhookDelivery
+	err := c.do(ctx, http.MethodGet, webhookPath(webhookID)+"/deliveries", page.apply(url.Values{}), nil, &deliveries)
+	return deliveries, err
+}
+
+// GetDelivery: GET /webhooks/{id}/deliveries/{delivery} (시도 기록 포함)
+func (c *Client) GetDelivery(ctx context.Context, webhookID, deliveryID uint) (model.DeliveryDetail, error) {
+	var detail model.DeliveryDetail
+	err := c.do(ctx, http.MethodGet, fmt.Sprintf("%s/deliveries/%d", webhookPath(webhookID), deliveryID), nil, nil, &detail)
+	return detail, err
+}
+
+// Redeliver: POST /webhooks/{id}/deliveries/{delivery}/redeliver
+func (c *Client) Redeliver(ctx context.Context, webhookID, deliveryID uint) (model.WebhookDelivery, error) {
+	var delivery model.WebhookDelivery
+	err := c.do(ctx, http.MethodPost, fmt.Sprintf("%s/deliveries/%d/redeliver", webhookPath(webhookID), deliveryID), nil, nil, &delivery)
+	return delivery, err
+}
@@ -0,0 +1,277 @@
+// Package cluster: Active/Standby 서버끼리 주고받는 heartbeat와 클러스터 상태
+// 각 서버는 설정된 peer에게 주기적으로 자기 상태를 보내고 상대 상태를 응답으로 받습니다.
+// 두 서버가 모두 Active라고 주장하면(split-brain) 임기(term)가 작은 쪽, 같으면 이름이 큰 쪽이 스스로 Standby가 됩니다.
+package cluster
+
+import (
+	"bytes"
+	"context"
+	"crypto/subtle"
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"go_study/global"
+	"go_study/model"
+)
+
+// 역할 이름
+const (
+	RoleActive  = "active"
+	RoleStandby = "standby"
+)
+
+// TokenHeader: peer끼리 주고받는 요청의 공유 토큰 (cluster.token)
+const TokenHeader = "X-Cluster-Token"
+
+// ValidToken: peer가 보낸 토큰이 맞는지 (토큰을 설정하지 않은 서버는 peer 요청을 전부 거절)
+// heartbeat는 Active를 Standby로 내릴 수 있으므로 토큰 없이 받으면 아무나 서버를 강등시킬 수 있습니다.
+func ValidToken(expected, got string) bool {
+	return expected != "" && subtle.ConstantTimeCompare([]byte(got), []byte(expected)) == 1
+}
+
+// Options: heartbeat 설정 (0이면 기본값)
+type Options struct {
+	NodeName    string
+	Peers       []string      // peer base URL (예: http://app-2:8080)
+	Interval    time.Duration // heartbeat 주기
+	PeerTimeout time.Duration // 이 시간 동안 heartbeat가 없으면 peer를 unreachable로 봄
+	Token       string        // peer 요청에 붙이는 공유 토큰
+}
+
+// Monitor: heartbeat 송수신과 split-brain 해소
+type Monitor struct {
+	opts      Options
+	startedAt time.Time
+	client    *http.Client
+
+	mu    sync.Mutex
+	peers map[string]*model.PeerStatus // key: peer URL (들어오기만 한 heartbeat는 "node:" + 이름)
+}
+
+// 생성자
+func New(opts Options) *Monitor {
+	if opts.Interval <= 0 {
+		opts.Interval = 2 * time.Second
+	}
+	if opts.PeerTimeout <= 0 {
+		opts.PeerTimeout = 3 * opts.Interval
+	}
+	m := &Monitor{
+		opts:      opts,
+		startedAt: time.Now(),
+		client:    &http.Client{Timeout: opts.Interval},
+		peers:     make(map[string]*model.PeerStatus),
+	}
+	for _, url := range opts.Peers {
+		url = strings.TrimRight(url, "/")
+		m.peers[url] = &model.PeerStatus{URL: url}
+	}
+	return m
+}
+
+// Self: 이 서버의 현재 상태
+func (m *Monitor) Self() model.NodeStatus {
+	role := RoleStandby
+	if global.IsActive() {
+		role = RoleActive
+	}
+	return model.NodeStatus{
+		Name:          m.opts.NodeName,
+		Role:          role,
+		Term:          global.Term(),
+		Version:       global.Version,
+		StartedAt:     m.startedAt,
+		UptimeSeconds: int64(time.Since(m.startedAt).Seconds()),
+	}
+}
+
+// Start: 백그라운드 heartbeat 시작 (peer가 없으면 아무것도 안 함)
+func (m *Monitor) Start() {
+	if len(m.opts.Peers) == 0 {
+		return
+	}
+	go func() {
+		ticker := time.NewTicker(m.opts.Interval)
+		defer ticker.Stop()
+		for range ticker.C {
+			m.Beat(context.Background())
+		}
+	}()
+}
+
+// Beat: 모든 peer에게 heartbeat 한 번 보내기
+func (m *Monitor) Beat(ctx context.Context) {
+	m.mu.Lock()
+	urls := make([]string, 0, len(m.peers))
+	for url, p := range m.peers {
+		if p.URL != "" {
+			urls = append(urls, url)
+		}
+	}
+	m.mu.Unlock()
+
+	var wg sync.WaitGroup
+	for _, url := range urls {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			peer, err := m.send(ctx, url)
+			m.record(url, peer, err)
+		}()
+	}
+	wg.Wait()
+}
+
+// peer의 POST /cluster/heartbeat 호출 (응답은 peer의 상태)
+func (m *Monitor) send(ctx context.Context, url string) (model.NodeStatus, error) {
+	var peer model.NodeStatus
+	body, _ := json.Marshal(m.Self())
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url+"/cluster/heartbeat", bytes.NewReader(body))
+	if err != nil {
+		return peer, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if m.opts.Token != "" {
+		req.Header.Set(TokenHeader, m.opts.Token)
+	}
+	resp, err := m.client.Do(req)
+	if err != nil {
+		return peer, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return peer, fmt.Errorf("heartbeat returned %d", resp.StatusCode)
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&model.WebResponse{Data: &peer}); err != nil {
+		return peer, err
+	}
+	return peer, nil
+}
+
+// 보낸 heartbeat 결과 기록
+func (m *Monitor) record(url string, peer model.NodeStatus, err error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if err == nil && peer.Name == m.opts.NodeName {
+		// 모든 서버가 같은 peers 설정을 쓰면 자기 자신도 들어 있음 → 이후로는 보내지 않음
+		delete(m.peers, url)
+		return
+	}
+	p := m.peers[url]
+	if err != nil {
+		p.LastError = err.Error()
+		return
+	}
+	now := time.Now()
+	p.LastHeartbeatAt, p.LastError, p.Node = &now, "", &peer
+	// 같은 서버가 먼저 heartbeat를 보내서 이름으로 기록된 게 있으면 합침
+	delete(m.peers, "node:"+peer.Name)
+	m.resolve(peer)
+}
+
+// Receive: peer가 보낸 heartbeat 처리 후 내 상태를 돌려줌
+func (m *Monitor) Receive(peer model.NodeStatus) model.NodeStatus {
+	if peer.Name == m.opts.NodeName {
+		return m.Self()
+	}
+	m.mu.Lock()
+	now := time.Now()
+	p := m.findByName(peer.Name)
+	if p == nil {
+		p = &model.PeerStatus{}
+		m.peers["node:"+peer.Name] = p
+	}
+	p.LastHeartbeatAt, p.Node = &now, &peer
+	m.resolve(peer)
+	m.mu.Unlock()
+	return m.Self()
+}
+
+func (m *Monitor) findByName(name string) *model.PeerStatus {
+	for _, p := range m.peers {
+		if p.Node != nil && p.Node.Name == name {
+			return p
+		}
+	}
+	return nil
+}
+
+// resolve: split-brain이면 질 쪽이 스스로 Standby가 됨 (mu를 잡은 상태로 호출)
+func (m *Monitor) resolve(peer model.NodeStatus) {
+	if global.IsActive() && peer.Role == RoleActive && outranks(peer, m.Self()) {
+		global.SetStandby()
+		log.Printf("🚨 [Cluster] split-brain 감지: %s(term %d)도 Active → %s(term %d)는 STANDBY로 전환\n",
+			peer.Name, peer.Term, m.opts.NodeName, global.Term())
+	}
+	// Standby일 때만 임기를 따라감 (다음에 승격되면 더 큰 임기를 받도록)
+	if !global.IsActive() {
+		global.ObserveTerm(peer.Term)
+	}
+}
+
+// a가 b보다 Active로 인정받아야 하는지 (임기가 크거나, 같으면 이름이 작은 쪽)
+func outranks(a, b model.NodeStatus) bool {
+	if a.Term != b.Term {
+		return a.Term > b.Term
+	}
+	return a.Name < b.Name
+}
+
+// Status: 클러스터 전체 상태
+func (m *Monitor) Status() model.ClusterStatus {
+	self := m.Self()
+	status := model.ClusterStatus{Self: self, Peers: []model.PeerStatus{}}
+	var holder *model.NodeStatus
+	if self.Role == RoleActive {
+		holder = &self
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for _, p := range m.peers {
+		view := *p
+		view.Reachable = p.LastHeartbeatAt != nil && time.Since(*p.LastHeartbeatAt) <= m.opts.PeerTimeout
+		if view.Reachable && p.Node != nil && p.Node.Role == RoleActive && (holder == nil || outranks(*p.Node, *holder)) {
+			holder = p.Node
+		}
+		status.Peers = append(status.Peers, view)
+	}
+	sort.Slice(status.Peers, func(i, j int) bool { return peerKey(status.Peers[i]) < peerKey(status.Peers[j]) })
+	if holder != nil {
+		status.LeaseHolder = holder.Name
+	}
+	return status
+}
+
+func peerKey(p model.PeerStatus) string {
+	if p.URL != "" {
+		return p.URL
+	}
+	return p.Node.Name
+}
+
+// ActiveURL: 지금 Active인 peer 주소 (모르면 빈 값, Standby가 쓰기 요청을 안내할 때 사용)
+func (m *Monitor) ActiveURL() string {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	var best *model.PeerStatus
+	for _, p := range m.peers {
+		reachable := p.LastHeartbeatAt != nil && time.Since(*p.LastHeartbeatAt) <= m.opts.PeerTimeout
+		if !reachable || p.URL == "" || p.Node == nil || p.Node.Role != RoleActive {
+			continue
+		}
+		if best == nil || outranks(*p.Node, *best.Node) {
+			best = p
+		}
+	}
+	if best == nil {
+		return ""
+	}
+	return best.URL
+}
@@ -0,0 +1,117 @@
+package cluster
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"go_study/global"
+	"go_study/model"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// 정해진 상태로 heartbeat에 응답하는 가짜 peer
+func fakePeer(t *testing.T, node *model.NodeStatus, received *model.NodeStatus) *httptest.Server {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/cluster/heartbeat", r.URL.Path)
+		assert.Equal(t, "secret", r.Header.Get(TokenHeader))
+		json.NewDecoder(r.Body).Decode(received)
+		json.NewEncoder(w).Encode(model.WebResponse{Code: 200, Data: node})
+	}))
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+func TestMonitor_SplitBrainStepsDownToNewerTerm(t *testing.T) {
+	defer global.SetStandby()
+	global.SetStandby()
+	global.SetActive()
+	myTerm := global.Term()
+
+	peer := model.NodeStatus{Name: "server-2", Role: RoleActive, Term: myTerm + 1}
+	var received model.NodeStatus
+	srv := fakePeer(t, &peer, &received)
+	m := New(Options{NodeName: "server-1", Peers: []string{srv.URL + "/"}, Token: "secret"})
+
+	m.Beat(context.Background())
+	assert.Equal(t, "server-1", received.Name)
+	assert.Equal(t, RoleActive, received.Role)
+
+	// 더 나중에 승격된 peer가 이기고, 다음 승격 때는 그보다 큰 임기를 받음
+	assert.False(t, global.IsActive())
+	assert.Equal(t, myTerm+1, global.Term())
+
+	status := m.Status()
+	assert.Equal(t, RoleStandby, status.Self.Role)
+	assert.Equal(t, "server-2", status.LeaseHolder)
+	assert.Len(t, status.Peers, 1)
+	assert.True(t, status.Peers[0].Reachable)
+	assert.Equal(t, srv.URL, status.Peers[0].URL)
+
+	global.SetActive()
+	assert.Equal(t, myTerm+2, global.Term())
+}
+
+func TestMonitor_ReceiveKeepsOlderPeerOut(t *testing.T) {
+	defer global.SetStandby()
+	global.SetStandby()
+	global.SetActive()
+	term := global.Term()
+	m := New(Options{NodeName: "server-b"})
+
+	// 1. 임기가 작은 peer가 Active라고 해도 나는 유지
+	self := m.Receive(model.NodeStatus{Name: "server-a", Role: RoleActive, Term: term - 1})
+	assert.Equal(t, RoleActive, self.Role)
+	assert.Equal(t, "server-b", m.Status().LeaseHolder)
+
+	// 2. 임기가 같으면 이름이 작은 쪽이 이김
+	self = m.Receive(model.NodeStatus{Name: "server-a", Role: RoleActive, Term: term})
+	assert.Equal(t, RoleStandby, self.Role)
+	status := m.Status()
+	assert.Equal(t, "server-a", status.LeaseHolder)
+	assert.Len(t, status.Peers, 1)
+}
+
+func TestMonitor_SelfAndUnreachablePeers(t *testing.T) {
+	defer global.SetStandby()
+	global.SetStandby()
+
+	me := model.NodeStatus{Name: "server-1"}
+	var received model.NodeStatus
+	self := fakePeer(t, &me, &received)
+	dead := httptest.NewServer(http.NotFoundHandler())
+	dead.Close()
+
+	m := New(Options{NodeName: "server-1", Peers: []string{self.URL, dead.URL}, Token: "secret"})
+	m.Beat(context.Background())
+
+	// 자기 자신 주소는 빠지고, 죽은 peer는 에러와 함께 unreachable
+	status := m.Status()
+	assert.Len(t, status.Peers, 1)
+	assert.Equal(t, dead.URL, status.Peers[0].URL)
+	assert.False(t, status.Peers[0].Reachable)
+	assert.NotEmpty(t, status.Peers[0].LastError)
+	assert.Empty(t, status.LeaseHolder)
+}
+
+func TestMonitor_ActiveURL(t *testing.T) {
+	defer global.SetStandby()
+	global.SetStandby()
+
+	active := model.NodeStatus{Name: "server-1", Role: RoleActive, Term: global.Term() + 1}
+	var received model.NodeStatus
+	srv := fakePeer(t, &active, &received)
+	m := New(Options{NodeName: "server-2", Peers: []string{srv.URL}, Token: "secret"})
+	assert.Empty(t, m.ActiveURL())
+
+	m.Beat(context.Background())
+	assert.Equal(t, srv.URL, m.ActiveURL())
+
+	// peer가 Standby가 되면 안내할 곳이 없음
+	active.Role = RoleStandby
+	m.Beat(context.Background())
+	assert.Empty(t, m.ActiveURL())
+}
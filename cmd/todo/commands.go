@@ -0,0 +1,244 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"go_study/client"
+	"go_study/model"
+)
+
+// parseArgs: 플래그와 위치 인자를 섞어 써도 되도록 (todo add "할 일" --priority 1)
+func parseArgs(fs *flag.FlagSet, args []string) ([]string, error) {
+	var positional []string
+	for {
+		if err := fs.Parse(args); err != nil {
+			return nil, err
+		}
+		args = fs.Args()
+		if len(args) == 0 {
+			return positional, nil
+		}
+		positional = append(positional, args[0])
+		args = args[1:]
+	}
+}
+
+func newFlagSet(name string, stderr io.Writer) *flag.FlagSet {
+	fs := flag.NewFlagSet("todo "+name, flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	return fs
+}
+
+// parseDue: 2025-12-31(그날 0시, 로컬 시간) 또는 RFC 3339
+func parseDue(s string) (time.Time, error) {
+	if t, err := time.Parse(time.RFC3339, s); err == nil {
+		return t, nil
+	}
+	t, err := time.ParseInLocation("2006-01-02", s, time.Local)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid date %q (use 2025-12-31 or RFC 3339)", s)
+	}
+	return t, nil
+}
+
+func parseIDs(args []string) ([]uint, error) {
+	if len(args) == 0 {
+		return nil, errors.New("at least one id is required")
+	}
+	ids := make([]uint, 0, len(args))
+	for _, a := range args {
+		n, err := strconv.ParseUint(a, 10, 64)
+		if err != nil || n == 0 {
+			return nil, fmt.Errorf("invalid id %q", a)
+		}
+		ids = append(ids, uint(n))
+	}
+	return ids, nil
+}
+
+// doneFilter: --done / --open 플래그를 필터로
+func doneFilter(done, open bool) (*bool, error) {
+	switch {
+	case done && open:
+		return nil, errors.New("--done and --open cannot be used together")
+	case done || open:
+		return &done, nil
+	}
+	return nil, nil
+}
+
+func cmdAdd(ctx context.Context, c *client.Client, p *printer, args []string, stderr io.Writer) error {
+	fs := newFlagSet("add", stderr)
+	priority := fs.Int("priority", 0, "우선순위 1(높음) ~ 9(낮음), 0은 미지정")
+	due := fs.String("due", "", "마감일")
+	rest, err := parseArgs(fs, args)
+	if err != nil {
+		return err
+	}
+	task := strings.TrimSpace(strings.Join(rest, " "))
+	if task == "" {
+		return errors.New("task is required")
+	}
+
+	input := model.CreateTodoInput{Task: task, Priority: *priority}
+	if *due != "" {
+		t, err := parseDue(*due)
+		if err != nil {
+			return err
+		}
+		input.DueAt = &t
+	}
+	todo, err := c.CreateTodo(ctx, input)
+	if err != nil {
+		return err
+	}
+	return p.Todos([]model.Todo{todo})
+}
+
+func cmdList(ctx context.Context, c *client.Client, p *printer, args []string, stderr io.Writer) error {
+	fs := newFlagSet("ls", stderr)
+	done := fs.Bool("done", false, "완료된 것만")
+	open := fs.Bool("open", false, "완료되지 않은 것만")
+	query := fs.String("q", "", "task 검색어")
+	if _, err := parseArgs(fs, args); err != nil {
+		return err
+	}
+	filter := client.TodoFilter{Query: *query}
+	var err error
+	if filter.Done, err = doneFilter(*done, *open); err != nil {
+		return err
+	}
+
+	todos, err := c.ListTodos(ctx, filter)
+	if err != nil {
+		return err
+	}
+	return p.Todos(todos)
+}
+
+func cmdDone(ctx context.Context, c *client.Client, p *printer, args []string) error {
+	ids, err := parseIDs(args)
+	if err != nil {
+		return err
+	}
+	done := true
+	var updated []model.Todo
+	for _, id := range ids {
+		todo, err := c.UpdateTodo(ctx, id, model.UpdateTodoInput{Done: &done})
+		if err != nil {
+			return fmt.Errorf("#%d: %w", id, err)
+		}
+		updated = append(updated, todo)
+	}
+	return p.Todos(updated)
+}
+
+func cmdEdit(ctx context.Context, c *client.Client, p *printer, args []string, stderr io.Writer) error {
+	fs := newFlagSet("edit", stderr)
+	task := fs.String("task", "", "새 내용")
+	priority := fs.Int("priority", -1, "우선순위 0 ~ 9")
+	due := fs.String("due", "", "마감일")
+	noDue := fs.Bool("no-due", false, "마감일 지우기")
+	undone := fs.Bool("undone", false, "완료 취소")
+	rest, err := parseArgs(fs, args)
+	if err != nil {
+		return err
+	}
+	ids, err := parseIDs(rest)
+	if err != nil {
+		return err
+	}
+	if len(ids) != 1 {
+		return errors.New("edit takes exactly one id")
+	}
+
+	var input model.UpdateTodoInput
+	changed := false
+	fs.Visit(func(f *flag.Flag) { changed = true })
+	if !changed {
+		return errors.New("nothing to change (use --task, --priority, --due, --no-due or --undone)")
+	}
+	if *task != "" {
+		input.Task = task
+	}
+	if *priority >= 0 {
+		input.Priority = priority
+	}
+	if *due != "" {
+		if *noDue {
+			return errors.New("--due and --no-due cannot be used together")
+		}
+		t, err := parseDue(*due)
+		if err != nil {
+			return err
+		}
+		input.DueAt = &t
+	}
+	input.ClearDue = *noDue
+	if *undone {
+		notDone := false
+		input.Done = &notDone
+	}
+
+	todo, err := c.UpdateTodo(ctx, ids[0], input)
+	if err != nil {
+		return err
+	}
+	return p.Todos([]model.Todo{todo})
+}
+
+func cmdRemove(ctx context.Context, c *client.Client, p *printer, args []string) error {
+	ids, err := parseIDs(args)
+	if err != nil {
+		return err
+	}
+	for _, id := range ids {
+		if err := c.DeleteTodo(ctx, id); err != nil {
+			return fmt.Errorf("#%d: %w", id, err)
+		}
+	}
+	return p.Deleted(ids)
+}
+
+func cmdExport(ctx context.Context, c *client.Client, args []string, stdout, stderr io.Writer) error {
+	fs := newFlagSet("export", stderr)
+	format := fs.String("format", "json", "json | csv | todotxt")
+	done := fs.Bool("done", false, "완료된 것만")
+	open := fs.Bool("open", false, "완료되지 않은 것만")
+	query := fs.String("q", "", "task 검색어")
+	file := fs.String("f", "", "저장할 파일 (기본: 표준 출력)")
+	if _, err := parseArgs(fs, args); err != nil {
+		return err
+	}
+	filter := client.TodoFilter{Query: *query}
+	var err error
+	if filter.Done, err = doneFilter(*done, *open); err != nil {
+		return err
+	}
+
+	body, err := c.ExportTodos(ctx, *format, filter)
+	if err != nil {
+		return err
+	}
+	defer body.Close()
+
+	w := stdout
+	if *file != "" {
+		f, err := os.Create(*file)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		w = f
+	}
+	_, err = io.Copy(w, body)
+	return err
+}
@@ -0,0 +1,60 @@
+package main
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+
+	"github.com/spf13/viper"
+)
+
+// cliConfig: ~/.config/todo/config.yaml
+//
+//	url: "http://localhost:8080"
+//	failover: ["http://app-2:8080"]  # url이 Standby(503)거나 죽었을 때 시도할 주소 (선택)
+//	token: "..."      # Authorization: Bearer (앞단 프록시 인증용, 선택)
+//	actor: "gyong97"  # 감사 로그에 남을 요청자 (X-Actor, 선택)
+//	output: "table"   # table | json
+type cliConfig struct {
+	URL      string   `mapstructure:"url"`
+	Failover []string `mapstructure:"failover"`
+	Token    string   `mapstructure:"token"`
+	Actor    string   `mapstructure:"actor"`
+	Output   string   `mapstructure:"output"`
+}
+
+// defaultConfigPath: $TODO_CLI_CONFIG, 없으면 사용자 설정 디렉터리의 todo/config.yaml
+func defaultConfigPath() string {
+	if p := os.Getenv("TODO_CLI_CONFIG"); p != "" {
+		return p
+	}
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return "config.yaml"
+	}
+	return filepath.Join(dir, "todo", "config.yaml")
+}
+
+// loadConfig: 설정 파일(없어도 됨) → 환경 변수(TODO_URL, TODO_TOKEN, TODO_ACTOR) 순서로 덮어씀
+func loadConfig(path string, explicit bool) (cliConfig, error) {
+	v := viper.New()
+	v.SetConfigFile(path)
+	v.SetConfigType("yaml")
+	v.SetDefault("url", "http://localhost:8080")
+	v.SetDefault("output", "table")
+	v.SetEnvPrefix("TODO")
+	for _, key := range []string{"url", "token", "actor", "output"} {
+		v.BindEnv(key)
+	}
+
+	if err := v.ReadInConfig(); err != nil {
+		// --config로 직접 지정한 파일이 없으면 에러, 기본 경로는 없어도 됨
+		var notFound *os.PathError
+		if explicit || !errors.As(err, &notFound) {
+			return cliConfig{}, err
+		}
+	}
+	var cfg cliConfig
+	err := v.Unmarshal(&cfg)
+	return cfg, err
+}
@@ -0,0 +1,102 @@
+// todo: 터미널에서 할 일을 다루는 명령줄 클라이언트 (go_study/client 사용)
+//
+//	todo add "보고서 쓰기 +work" --priority 1 --due 2025-12-31
+//	todo ls --open -q work
+//	todo done 3 4
+//	todo edit 3 --task "보고서 마무리" --no-due
+//	todo rm 5
+//	todo export --format csv -f todos.csv
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+
+	"go_study/client"
+)
+
+const usage = `usage: todo [--config file] [--url URL] [-o table|json] <command> [args]
+
+commands:
+  add <task...>   [--priority N] [--due DATE]                할 일 추가
+  ls              [--done | --open] [-q text]                 목록
+  done <id...>                                               완료 표시
+  edit <id>       [--task T] [--priority N] [--due DATE | --no-due] [--undone]
+  rm <id...>                                                 삭제
+  export          [--format json|csv|todotxt] [--done | --open] [-q text] [-f file]
+
+DATE: 2025-12-31 또는 2025-12-31T18:00:00+09:00
+설정 파일(기본 %s): url, failover, token, actor, output
+`
+
+func main() {
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	if err := run(ctx, os.Args[1:], os.Stdout, os.Stderr); err != nil {
+		if !errors.Is(err, flag.ErrHelp) {
+			fmt.Fprintln(os.Stderr, "todo:", err)
+		}
+		os.Exit(1)
+	}
+}
+
+// run: 전역 플래그를 읽고 하위 명령 실행 (테스트에서 그대로 호출)
+func run(ctx context.Context, args []string, stdout, stderr io.Writer) error {
+	fs := flag.NewFlagSet("todo", flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	fs.Usage = func() { fmt.Fprintf(stderr, usage, defaultConfigPath()) }
+	configPath := fs.String("config", "", "설정 파일 경로")
+	baseURL := fs.String("url", "", "서버 주소 (설정 파일의 url보다 우선)")
+	output := fs.String("o", "", "출력 형식: table | json")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if fs.NArg() == 0 {
+		fs.Usage()
+		return flag.ErrHelp
+	}
+
+	path := *configPath
+	if path == "" {
+		path = defaultConfigPath()
+	}
+	cfg, err := loadConfig(path, *configPath != "")
+	if err != nil {
+		return fmt.Errorf("config %s: %w", path, err)
+	}
+	if *baseURL != "" {
+		cfg.URL = *baseURL
+	}
+	if *output != "" {
+		cfg.Output = *output
+	}
+	p, err := newPrinter(cfg.Output, stdout)
+	if err != nil {
+		return err
+	}
+
+	c := client.New(cfg.URL, client.WithFailover(cfg.Failover...), client.WithToken(cfg.Token), client.WithActor(cfg.Actor))
+	cmd, rest := fs.Arg(0), fs.Args()[1:]
+	switch cmd {
+	case "add":
+		return cmdAdd(ctx, c, p, rest, stderr)
+	case "ls", "list":
+		return cmdList(ctx, c, p, rest, stderr)
+	case "done":
+		return cmdDone(ctx, c, p, rest)
+	case "edit":
+		return cmdEdit(ctx, c, p, rest, stderr)
+	case "rm", "delete":
+		return cmdRemove(ctx, c, p, rest)
+	case "export":
+		return cmdExport(ctx, c, rest, stdout, stderr)
+	}
+	fs.Usage()
+	return fmt.Errorf("unknown command %q", cmd)
+}
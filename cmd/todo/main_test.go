@@ -0,0 +1,106 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"go_study/handler"
+	"go_study/model"
+	"go_study/repository"
+
+	"github.com/gin-gonic/gin"
+	"github.com/glebarez/sqlite"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+// 인메모리 DB를 쓰는 실제 핸들러로 서버를 띄우고, 설정 파일로 주소를 넘깁니다.
+func newTestServer(t *testing.T) string {
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
+	require.NoError(t, err)
+	db.AutoMigrate(&model.Todo{}, &model.Sequence{}, &model.AuditEvent{}, &model.TodoRevision{}, &model.OutboxEvent{})
+	h := handler.NewTodoHandler(repository.NewSQLiteRepository(db), repository.NewSQLiteAuditRepository(db), nil)
+
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	r.GET("/todos", h.GetTodos)
+	r.POST("/todos", h.AddTodo)
+	r.GET("/todos/export", h.ExportTodos)
+	r.PATCH("/todos/:id", h.ToggleTodoStatus)
+	r.DELETE("/todos/:id", h.DeleteTodo)
+	srv := httptest.NewServer(r)
+	t.Cleanup(srv.Close)
+
+	config := filepath.Join(t.TempDir(), "config.yaml")
+	require.NoError(t, os.WriteFile(config, []byte("url: "+srv.URL+"\nactor: tester\n"), 0o600))
+	return config
+}
+
+func runCLI(t *testing.T, config string, args ...string) string {
+	var stdout, stderr bytes.Buffer
+	err := run(context.Background(), append([]string{"--config", config}, args...), &stdout, &stderr)
+	require.NoError(t, err, stderr.String())
+	return stdout.String()
+}
+
+func listJSON(t *testing.T, config string, args ...string) []model.Todo {
+	var todos []model.Todo
+	require.NoError(t, json.Unmarshal([]byte(runCLI(t, config, append([]string{"-o", "json", "ls"}, args...)...)), &todos))
+	return todos
+}
+
+func TestCLI_Workflow(t *testing.T) {
+	config := newTestServer(t)
+
+	// 1. add: 플래그가 위치 인자 뒤에 와도 됨
+	out := runCLI(t, config, "add", "보고서", "쓰기", "+work", "--priority", "1", "--due", "2030-01-02")
+	assert.Contains(t, out, "보고서 쓰기 +work")
+	assert.Contains(t, out, "2030-01-02")
+	runCLI(t, config, "add", "장보기")
+
+	// 2. done / ls 필터
+	runCLI(t, config, "done", "1")
+	done := listJSON(t, config, "--done")
+	require.Len(t, done, 1)
+	assert.Equal(t, uint(1), done[0].ID)
+	open := listJSON(t, config, "--open", "-q", "장")
+	require.Len(t, open, 1)
+	assert.Equal(t, "장보기", open[0].Task)
+
+	// 3. edit: 보낸 필드만 바뀜
+	runCLI(t, config, "edit", "1", "--task", "보고서 마무리", "--no-due", "--undone")
+	all := listJSON(t, config)
+	require.Len(t, all, 2)
+	assert.Equal(t, "보고서 마무리", all[0].Task)
+	assert.Nil(t, all[0].DueAt)
+	assert.False(t, all[0].Done)
+	assert.Equal(t, 1, all[0].Priority)
+
+	// 4. export / rm
+	csv := runCLI(t, config, "export", "--format", "csv")
+	assert.Equal(t, 3, strings.Count(csv, "\n"))
+	assert.Contains(t, runCLI(t, config, "rm", "2"), "deleted #2")
+	assert.Len(t, listJSON(t, config), 1)
+}
+
+func TestCLI_Errors(t *testing.T) {
+	config := newTestServer(t)
+	var stdout, stderr bytes.Buffer
+	ctx := context.Background()
+
+	err := run(ctx, []string{"--config", config, "done", "99"}, &stdout, &stderr)
+	assert.ErrorContains(t, err, "404")
+	err = run(ctx, []string{"--config", config, "edit", "1"}, &stdout, &stderr)
+	assert.ErrorContains(t, err, "nothing to change")
+	err = run(ctx, []string{"--config", config, "ls", "--done", "--open"}, &stdout, &stderr)
+	assert.Error(t, err)
+	err = run(ctx, []string{"--config", filepath.Join(t.TempDir(), "missing.yaml"), "ls"}, &stdout, &stderr)
+	assert.Error(t, err)
+}
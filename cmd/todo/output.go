@@ -0,0 +1,74 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"text/tabwriter"
+	"time"
+
+	"go_study/model"
+)
+
+// printer: 결과를 표(table) 또는 JSON으로 출력
+type printer struct {
+	json bool
+	w    io.Writer
+}
+
+func newPrinter(format string, w io.Writer) (*printer, error) {
+	switch format {
+	case "", "table":
+		return &printer{w: w}, nil
+	case "json":
+		return &printer{json: true, w: w}, nil
+	}
+	return nil, fmt.Errorf("unknown output format %q (table | json)", format)
+}
+
+func (p *printer) Todos(todos []model.Todo) error {
+	if p.json {
+		if todos == nil {
+			todos = []model.Todo{}
+		}
+		return p.encode(todos)
+	}
+
+	tw := tabwriter.NewWriter(p.w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "ID\tDONE\tPRI\tDUE\tTASK")
+	for _, t := range todos {
+		done := " "
+		if t.Done {
+			done = "x"
+		}
+		pri := "-"
+		if t.Priority > 0 {
+			pri = fmt.Sprint(t.Priority)
+		}
+		due := "-"
+		if t.DueAt != nil {
+			due = t.DueAt.Local().Format("2006-01-02 15:04")
+			if !t.Done && t.DueAt.Before(time.Now()) {
+				due += " !"
+			}
+		}
+		fmt.Fprintf(tw, "%d\t%s\t%s\t%s\t%s\n", t.ID, done, pri, due, t.Task)
+	}
+	return tw.Flush()
+}
+
+func (p *printer) Deleted(ids []uint) error {
+	if p.json {
+		return p.encode(map[string][]uint{"deleted": ids})
+	}
+	for _, id := range ids {
+		fmt.Fprintf(p.w, "deleted #%d\n", id)
+	}
+	return nil
+}
+
+func (p *printer) encode(v interface{}) error {
+	enc := json.NewEncoder(p.w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(v)
+}
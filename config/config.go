@@ -1,7 +1,11 @@
 package config
 
 import (
+	"errors"
+	"fmt"
 	"log"
+	"strings"
+	"time"
 
 	"github.com/spf13/viper"
 )
@@ -10,10 +14,21 @@ import (
 type Config struct {
 	Server struct {
 		Port string `mapstructure:"port"`
+
+		TLS struct {
+			Enabled        bool          `mapstructure:"enabled"`          // nginx 없이 직접 HTTPS (HTTP/2 포함)로 서빙
+			CertFile       string        `mapstructure:"cert_file"`        // PEM 인증서 (체인 포함)
+			KeyFile        string        `mapstructure:"key_file"`         // PEM 개인 키
+			ReloadInterval time.Duration `mapstructure:"reload_interval"`  // 인증서 파일이 바뀌었는지 확인하는 주기 (0이면 다시 읽지 않음)
+			ClientCAFile   string        `mapstructure:"client_ca_file"`   // 설정하면 /admin은 이 CA가 서명한 클라이언트 인증서가 있어야 함 (mutual TLS)
+			AdminClientCNs []string      `mapstructure:"admin_client_cns"` // /admin에 허용할 클라이언트 인증서 CN (비어 있으면 CA가 서명한 인증서 모두)
+			RedirectPort   string        `mapstructure:"redirect_port"`    // 이 주소(예: ":80")로 오는 HTTP 요청을 HTTPS로 리다이렉트 (비어 있으면 안 띄움)
+		} `mapstructure:"tls"`
 	} `mapstructure:"server"`
 
 	Database struct {
-		File string `mapstructure:"file"`
+		File        string `mapstructure:"file"`
+		AutoMigrate bool   `mapstructure:"auto_migrate"` // 시작할 때 남은 마이그레이션 적용 (false면 ./main migrate up 을 따로 실행해야 시작됨)
 	} `mapstructure:"database"`
 
 	Log struct {
@@ -23,26 +38,169 @@ type Config struct {
 		MaxBackups int    `mapstructure:"max_backups"`
 		MaxAge     int    `mapstructure:"max_age"`
 	} `mapstructure:"log"`
+
+	Idempotency struct {
+		TTL time.Duration `mapstructure:"ttl"` // Idempotency-Key 응답 보관 기간 (예: "24h")
+	} `mapstructure:"idempotency"`
+
+	Undo struct {
+		Window time.Duration `mapstructure:"window"` // POST /undo로 되돌릴 수 있는 기간 (예: "10m")
+	} `mapstructure:"undo"`
+
+	Webhook struct {
+		MaxAttempts  int           `mapstructure:"max_attempts"`  // 이만큼 실패하면 포기 (수동 재전송만 가능)
+		BaseBackoff  time.Duration `mapstructure:"base_backoff"`  // 첫 재시도 대기, 이후 2배씩
+		MaxBackoff   time.Duration `mapstructure:"max_backoff"`   // 재시도 대기 상한
+		Timeout      time.Duration `mapstructure:"timeout"`       // 요청 하나의 제한 시간
+		PollInterval time.Duration `mapstructure:"poll_interval"` // 전송 대기열 확인 주기
+	} `mapstructure:"webhook"`
+
+	Outbox struct {
+		PollInterval time.Duration `mapstructure:"poll_interval"` // outbox 확인 주기
+		MaxAttempts  int           `mapstructure:"max_attempts"`  // 이만큼 실패한 이벤트는 last_error와 함께 남겨둠
+		Retention    time.Duration `mapstructure:"retention"`     // 보낸 이벤트 보관 기간
+	} `mapstructure:"outbox"`
+
+	Jobs struct {
+		Workers           int           `mapstructure:"workers"`            // 전체 동시 실행 수
+		PollInterval      time.Duration `mapstructure:"poll_interval"`      // 작업 큐 확인 주기
+		VisibilityTimeout time.Duration `mapstructure:"visibility_timeout"` // 실행 중인 작업을 잡고 있는 시간 (지나면 다른 서버가 가져감)
+		MaxAttempts       int           `mapstructure:"max_attempts"`       // 이만큼 실패하면 failed (GET /admin/queue에서 재시도)
+		BaseBackoff       time.Duration `mapstructure:"base_backoff"`       // 첫 재시도 대기, 이후 2배씩
+		MaxBackoff        time.Duration `mapstructure:"max_backoff"`        // 재시도 대기 상한
+		Retention         time.Duration `mapstructure:"retention"`          // 성공한 작업 보관 기간
+	} `mapstructure:"jobs"`
+
+	Health struct {
+		CheckTimeout time.Duration `mapstructure:"check_timeout"` // 점검 하나의 제한 시간
+		MinFreeMB    uint64        `mapstructure:"min_free_mb"`   // DB 파일이 있는 볼륨의 최소 여유 공간 (MiB)
+	} `mapstructure:"health"`
+
+	Cluster struct {
+		NodeName          string        `mapstructure:"node_name"`           // 비어 있으면 hostname
+		Peers             []string      `mapstructure:"peers"`               // 다른 서버 주소 (예: http://app-2:8080)
+		HeartbeatInterval time.Duration `mapstructure:"heartbeat_interval"`  // heartbeat 주기
+		PeerTimeout       time.Duration `mapstructure:"peer_timeout"`        // 이 시간 동안 소식이 없으면 unreachable
+		Token             string        `mapstructure:"token" secret:"true"` // 서버 간 heartbeat 공유 토큰 (선택, GET /admin/config에서 가림)
+	} `mapstructure:"cluster"`
+
+	Standby struct {
+		Mode string `mapstructure:"mode"` // "reject": 모든 요청 503 | "read_only": 읽기만 처리, 쓰기는 503 + Active 주소 안내
+	} `mapstructure:"standby"`
+
+	Replication struct {
+		Enabled   bool          `mapstructure:"enabled"`    // Standby가 자기 DB 파일에 Active의 변경분을 따라 적용
+		Source    string        `mapstructure:"source"`     // Active 주소 (비어 있으면 cluster heartbeat로 찾은 Active)
+		BatchSize int           `mapstructure:"batch_size"` // 한 번에 받는 최대 할 일 수
+		LongPoll  time.Duration `mapstructure:"long_poll"`  // 변경이 없을 때 Active가 응답을 미루는 시간
+		MaxLag    time.Duration `mapstructure:"max_lag"`    // 이보다 오래 따라잡지 못하면 health 점검 실패
+	} `mapstructure:"replication"`
+
+	CORS struct {
+		AllowedOrigins   []string      `mapstructure:"allowed_origins"`   // 다른 출처의 프런트엔드 (예: https://app.example.com, https://*.example.com, "*"), 비어 있으면 CORS 헤더 없음
+		AllowedMethods   []string      `mapstructure:"allowed_methods"`   // preflight에 알려줄 메소드
+		AllowedHeaders   []string      `mapstructure:"allowed_headers"`   // preflight에 알려줄 요청 헤더
+		ExposedHeaders   []string      `mapstructure:"exposed_headers"`   // 브라우저 JS가 읽을 수 있는 응답 헤더
+		AllowCredentials bool          `mapstructure:"allow_credentials"` // 쿠키/인증 헤더 포함 요청 허용 ("*"와 같이 쓸 수 없음)
+		MaxAge           time.Duration `mapstructure:"max_age"`           // 브라우저가 preflight 결과를 캐시하는 시간
+	} `mapstructure:"cors"`
+
+	Security struct {
+		ContentSecurityPolicy string        `mapstructure:"content_security_policy"` // 정적 페이지(/, /static)의 Content-Security-Policy
+		HSTSMaxAge            time.Duration `mapstructure:"hsts_max_age"`            // HTTPS 요청에 Strict-Transport-Security (0이면 안 붙임)
+		FrameOptions          string        `mapstructure:"frame_options"`           // X-Frame-Options (DENY | SAMEORIGIN, 비우면 안 붙임)
+		ReferrerPolicy        string        `mapstructure:"referrer_policy"`         // Referrer-Policy (비우면 안 붙임)
+
+		CSRF struct {
+			Enabled        bool     `mapstructure:"enabled"`         // 쿠키 기반 세션을 쓰는 요청에 CSRF 토큰 요구
+			SessionCookies []string `mapstructure:"session_cookies"` // 이 쿠키 중 하나라도 있는 쓰기 요청만 검사 (쿠키가 없는 API 클라이언트는 대상 아님)
+			CookieName     string   `mapstructure:"cookie_name"`     // 토큰을 내려주는 쿠키 (JS가 읽어서 헤더로 다시 보냄)
+			HeaderName     string   `mapstructure:"header_name"`     // 토큰을 담아 보내는 요청 헤더
+		} `mapstructure:"csrf"`
+	} `mapstructure:"security"`
+
+	Backup struct {
+		Dir      string        `mapstructure:"dir"`      // 백업 파일을 둘 디렉터리
+		Interval time.Duration `mapstructure:"interval"` // 자동 백업 주기 (0이면 자동 백업 안 함)
+		Keep     int           `mapstructure:"keep"`     // 남길 백업 개수 (0이면 지우지 않음)
+	} `mapstructure:"backup"`
 }
 
 // 전역 설정 변수
 var AppConfig *Config
 
-// 설정 로드 함수
+// 환경 변수 접두사: TODO_SERVER_PORT → server.port, TODO_LOG_MAX_SIZE → log.max_size
+const EnvPrefix = "TODO"
+
+// 설정 로드 함수 (실패하면 종료)
 func LoadConfig() {
-	viper.AddConfigPath(".")      // 현재 디렉토리에서 찾기
-	viper.SetConfigName("config") // 파일 이름 (config.yaml)
-	viper.SetConfigType("yaml")   // 파일 형식
+	if err := Load("", nil); err != nil {
+		log.Fatal(err)
+	}
+}
 
-	viper.AutomaticEnv() // 환경 변수도 읽을 수 있게 설정
+// Load: 기본값 < 설정 파일 < 환경 변수(TODO_*) < overrides 순으로 합친 설정을 검증한 뒤 AppConfig에 저장
+// path가 비어 있으면 ./config.yaml (없으면 기본값과 환경 변수만), overrides는 명령줄 플래그 값 (키: "server.port" 같은 점 표기)
+// 잘못된 항목이 있으면 AppConfig는 그대로 두고 모든 항목을 담은 *ValidationError를 돌려줍니다.
+func Load(path string, overrides map[string]interface{}) error {
+	cfg, err := read(newViper(), path, overrides)
+	if err != nil {
+		return err
+	}
+	if err := cfg.Validate(); err != nil {
+		return err
+	}
+	AppConfig = cfg
+	return nil
+}
+
+// read: v에 설정 파일 위치를 정하고 읽어서 overrides까지 합친 설정 (검증은 하지 않음)
+func read(v *viper.Viper, path string, overrides map[string]interface{}) (*Config, error) {
+	if path != "" {
+		v.SetConfigFile(path)
+	} else {
+		v.AddConfigPath(".")      // 현재 디렉토리에서 찾기
+		v.SetConfigName("config") // 파일 이름 (config.yaml)
+		v.SetConfigType("yaml")   // 파일 형식
+	}
 
-	// 파일 읽기
-	if err := viper.ReadInConfig(); err != nil {
-		log.Fatalf("Error reading config file: %s", err)
+	// 파일 읽기 (경로를 직접 준 경우가 아니면 파일이 없어도 기본값으로 시작)
+	if err := v.ReadInConfig(); err != nil {
+		var notFound viper.ConfigFileNotFoundError
+		if path != "" || !errors.As(err, &notFound) {
+			return nil, fmt.Errorf("error reading config file: %w", err)
+		}
+	}
+	for key, value := range overrides {
+		v.Set(key, value)
 	}
 
 	// 읽은 값을 구조체에 매핑 (Unmarshalling)
-	if err := viper.Unmarshal(&AppConfig); err != nil {
-		log.Fatalf("Unable to decode into struct: %v", err)
+	var cfg Config
+	if err := v.Unmarshal(&cfg); err != nil {
+		return nil, fmt.Errorf("unable to decode into struct: %w", err)
+	}
+	return &cfg, nil
+}
+
+// Default: 기본값만 채운 설정 (테스트용)
+func Default() *Config {
+	var cfg Config
+	if err := newViper().Unmarshal(&cfg); err != nil {
+		panic(err)
+	}
+	return &cfg
+}
+
+// newViper: 기본값과 환경 변수 규칙을 등록한 viper
+// viper는 아는 키(기본값이 있는 키)만 환경 변수에서 찾으므로, 모든 항목에 기본값이 있어야 합니다.
+func newViper() *viper.Viper {
+	v := viper.New()
+	for key, value := range defaults {
+		v.SetDefault(key, value)
 	}
+	v.SetEnvPrefix(EnvPrefix)
+	v.SetEnvKeyReplacer(strings.NewReplacer(".", "_"))
+	v.AutomaticEnv() // 환경 변수도 읽을 수 있게 설정
+	return v
 }
@@ -0,0 +1,140 @@
+package config
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func writeConfig(t *testing.T, body string) string {
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	require.NoError(t, os.WriteFile(path, []byte(body), 0o644))
+	return path
+}
+
+// 기본값에 빠진 항목이 있으면 그 항목은 환경 변수로 바꿀 수 없음
+func TestDefaults_CoverEveryField(t *testing.T) {
+	var walk func(prefix string, typ reflect.Type)
+	walk = func(prefix string, typ reflect.Type) {
+		for i := 0; i < typ.NumField(); i++ {
+			field := typ.Field(i)
+			key := prefix + field.Tag.Get("mapstructure")
+			if field.Type.Kind() == reflect.Struct {
+				walk(key+".", field.Type)
+				continue
+			}
+			assert.Contains(t, defaults, key, "no default for %s", key)
+		}
+	}
+	walk("", reflect.TypeOf(Config{}))
+	assert.NoError(t, Default().Validate())
+}
+
+func TestLoad_MissingKeysUseDefaults(t *testing.T) {
+	path := writeConfig(t, "server:\n  port: \":9090\"\nlog:\n  level: debug\n")
+	require.NoError(t, Load(path, nil))
+
+	assert.Equal(t, ":9090", AppConfig.Server.Port)
+	assert.Equal(t, "debug", AppConfig.Log.Level)
+	assert.Equal(t, 10, AppConfig.Log.MaxSize)
+	assert.Equal(t, 8, AppConfig.Webhook.MaxAttempts)
+	assert.Equal(t, 24*time.Hour, AppConfig.Idempotency.TTL)
+	assert.Equal(t, StandbyReadOnly, AppConfig.Standby.Mode)
+}
+
+func TestLoad_EnvAndOverridePrecedence(t *testing.T) {
+	path := writeConfig(t, "server:\n  port: \":9090\"\nlog:\n  max_size: 20\n")
+	t.Setenv("TODO_SERVER_PORT", ":7070")
+	t.Setenv("TODO_LOG_MAX_SIZE", "50")
+	t.Setenv("TODO_CLUSTER_PEERS", "http://app-1:8080,http://app-2:8080")
+	t.Setenv("TODO_CLUSTER_TOKEN", "s3cret")
+	t.Setenv("TODO_JOBS_BASE_BACKOFF", "30s")
+
+	require.NoError(t, Load(path, nil))
+	assert.Equal(t, ":7070", AppConfig.Server.Port, "env > file")
+	assert.Equal(t, 50, AppConfig.Log.MaxSize)
+	assert.Equal(t, []string{"http://app-1:8080", "http://app-2:8080"}, AppConfig.Cluster.Peers)
+	assert.Equal(t, 30*time.Second, AppConfig.Jobs.BaseBackoff)
+
+	require.NoError(t, Load(path, map[string]interface{}{"server.port": ":6060"}))
+	assert.Equal(t, ":6060", AppConfig.Server.Port, "flag > env")
+}
+
+func TestLoad_ReportsEveryInvalidField(t *testing.T) {
+	path := writeConfig(t, `
+server:
+  port: "8080"
+  tls:
+    enabled: true
+    cert_file: /nonexistent/server.crt
+    redirect_port: "80"
+log:
+  level: loud
+  max_size: 0
+jobs:
+  workers: 0
+  base_backoff: "1h"
+  max_backoff: "1m"
+cluster:
+  peers: ["app-2:8080"]
+standby:
+  mode: sleep
+cors:
+  allowed_origins: ["*", "app.example.com"]
+  allow_credentials: true
+`)
+	before := Default()
+	AppConfig = before
+
+	err := Load(path, nil)
+	var verr *ValidationError
+	require.True(t, errors.As(err, &verr), "got %v", err)
+
+	var fields []string
+	for _, f := range verr.Fields {
+		fields = append(fields, f.Field)
+	}
+	assert.ElementsMatch(t, []string{
+		"server.port", "server.tls.cert_file", "server.tls.key_file", "server.tls.redirect_port", "log.level", "log.max_size", "jobs.workers", "jobs.max_backoff", "cluster.peers[0]", "cluster.token", "standby.mode",
+		"cors.allowed_origins[0]", "cors.allowed_origins[1]",
+	}, fields)
+	assert.Same(t, before, AppConfig, "invalid config must not replace the current one")
+}
+
+func TestValidate_ReplicationRequiresToken(t *testing.T) {
+	cfg := Default()
+	cfg.Replication.Enabled = true
+	cfg.Replication.Source = "http://app-1:8080"
+
+	var verr *ValidationError
+	require.True(t, errors.As(cfg.Validate(), &verr))
+	require.Len(t, verr.Fields, 1)
+	assert.Equal(t, "cluster.token", verr.Fields[0].Field)
+
+	cfg.Cluster.Token = "s3cret"
+	assert.NoError(t, cfg.Validate())
+}
+
+func TestLoad_ExplicitFileMustExist(t *testing.T) {
+	assert.Error(t, Load(filepath.Join(t.TempDir(), "missing.yaml"), nil))
+}
+
+func TestEffective_RedactsSecrets(t *testing.T) {
+	cfg := Default()
+	cfg.Cluster.Token = "s3cret"
+
+	eff := cfg.Effective()
+	cluster := eff["cluster"].(map[string]interface{})
+	assert.Equal(t, Redacted, cluster["token"])
+	assert.Equal(t, "2s", cluster["heartbeat_interval"])
+	assert.Equal(t, ":8080", eff["server"].(map[string]interface{})["port"])
+
+	cfg.Cluster.Token = ""
+	assert.Equal(t, "", cfg.Effective()["cluster"].(map[string]interface{})["token"])
+}
@@ -0,0 +1,82 @@
+package config
+
+// defaults: 모든 설정 항목의 기본값 (config.yaml이나 환경 변수에 없으면 이 값)
+// Config에 항목을 추가하면 여기에도 추가해야 환경 변수로 바꿀 수 있습니다. (TestDefaults_CoverEveryField)
+var defaults = map[string]interface{}{
+	"server.port":                 ":8080",
+	"server.tls.enabled":          false,
+	"server.tls.cert_file":        "",
+	"server.tls.key_file":         "",
+	"server.tls.reload_interval":  "1m",
+	"server.tls.client_ca_file":   "",
+	"server.tls.admin_client_cns": []string{},
+	"server.tls.redirect_port":    "",
+
+	"database.file":         "/data/todos.db",
+	"database.auto_migrate": true,
+
+	"log.level":       "info",
+	"log.path":        "./logs/server.log",
+	"log.max_size":    10, // MB
+	"log.max_backups": 5,
+	"log.max_age":     30, // 일
+
+	"idempotency.ttl": "24h",
+
+	"undo.window": "10m",
+
+	"webhook.max_attempts":  8,
+	"webhook.base_backoff":  "10s",
+	"webhook.max_backoff":   "1h",
+	"webhook.timeout":       "10s",
+	"webhook.poll_interval": "2s",
+
+	"outbox.poll_interval": "1s",
+	"outbox.max_attempts":  10,
+	"outbox.retention":     "168h",
+
+	"jobs.workers":            4,
+	"jobs.poll_interval":      "1s",
+	"jobs.visibility_timeout": "5m",
+	"jobs.max_attempts":       5,
+	"jobs.base_backoff":       "10s",
+	"jobs.max_backoff":        "1h",
+	"jobs.retention":          "168h",
+
+	"health.check_timeout": "2s",
+	"health.min_free_mb":   100,
+
+	"cluster.node_name":          "",
+	"cluster.peers":              []string{},
+	"cluster.heartbeat_interval": "2s",
+	"cluster.peer_timeout":       "6s",
+	"cluster.token":              "",
+
+	"standby.mode": StandbyReadOnly,
+
+	"replication.enabled":    false,
+	"replication.source":     "",
+	"replication.batch_size": 500,
+	"replication.long_poll":  "10s",
+	"replication.max_lag":    "30s",
+
+	"cors.allowed_origins":   []string{},
+	"cors.allowed_methods":   []string{"GET", "POST", "PUT", "PATCH", "DELETE"},
+	"cors.allowed_headers":   []string{"Content-Type", "Authorization", "Idempotency-Key", "X-Actor", "X-Request-ID", "X-Timezone", "X-CSRF-Token"},
+	"cors.exposed_headers":   []string{"X-Request-ID", "Idempotent-Replayed"},
+	"cors.allow_credentials": false,
+	"cors.max_age":           "10m",
+
+	"security.content_security_policy": "default-src 'self'; script-src 'self' 'unsafe-inline'; style-src 'self' 'unsafe-inline' https://cdn.jsdelivr.net; img-src 'self' data:; connect-src 'self'; frame-ancestors 'none'; base-uri 'self'; form-action 'self'",
+	"security.hsts_max_age":            "8760h", // 1년
+	"security.frame_options":           "DENY",
+	"security.referrer_policy":         "strict-origin-when-cross-origin",
+	"security.csrf.enabled":            false,
+	"security.csrf.session_cookies":    []string{},
+	"security.csrf.cookie_name":        "csrf_token",
+	"security.csrf.header_name":        "X-CSRF-Token",
+
+	"backup.dir":      "/data/backups",
+	"backup.interval": "24h",
+	"backup.keep":     7,
+}
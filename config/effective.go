@@ -0,0 +1,96 @@
+package config
+
+import (
+	"reflect"
+	"strings"
+	"time"
+)
+
+// Redacted: 값이 있는 비밀 항목(`secret:"true"`) 대신 보여주는 값
+const Redacted = "********"
+
+// Effective: 실제로 적용된 설정을 config.yaml과 같은 키 구조의 map으로 (비밀 항목은 가림, 기간은 "10s" 형식)
+// GET /admin/config처럼 밖으로 보여줄 때 사용합니다.
+func (c *Config) Effective() map[string]interface{} {
+	return effectiveStruct(reflect.ValueOf(*c))
+}
+
+func effectiveStruct(v reflect.Value) map[string]interface{} {
+	out := make(map[string]interface{}, v.NumField())
+	t := v.Type()
+	for i := 0; i < v.NumField(); i++ {
+		field := t.Field(i)
+		key := field.Tag.Get("mapstructure")
+		if key == "" || key == "-" {
+			continue
+		}
+		value := v.Field(i)
+		if value.Kind() == reflect.Struct {
+			out[key] = effectiveStruct(value)
+			continue
+		}
+		out[key] = displayValue(value, field.Tag.Get("secret") == "true")
+	}
+	return out
+}
+
+// displayValue: 밖으로 보여줄 값 하나
+func displayValue(value reflect.Value, secret bool) interface{} {
+	switch {
+	case secret:
+		if value.IsZero() {
+			return ""
+		}
+		return Redacted
+	case value.Type() == reflect.TypeOf(time.Duration(0)):
+		return time.Duration(value.Int()).String()
+	default:
+		return value.Interface()
+	}
+}
+
+// leaf: 설정 항목 하나 ("server.port" 같은 점 표기 키)
+type leaf struct {
+	key    string
+	value  reflect.Value
+	secret bool
+}
+
+// leaves: 모든 설정 항목 (구조체 순서대로)
+func leaves(c *Config) []leaf {
+	var out []leaf
+	var walk func(prefix string, v reflect.Value)
+	walk = func(prefix string, v reflect.Value) {
+		t := v.Type()
+		for i := 0; i < v.NumField(); i++ {
+			field := t.Field(i)
+			key := prefix + field.Tag.Get("mapstructure")
+			if v.Field(i).Kind() == reflect.Struct {
+				walk(key+".", v.Field(i))
+				continue
+			}
+			out = append(out, leaf{key: key, value: v.Field(i), secret: field.Tag.Get("secret") == "true"})
+		}
+	}
+	walk("", reflect.ValueOf(c).Elem())
+	return out
+}
+
+// fieldByKey: "server.port" 같은 키에 해당하는 필드 (c는 포인터여야 값을 바꿀 수 있음)
+func fieldByKey(c *Config, key string) reflect.Value {
+	v := reflect.ValueOf(c).Elem()
+	for _, part := range strings.Split(key, ".") {
+		t := v.Type()
+		found := false
+		for i := 0; i < t.NumField(); i++ {
+			if t.Field(i).Tag.Get("mapstructure") == part {
+				v, found = v.Field(i), true
+				break
+			}
+		}
+		if !found {
+			return reflect.Value{}
+		}
+	}
+	return v
+}
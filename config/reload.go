@@ -0,0 +1,177 @@
+package config
+
+import (
+	"errors"
+	"log"
+	"reflect"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+)
+
+// reloadable: 재시작 없이 바로 적용되는 설정 키 ("."으로 끝나면 그 섹션 전체)
+// 실제 적용은 OnReload 구독자가 합니다. 여기 없는 키가 바뀌면 실행 중인 값은 그대로 두고 "재시작 필요"로 알려줍니다.
+var reloadable = []string{
+	"server.tls.admin_client_cns", // /admin 허용 CN (요청마다 읽음, 인증서 파일 자체는 server.tls.reload_interval마다 다시 읽음)
+	"log.level",                   // middleware 로거 레벨
+	"backup.interval",             // 자동 백업 주기
+	"backup.keep",                 // 백업 보관 개수
+	"cors.",                       // 요청마다 Reloader.Current()에서 읽음
+	"security.",                   // 보안 헤더, CSRF (요청마다 읽음)
+}
+
+// IsReloadable: key가 재시작 없이 적용되는지
+func IsReloadable(key string) bool {
+	for _, r := range reloadable {
+		if key == r || (strings.HasSuffix(r, ".") && strings.HasPrefix(key, r)) {
+			return true
+		}
+	}
+	return false
+}
+
+// Change: 바뀐 설정 항목 하나 (비밀 항목은 값 대신 Redacted)
+type Change struct {
+	Key string      `json:"key" example:"log.level"`
+	Old interface{} `json:"old"` // 예: "info"
+	New interface{} `json:"new"` // 예: "debug"
+}
+
+// ReloadStatus: 설정을 다시 읽은 결과
+type ReloadStatus struct {
+	At              time.Time    `json:"at"`
+	Applied         []Change     `json:"applied"`          // 바로 적용된 항목
+	RestartRequired []Change     `json:"restart_required"` // 파일에는 바뀌었지만 재시작해야 적용되는 항목 (실행 중인 값은 이전 값)
+	Errors          []FieldError `json:"errors,omitempty"` // 검증 실패 항목 (하나라도 있으면 아무것도 적용하지 않음)
+	Error           string       `json:"error,omitempty"`  // 파일을 읽지 못한 경우 등
+}
+
+// Reloader: 실행 중인 설정을 들고 있다가, 설정 파일이 바뀌면 다시 읽고 검증해서 바꿀 수 있는 항목만 한꺼번에 교체
+// Current()는 항상 완전한 설정 하나를 돌려주므로, 읽는 쪽이 반쯤 바뀐 설정을 보는 일은 없습니다.
+type Reloader struct {
+	current atomic.Pointer[Config]
+	last    atomic.Pointer[ReloadStatus]
+
+	mu          sync.Mutex // Reload가 겹치지 않도록
+	subscribers []func(old, new *Config)
+	watching    bool
+	path        string
+	overrides   map[string]interface{}
+}
+
+// 생성자 (initial: 시작할 때 읽은 설정)
+func NewReloader(initial *Config) *Reloader {
+	r := &Reloader{}
+	r.current.Store(initial)
+	return r
+}
+
+// Current: 지금 적용된 설정 (바꾸지 말 것)
+func (r *Reloader) Current() *Config {
+	return r.current.Load()
+}
+
+// OnReload: 설정이 바뀐 뒤 호출할 함수 등록 (바로 적용되는 항목이 하나라도 바뀌었을 때, 등록 순서대로)
+func (r *Reloader) OnReload(fn func(old, new *Config)) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.subscribers = append(r.subscribers, fn)
+}
+
+// LastReload: 마지막으로 다시 읽은 결과 (아직 없으면 false)
+func (r *Reloader) LastReload() (ReloadStatus, bool) {
+	if s := r.last.Load(); s != nil {
+		return *s, true
+	}
+	return ReloadStatus{}, false
+}
+
+// Watch: Load와 같은 설정 파일(path, overrides)을 지켜보다가 바뀌면 Reload
+// 명령줄 플래그(overrides)는 다시 읽을 때도 계속 우선합니다.
+func (r *Reloader) Watch(path string, overrides map[string]interface{}) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.watching {
+		return errors.New("config: already watching")
+	}
+	v := newViper()
+	if _, err := read(v, path, overrides); err != nil {
+		return err
+	}
+	if v.ConfigFileUsed() == "" {
+		return errors.New("config: no config file to watch")
+	}
+	r.watching, r.path, r.overrides = true, path, overrides
+
+	v.OnConfigChange(func(e fsnotify.Event) {
+		r.Reload()
+	})
+	v.WatchConfig()
+	log.Printf("👀 [Config] %s 변경 감시 시작", v.ConfigFileUsed())
+	return nil
+}
+
+// Reload: 설정 파일을 지금 다시 읽어서 적용 (POST /admin/config/reload, 파일 변경 감지)
+// 검증에 실패하면 아무것도 바꾸지 않고, 재시작이 필요한 항목은 실행 중인 값을 그대로 둡니다.
+func (r *Reloader) Reload() ReloadStatus {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	status := ReloadStatus{At: time.Now(), Applied: []Change{}, RestartRequired: []Change{}}
+	defer func() { r.last.Store(&status) }()
+
+	if !r.watching {
+		status.Error = "config file is not being watched (server started without a config file)"
+		return status
+	}
+	next, err := read(newViper(), r.path, r.overrides)
+	if err != nil {
+		status.Error = err.Error()
+		log.Printf("❌ [Config] 다시 읽기 실패: %v", err)
+		return status
+	}
+	if err := next.Validate(); err != nil {
+		var verr *ValidationError
+		if errors.As(err, &verr) {
+			status.Errors = verr.Fields
+		}
+		status.Error = err.Error()
+		log.Printf("❌ [Config] 새 설정을 적용하지 않음: %v", err)
+		return status
+	}
+
+	old := r.Current()
+	applied := *old // 바로 적용되는 항목만 새 값으로
+	nextLeaves := leaves(next)
+	for i, l := range leaves(old) {
+		n := nextLeaves[i]
+		if reflect.DeepEqual(l.value.Interface(), n.value.Interface()) {
+			continue
+		}
+		change := Change{Key: l.key, Old: displayValue(l.value, l.secret), New: displayValue(n.value, n.secret)}
+		if IsReloadable(l.key) {
+			fieldByKey(&applied, l.key).Set(n.value)
+			status.Applied = append(status.Applied, change)
+		} else {
+			status.RestartRequired = append(status.RestartRequired, change)
+		}
+	}
+
+	for _, c := range status.Applied {
+		log.Printf("🔄 [Config] 적용: %s %v → %v", c.Key, c.Old, c.New)
+	}
+	for _, c := range status.RestartRequired {
+		log.Printf("⚠️ [Config] 재시작해야 적용: %s %v → %v", c.Key, c.Old, c.New)
+	}
+	if len(status.Applied) == 0 {
+		return status
+	}
+	r.current.Store(&applied)
+	for _, fn := range r.subscribers {
+		fn(old, &applied)
+	}
+	return status
+}
@@ -0,0 +1,81 @@
+package config
+
+import (
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newWatchedReloader(t *testing.T, body string) (*Reloader, string) {
+	path := writeConfig(t, body)
+	require.NoError(t, Load(path, nil))
+	r := NewReloader(AppConfig)
+	require.NoError(t, r.Watch(path, nil))
+	return r, path
+}
+
+func TestReloader_AppliesOnlyReloadableKeys(t *testing.T) {
+	r, path := newWatchedReloader(t, "log:\n  level: info\nbackup:\n  keep: 7\n")
+	before := r.Current()
+
+	var calls int
+	r.OnReload(func(old, cfg *Config) {
+		calls++
+		assert.Same(t, before, old)
+		assert.Equal(t, "debug", cfg.Log.Level)
+	})
+
+	require.NoError(t, os.WriteFile(path, []byte("log:\n  level: debug\nbackup:\n  keep: 3\nserver:\n  port: \":9090\"\ncluster:\n  token: new-secret\n"), 0o644))
+	status := r.Reload()
+
+	assert.Empty(t, status.Error)
+	assert.ElementsMatch(t, []Change{
+		{Key: "log.level", Old: "info", New: "debug"},
+		{Key: "backup.keep", Old: 7, New: 3},
+	}, status.Applied)
+	assert.ElementsMatch(t, []Change{
+		{Key: "server.port", Old: ":8080", New: ":9090"},
+		{Key: "cluster.token", Old: "", New: Redacted},
+	}, status.RestartRequired)
+
+	cur := r.Current()
+	assert.Equal(t, "debug", cur.Log.Level)
+	assert.Equal(t, 3, cur.Backup.Keep)
+	assert.Equal(t, ":8080", cur.Server.Port, "restart-only keys keep the running value")
+	assert.Equal(t, "info", before.Log.Level, "previous config is not mutated")
+	assert.Equal(t, 1, calls)
+
+	last, ok := r.LastReload()
+	require.True(t, ok)
+	assert.Equal(t, status.At, last.At)
+}
+
+func TestReloader_InvalidConfigKeepsCurrent(t *testing.T) {
+	r, path := newWatchedReloader(t, "log:\n  level: info\n")
+	before := r.Current()
+
+	require.NoError(t, os.WriteFile(path, []byte("log:\n  level: loud\n  max_size: -1\n"), 0o644))
+	status := r.Reload()
+
+	assert.NotEmpty(t, status.Error)
+	assert.Len(t, status.Errors, 2)
+	assert.Empty(t, status.Applied)
+	assert.Same(t, before, r.Current())
+}
+
+func TestReloader_WatchesFile(t *testing.T) {
+	r, path := newWatchedReloader(t, "log:\n  level: info\n")
+
+	require.NoError(t, os.WriteFile(path, []byte("log:\n  level: warn\n"), 0o644))
+	assert.Eventually(t, func() bool {
+		return r.Current().Log.Level == "warn"
+	}, 5*time.Second, 20*time.Millisecond)
+}
+
+func TestReloader_NotWatching(t *testing.T) {
+	r := NewReloader(Default())
+	assert.NotEmpty(t, r.Reload().Error)
+}
@@ -0,0 +1,232 @@
+package config
+
+import (
+	"fmt"
+	"net"
+	"net/url"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Standby 동작 방식 (standby.mode, middleware.StandbyGuard가 사용)
+const (
+	StandbyReject   = "reject"    // 모든 요청 503 (nginx가 Active로 넘기도록)
+	StandbyReadOnly = "read_only" // 읽기 요청은 처리, 쓰기 요청만 503 + Active 주소 안내
+)
+
+// FieldError: 잘못된 설정 항목 하나
+type FieldError struct {
+	Field   string `json:"field"` // 예: server.port
+	Message string `json:"message"`
+}
+
+// ValidationError: 잘못된 설정 항목 전부 (하나씩 고쳐가며 다시 시작하지 않도록 한 번에 보여줌)
+type ValidationError struct {
+	Fields []FieldError
+}
+
+func (e *ValidationError) Error() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "invalid config (%d):", len(e.Fields))
+	for _, f := range e.Fields {
+		fmt.Fprintf(&b, "\n  - %s: %s", f.Field, f.Message)
+	}
+	return b.String()
+}
+
+// validator: 검사하면서 문제를 모음
+type validator struct {
+	fields []FieldError
+}
+
+func (v *validator) fail(field, format string, args ...interface{}) {
+	v.fields = append(v.fields, FieldError{Field: field, Message: fmt.Sprintf(format, args...)})
+}
+
+func (v *validator) positive(field string, n int) {
+	if n <= 0 {
+		v.fail(field, "must be positive, got %d", n)
+	}
+}
+
+func (v *validator) nonNegative(field string, n int) {
+	if n < 0 {
+		v.fail(field, "must not be negative, got %d", n)
+	}
+}
+
+func (v *validator) duration(field string, d time.Duration) {
+	if d <= 0 {
+		v.fail(field, "must be a positive duration (e.g. \"10s\"), got %q", d)
+	}
+}
+
+func (v *validator) notEmpty(field, s string) {
+	if strings.TrimSpace(s) == "" {
+		v.fail(field, "must not be empty")
+	}
+}
+
+func (v *validator) oneOf(field, s string, allowed ...string) {
+	for _, a := range allowed {
+		if s == a {
+			return
+		}
+	}
+	v.fail(field, "must be one of %s, got %q", strings.Join(allowed, " | "), s)
+}
+
+func (v *validator) file(field, path string) {
+	if strings.TrimSpace(path) == "" {
+		v.fail(field, "must not be empty")
+		return
+	}
+	if st, err := os.Stat(path); err != nil {
+		v.fail(field, "%v", err)
+	} else if st.IsDir() {
+		v.fail(field, "%s is a directory", path)
+	}
+}
+
+func (v *validator) httpURL(field, s string) {
+	u, err := url.Parse(s)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		v.fail(field, "must be an http(s) URL like http://app-1:8080, got %q", s)
+	}
+}
+
+// Validate: 모든 항목을 검사해서 잘못된 항목을 전부 담은 *ValidationError (문제가 없으면 nil)
+func (c *Config) Validate() error {
+	v := &validator{}
+
+	if _, port, err := net.SplitHostPort(c.Server.Port); err != nil {
+		v.fail("server.port", "must be [host]:port like \":8080\", got %q", c.Server.Port)
+	} else if n, err := strconv.Atoi(port); err != nil || n < 1 || n > 65535 {
+		v.fail("server.port", "port must be 1-65535, got %q", port)
+	}
+
+	if tls := c.Server.TLS; tls.Enabled {
+		v.file("server.tls.cert_file", tls.CertFile)
+		v.file("server.tls.key_file", tls.KeyFile)
+		if tls.ClientCAFile != "" {
+			v.file("server.tls.client_ca_file", tls.ClientCAFile)
+		}
+		if tls.ReloadInterval < 0 {
+			v.fail("server.tls.reload_interval", "must not be negative (0 disables reloading), got %q", tls.ReloadInterval)
+		}
+		if tls.RedirectPort != "" {
+			if _, _, err := net.SplitHostPort(tls.RedirectPort); err != nil {
+				v.fail("server.tls.redirect_port", "must be [host]:port like \":80\", got %q", tls.RedirectPort)
+			} else if tls.RedirectPort == c.Server.Port {
+				v.fail("server.tls.redirect_port", "must differ from server.port (%s)", c.Server.Port)
+			}
+		}
+	} else if c.Server.TLS.ClientCAFile != "" || len(c.Server.TLS.AdminClientCNs) > 0 {
+		v.fail("server.tls.client_ca_file", "client certificates require server.tls.enabled")
+	}
+
+	v.notEmpty("database.file", c.Database.File)
+
+	v.oneOf("log.level", c.Log.Level, "debug", "info", "warn", "error")
+	v.notEmpty("log.path", c.Log.Path)
+	v.positive("log.max_size", c.Log.MaxSize)
+	v.nonNegative("log.max_backups", c.Log.MaxBackups)
+	v.nonNegative("log.max_age", c.Log.MaxAge)
+
+	v.duration("idempotency.ttl", c.Idempotency.TTL)
+	v.duration("undo.window", c.Undo.Window)
+
+	v.positive("webhook.max_attempts", c.Webhook.MaxAttempts)
+	v.duration("webhook.base_backoff", c.Webhook.BaseBackoff)
+	v.duration("webhook.max_backoff", c.Webhook.MaxBackoff)
+	if c.Webhook.MaxBackoff < c.Webhook.BaseBackoff {
+		v.fail("webhook.max_backoff", "must be at least webhook.base_backoff (%s)", c.Webhook.BaseBackoff)
+	}
+	v.duration("webhook.timeout", c.Webhook.Timeout)
+	v.duration("webhook.poll_interval", c.Webhook.PollInterval)
+
+	v.duration("outbox.poll_interval", c.Outbox.PollInterval)
+	v.positive("outbox.max_attempts", c.Outbox.MaxAttempts)
+	v.duration("outbox.retention", c.Outbox.Retention)
+
+	v.positive("jobs.workers", c.Jobs.Workers)
+	v.duration("jobs.poll_interval", c.Jobs.PollInterval)
+	v.duration("jobs.visibility_timeout", c.Jobs.VisibilityTimeout)
+	v.positive("jobs.max_attempts", c.Jobs.MaxAttempts)
+	v.duration("jobs.base_backoff", c.Jobs.BaseBackoff)
+	v.duration("jobs.max_backoff", c.Jobs.MaxBackoff)
+	if c.Jobs.MaxBackoff < c.Jobs.BaseBackoff {
+		v.fail("jobs.max_backoff", "must be at least jobs.base_backoff (%s)", c.Jobs.BaseBackoff)
+	}
+	v.duration("jobs.retention", c.Jobs.Retention)
+
+	v.duration("health.check_timeout", c.Health.CheckTimeout)
+
+	for i, peer := range c.Cluster.Peers {
+		v.httpURL(fmt.Sprintf("cluster.peers[%d]", i), peer)
+	}
+	if len(c.Cluster.Peers) > 0 || c.Replication.Enabled {
+		// heartbeat로 Active를 강등시키거나 변경분으로 모든 할 일을 받아갈 수 있으므로 서버 간 통신에는 토큰 필수
+		v.notEmpty("cluster.token", c.Cluster.Token)
+	}
+	v.duration("cluster.heartbeat_interval", c.Cluster.HeartbeatInterval)
+	v.duration("cluster.peer_timeout", c.Cluster.PeerTimeout)
+	if c.Cluster.PeerTimeout <= c.Cluster.HeartbeatInterval {
+		v.fail("cluster.peer_timeout", "must be longer than cluster.heartbeat_interval (%s)", c.Cluster.HeartbeatInterval)
+	}
+
+	v.oneOf("standby.mode", c.Standby.Mode, StandbyReject, StandbyReadOnly)
+
+	if c.Replication.Enabled {
+		if c.Replication.Source != "" {
+			v.httpURL("replication.source", c.Replication.Source)
+		} else if len(c.Cluster.Peers) == 0 {
+			v.fail("replication.source", "must be set when replication is enabled and cluster.peers is empty")
+		}
+		v.positive("replication.batch_size", c.Replication.BatchSize)
+		v.duration("replication.long_poll", c.Replication.LongPoll)
+		v.duration("replication.max_lag", c.Replication.MaxLag)
+	}
+
+	for i, origin := range c.CORS.AllowedOrigins {
+		field := fmt.Sprintf("cors.allowed_origins[%d]", i)
+		if origin == "*" {
+			if c.CORS.AllowCredentials {
+				v.fail(field, "\"*\" cannot be used with cors.allow_credentials (list the origins instead)")
+			}
+			continue
+		}
+		u, err := url.Parse(strings.Replace(origin, "://*.", "://wildcard.", 1))
+		if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" || (u.Path != "" && u.Path != "/") {
+			v.fail(field, "must be \"*\" or an origin like https://app.example.com or https://*.example.com, got %q", origin)
+		}
+	}
+	if c.CORS.MaxAge < 0 {
+		v.fail("cors.max_age", "must not be negative, got %q", c.CORS.MaxAge)
+	}
+
+	if c.Security.HSTSMaxAge < 0 {
+		v.fail("security.hsts_max_age", "must not be negative (0 disables HSTS), got %q", c.Security.HSTSMaxAge)
+	}
+	v.oneOf("security.frame_options", c.Security.FrameOptions, "", "DENY", "SAMEORIGIN")
+	if csrf := c.Security.CSRF; csrf.Enabled {
+		if len(csrf.SessionCookies) == 0 {
+			v.fail("security.csrf.session_cookies", "must list the session cookie names when CSRF protection is enabled")
+		}
+		v.notEmpty("security.csrf.cookie_name", csrf.CookieName)
+		v.notEmpty("security.csrf.header_name", csrf.HeaderName)
+	}
+
+	v.notEmpty("backup.dir", c.Backup.Dir)
+	if c.Backup.Interval < 0 {
+		v.fail("backup.interval", "must not be negative (0 disables automatic backups), got %q", c.Backup.Interval)
+	}
+	v.nonNegative("backup.keep", c.Backup.Keep)
+
+	if len(v.fields) > 0 {
+		return &ValidationError{Fields: v.fields}
+	}
+	return nil
+}
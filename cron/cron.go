@@ -1,38 +1,128 @@
+// Package cron: 주기 작업과 백그라운드 작업 핸들러 등록
+// 실제 실행은 jobs.Queue가 하므로 재시작해도 사라지지 않고, GET /admin/queue에서 확인할 수 있습니다.
 package cron
 
 import (
+	"context"
+	"fmt"
 	"log"
 	"time"
 
-	"go_study/global"
+	"go_study/backup"
+	"go_study/jobs"
+	"go_study/model"
 	"go_study/repository"
 )
 
-// StartStatsJob: 1분마다 통계를 조회해서 알림을 보내는 함수
-func StartStatsJob(repo repository.TodoRepository) {
-	// 별도의 고루틴(일꾼)을 생성해서 메인 서버를 방해하지 않게 함
-	go func() {
-		// 1분(Minute) 간격으로 울리는 알람 시계 생성
-		ticker := time.NewTicker(1 * time.Minute)
-		defer ticker.Stop()
-
-		log.Println("⏰ [Cron] 통계 리포트 작업이 시작되었습니다 (1분 간격)")
-
-		// 알람이 울릴 때마다 루프 실행 (무한 루프)
-		for range ticker.C {
-			if !global.IsActive() {
-				continue
-			}
-			// 1. DB 조회 (기존에 만들어둔 GetStats 이용)
-			total, done, err := repo.GetStats()
-			if err != nil {
-				log.Printf("❌ [Cron] 통계 조회 실패: %v\n", err)
-				continue
-			}
-
-			// 2. Slack 전송 (여기서는 로그로 흉내)
-			// 실제로는 여기서 http.Post("https://hooks.slack.com/...", ...)를 호출
-			log.Printf("🔔 [Slack Bot] 현재 리포트 도착! 📝 총 할 일: %d개 / ✅ 완료: %d개", total, done)
-		}
-	}()
+// RegisterStatsJob: 1분마다 통계를 조회해서 알림을 보내는 작업
+func RegisterStatsJob(q *jobs.Queue, repo repository.TodoRepository) {
+	q.Register(model.JobStatsReport, 1, func(ctx context.Context, job model.Job) error {
+		// 1. DB 조회 (기존에 만들어둔 GetStats 이용)
+		total, done, err := repo.GetStats()
+		if err != nil {
+			return fmt.Errorf("통계 조회 실패: %w", err)
+		}
+
+		// 2. Slack 전송 (여기서는 로그로 흉내)
+		// 실제로는 여기서 http.Post("https://hooks.slack.com/...", ...)를 호출
+		log.Printf("🔔 [Slack Bot] 현재 리포트 도착! 📝 총 할 일: %d개 / ✅ 완료: %d개", total, done)
+		return nil
+	})
+	q.Every(model.JobStatsReport, 1*time.Minute, nil)
+}
+
+// RegisterDailyReportJob: POST /reports로 들어온 리포트 생성 작업
+func RegisterDailyReportJob(q *jobs.Queue, repo repository.TodoRepository) {
+	q.Register(model.JobDailyReport, 1, func(ctx context.Context, job model.Job) error {
+		log.Println("📝 [Jobs] 리포트 데이터 수집 시작...")
+
+		// ✨ 진짜 DB 조회: 미완료된 할 일 가져오기
+		pendingTodos, err := repo.GetPendingTodos()
+		if err != nil {
+			return fmt.Errorf("리포트 생성 실패: %w", err)
+		}
+
+		// 리포트 내용 작성 (파일로 저장하거나 이메일 보내는 척)
+		reportContent := fmt.Sprintf("=== Daily Report ===\n남은 할 일: %d건\n", len(pendingTodos))
+		for _, t := range pendingTodos {
+			reportContent += fmt.Sprintf("- [ ] %s\n", t.Task)
+		}
+
+		// 시간 조금 걸리는 척 (리포트 파일 생성 시뮬레이션)
+		select {
+		case <-time.After(2 * time.Second):
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+
+		log.Printf("✅ [Jobs] 리포트 생성 완료! (작업 #%d)\n%s", job.ID, reportContent)
+		// 실제로는 여기서 smtp.SendMail() 등을 호출함
+		return nil
+	})
+}
+
+// RegisterIdempotencyCleanupJob: 1시간마다 만료된 Idempotency-Key 레코드를 정리하는 작업
+func RegisterIdempotencyCleanupJob(q *jobs.Queue, repo repository.IdempotencyRepository) {
+	q.Register(model.JobIdempotencyCleanup, 1, func(ctx context.Context, job model.Job) error {
+		deleted, err := repo.DeleteExpired(time.Now())
+		if err != nil {
+			return fmt.Errorf("Idempotency 레코드 정리 실패: %w", err)
+		}
+		if deleted > 0 {
+			log.Printf("🧹 [Cron] 만료된 Idempotency 레코드 %d건 삭제", deleted)
+		}
+		return nil
+	})
+	q.Every(model.JobIdempotencyCleanup, 1*time.Hour, nil)
+}
+
+// RegisterOutboxCleanupJob: 1시간마다 보낸 지 retention이 지난 outbox 이벤트를 정리하는 작업
+func RegisterOutboxCleanupJob(q *jobs.Queue, repo repository.OutboxRepository, retention time.Duration) {
+	q.Register(model.JobOutboxCleanup, 1, func(ctx context.Context, job model.Job) error {
+		deleted, err := repo.DeleteSentBefore(time.Now().Add(-retention))
+		if err != nil {
+			return fmt.Errorf("outbox 이벤트 정리 실패: %w", err)
+		}
+		if deleted > 0 {
+			log.Printf("🧹 [Cron] 보낸 outbox 이벤트 %d건 삭제", deleted)
+		}
+		return nil
+	})
+	q.Every(model.JobOutboxCleanup, 1*time.Hour, nil)
+}
+
+// RegisterQueueCleanupJob: 1시간마다 끝난 지 retention이 지난 성공 작업을 정리하는 작업
+func RegisterQueueCleanupJob(q *jobs.Queue, repo repository.JobRepository, retention time.Duration) {
+	q.Register(model.JobQueueCleanup, 1, func(ctx context.Context, job model.Job) error {
+		deleted, err := repo.DeleteFinishedBefore(time.Now().Add(-retention))
+		if err != nil {
+			return fmt.Errorf("작업 정리 실패: %w", err)
+		}
+		if deleted > 0 {
+			log.Printf("🧹 [Cron] 끝난 작업 %d건 삭제", deleted)
+		}
+		return nil
+	})
+	q.Every(model.JobQueueCleanup, 1*time.Hour, nil)
+}
+
+// RegisterBackupJob: interval마다 DB 스냅샷 백업을 만들고 최신 keep()개만 남기는 작업 (interval이 0이면 예약하지 않음)
+// 주기를 바꾸려면 q.Every(model.JobBackup, 새 주기, nil)
+func RegisterBackupJob(q *jobs.Queue, mgr *backup.Manager, interval time.Duration, keep func() int) {
+	q.Register(model.JobBackup, 1, func(ctx context.Context, job model.Job) error {
+		info, err := mgr.Create(ctx)
+		if err != nil {
+			return fmt.Errorf("백업 실패: %w", err)
+		}
+		log.Printf("💾 [Cron] DB 백업 완료: %s (%d bytes)", info.Name, info.SizeBytes)
+		removed, err := mgr.Prune(keep())
+		if err != nil {
+			return fmt.Errorf("오래된 백업 정리 실패: %w", err)
+		}
+		if len(removed) > 0 {
+			log.Printf("🧹 [Cron] 오래된 백업 %d개 삭제", len(removed))
+		}
+		return nil
+	})
+	q.Every(model.JobBackup, interval, nil)
 }
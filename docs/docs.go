@@ -18,19 +18,66 @@ const docTemplate = `{
     "host": "{{.Host}}",
     "basePath": "{{.BasePath}}",
     "paths": {
-        "/dashboard": {
+        "/admin/audit": {
             "get": {
-                "description": "사용자 정보와 할 일 통계를 병렬로 조회하여 반환합니다.",
-                "consumes": [
-                    "application/json"
-                ],
+                "description": "할 일 변경과 관리자 API 호출 기록을 최신순으로 조회합니다.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Dashboard"
+                    "Admin"
+                ],
+                "summary": "감사 로그 조회",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "요청자",
+                        "name": "actor",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "todo.create | todo.update | todo.delete | todo.restore | admin.request",
+                        "name": "action",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "대상 ID",
+                        "name": "entity_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "요청 ID",
+                        "name": "request_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "시작 시각 (RFC3339, 포함)",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "끝 시각 (RFC3339, 미포함)",
+                        "name": "to",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "개수 (기본 100, 최대 1000)",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "건너뛸 개수",
+                        "name": "offset",
+                        "in": "query"
+                    }
                 ],
-                "summary": "대시보드 데이터 조회",
                 "responses": {
                     "200": {
                         "description": "OK",
@@ -45,33 +92,112 @@ const docTemplate = `{
                                         "data": {
                                             "type": "array",
                                             "items": {
-                                                "type": "string"
+                                                "$ref": "#/definitions/model.AuditEvent"
                                             }
                                         }
                                     }
                                 }
                             ]
                         }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/model.WebResponse"
+                        }
                     }
                 }
             }
         },
-        "/reports": {
-            "post": {
-                "description": "리포트 생성을 비동기로 요청합니다. (처리 결과는 이메일 발송 등)",
-                "consumes": [
-                    "application/json"
+        "/admin/audit/export": {
+            "get": {
+                "description": "조건에 맞는 감사 로그 전체를 CSV 또는 JSON Lines로 내려받습니다. (오래된 순, 스트리밍)",
+                "produces": [
+                    "text/plain"
+                ],
+                "tags": [
+                    "Admin"
+                ],
+                "summary": "감사 로그 내보내기",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "csv | jsonl (기본 jsonl)",
+                        "name": "format",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "요청자",
+                        "name": "actor",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "동작",
+                        "name": "action",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "시작 시각 (RFC3339)",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "끝 시각 (RFC3339)",
+                        "name": "to",
+                        "in": "query"
+                    }
                 ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "file"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/model.WebResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/backup": {
+            "post": {
+                "description": "서버를 멈추지 않고 VACUUM INTO로 지금 시점의 일관된 DB 스냅샷을 백업 디렉터리에 만듭니다. 보관 개수를 넘는 오래된 백업은 지웁니다.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Reports"
+                    "Admin"
                 ],
-                "summary": "일일 리포트 생성 요청",
+                "summary": "DB 백업 만들기",
                 "responses": {
-                    "202": {
-                        "description": "요청 접수됨",
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/model.WebResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/model.BackupInfo"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
                         "schema": {
                             "$ref": "#/definitions/model.WebResponse"
                         }
@@ -79,19 +205,50 @@ const docTemplate = `{
                 }
             }
         },
-        "/todos": {
+        "/admin/backups": {
             "get": {
-                "description": "저장된 모든 할 일 목록을 반환합니다.",
-                "consumes": [
+                "description": "백업 디렉터리의 백업 파일을 최신순으로 보여줍니다. 복원은 서버를 멈추고 ` + "`" + `./main restore \u003c이름\u003e` + "`" + `으로 합니다.",
+                "produces": [
                     "application/json"
                 ],
+                "tags": [
+                    "Admin"
+                ],
+                "summary": "DB 백업 목록",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/model.WebResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/model.BackupInfo"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/calendar-tokens": {
+            "get": {
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Todos"
+                    "Calendar"
                 ],
-                "summary": "할 일 목록 조회",
+                "summary": "달력 구독 토큰 목록",
                 "responses": {
                     "200": {
                         "description": "OK",
@@ -106,7 +263,7 @@ const docTemplate = `{
                                         "data": {
                                             "type": "array",
                                             "items": {
-                                                "$ref": "#/definitions/model.Todo"
+                                                "$ref": "#/definitions/model.CalendarToken"
                                             }
                                         }
                                     }
@@ -117,7 +274,7 @@ const docTemplate = `{
                 }
             },
             "post": {
-                "description": "새로운 할 일을 목록에 추가합니다.",
+                "description": "새 비밀 토큰을 발급합니다. 구독 주소는 /calendar/{token}.ics 입니다.",
                 "consumes": [
                     "application/json"
                 ],
@@ -125,29 +282,23 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Todos"
+                    "Calendar"
                 ],
-                "summary": "할 일 추가",
+                "summary": "달력 구독 토큰 발급",
                 "parameters": [
                     {
-                        "description": "할 일 정보",
-                        "name": "todo",
+                        "description": "토큰 이름",
+                        "name": "input",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/handler.CreateTodoInput"
+                            "$ref": "#/definitions/model.CreateCalendarTokenInput"
                         }
                     }
                 ],
                 "responses": {
                     "201": {
                         "description": "Created",
-                        "schema": {
-                            "$ref": "#/definitions/model.Todo"
-                        }
-                    },
-                    "400": {
-                        "description": "Bad Request",
                         "schema": {
                             "allOf": [
                                 {
@@ -157,33 +308,33 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "type": "object"
+                                            "$ref": "#/definitions/model.CalendarToken"
                                         }
                                     }
                                 }
                             ]
                         }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/model.WebResponse"
+                        }
                     }
                 }
             }
         },
-        "/todos/{id}": {
+        "/admin/calendar-tokens/{id}": {
             "delete": {
-                "description": "특정 ID의 할 일을 영구적으로 삭제합니다.",
-                "consumes": [
-                    "application/json"
-                ],
-                "produces": [
-                    "application/json"
-                ],
+                "description": "토큰을 삭제하면 그 주소로는 더 이상 피드를 받을 수 없습니다.",
                 "tags": [
-                    "Todos"
+                    "Calendar"
                 ],
-                "summary": "할 일 삭제",
+                "summary": "달력 구독 토큰 폐기",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "삭제할 할 일 ID",
+                        "description": "토큰 ID",
                         "name": "id",
                         "in": "path",
                         "required": true
@@ -191,91 +342,3013 @@ const docTemplate = `{
                 ],
                 "responses": {
                     "200": {
-                        "description": "삭제 성공",
-                        "schema": {
-                            "$ref": "#/definitions/model.WebResponse"
-                        }
-                    },
-                    "400": {
-                        "description": "잘못된 ID 형식",
+                        "description": "OK",
                         "schema": {
                             "$ref": "#/definitions/model.WebResponse"
                         }
                     },
                     "404": {
-                        "description": "ID를 찾을 수 없음",
-                        "schema": {
-                            "$ref": "#/definitions/model.WebResponse"
-                        }
-                    },
-                    "500": {
-                        "description": "서버 내부 에러",
+                        "description": "Not Found",
                         "schema": {
                             "$ref": "#/definitions/model.WebResponse"
                         }
                     }
                 }
-            },
-            "patch": {
-                "description": "특정 ID의 할 일 완료 상태(Done)를 반전시킵니다.",
-                "consumes": [
-                    "application/json"
-                ],
+            }
+        },
+        "/admin/cluster": {
+            "get": {
+                "description": "이 서버와 peer들의 이름, 역할, 임기, 가동 시간, 마지막 heartbeat, 빌드 버전과 현재 Active(lease holder)를 보여줍니다.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Todos"
+                    "Admin"
                 ],
-                "summary": "할 일 완료 여부 토글",
-                "parameters": [
-                    {
-                        "type": "integer",
-                        "description": "수정할 할 일 ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
+                "summary": "클러스터 상태",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/model.WebResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/model.ClusterStatus"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
                     }
+                }
+            }
+        },
+        "/admin/config": {
+            "get": {
+                "description": "기본값, config.yaml, 환경 변수(TODO_*), 명령줄 플래그를 합쳐 실제로 적용된 설정을 보여줍니다. 비밀 항목(cluster.token 등)은 가려집니다.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Admin"
                 ],
+                "summary": "적용된 설정 조회",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/model.Todo"
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/model.WebResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "object",
+                                            "additionalProperties": true
+                                        }
+                                    }
+                                }
+                            ]
                         }
-                    },
-                    "400": {
-                        "description": "잘못된 ID 형식",
+                    }
+                }
+            }
+        },
+        "/admin/config/reload": {
+            "get": {
+                "description": "파일 변경 감지나 POST /admin/config/reload로 마지막에 다시 읽은 결과 (적용된 항목, 재시작이 필요한 항목, 검증 오류)",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Admin"
+                ],
+                "summary": "마지막 설정 다시 읽기 결과",
+                "responses": {
+                    "200": {
+                        "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/model.WebResponse"
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/model.WebResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/config.ReloadStatus"
+                                        }
+                                    }
+                                }
+                            ]
                         }
                     },
                     "404": {
-                        "description": "ID를 찾을 수 없음",
+                        "description": "Not Found",
                         "schema": {
                             "$ref": "#/definitions/model.WebResponse"
                         }
                     }
                 }
-            }
-        }
-    },
-    "definitions": {
-        "handler.CreateTodoInput": {
-            "type": "object",
+            },
+            "post": {
+                "description": "config.yaml을 지금 다시 읽어 검증한 뒤, 재시작 없이 바꿀 수 있는 항목(log.level, backup.interval, backup.keep, cors.*, security.*)만 한꺼번에 적용합니다. 재시작해야 적용되는 항목은 restart_required로 알려주고 실행 중인 값은 그대로 둡니다. (파일이 바뀌면 자동으로도 다시 읽음)",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Admin"
+                ],
+                "summary": "설정 다시 읽기",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/model.WebResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/config.ReloadStatus"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/model.WebResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/config.ReloadStatus"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/demote": {
+            "post": {
+                "description": "관리자 명령으로 서버를 Standby 상태로 전환합니다.",
+                "tags": [
+                    "System"
+                ],
+                "summary": "서버 스탠바이 (Active -\u003e Standby)",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.WebResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/promote": {
+            "post": {
+                "description": "관리자 명령으로 서버를 Active 상태로 전환합니다.\n복제 모드(replication.enabled)면 복제를 멈추고 Active에 남은 변경분을 최대 5초 동안 받은 뒤 승격하고, data에 복제 상태(model.ReplicationStatus)를 돌려줍니다. 다 따라잡지 못했으면 lag_seq를 확인하세요.",
+                "tags": [
+                    "System"
+                ],
+                "summary": "서버 승격 (Standby -\u003e Active)",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.WebResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/queue": {
+            "get": {
+                "description": "상태별 작업 수와 조건에 맞는 작업 목록(최신순)을 조회합니다.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Admin"
+                ],
+                "summary": "작업 큐 조회",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "queued | running | succeeded | failed",
+                        "name": "status",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "작업 종류 (예: report.daily)",
+                        "name": "type",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "개수 (기본 100, 최대 1000)",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "건너뛸 개수",
+                        "name": "offset",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/model.WebResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/model.QueueOverview"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/model.WebResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/queue/{id}": {
+            "get": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Admin"
+                ],
+                "summary": "작업 상세 조회",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "작업 ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/model.WebResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/model.Job"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/model.WebResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/queue/{id}/retry": {
+            "post": {
+                "description": "재시도 한도를 넘겨 포기한(failed) 작업을 시도 횟수 0부터 다시 실행합니다.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Admin"
+                ],
+                "summary": "실패한 작업 재시도",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "작업 ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/model.WebResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/model.Job"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/model.WebResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "실패 상태가 아님",
+                        "schema": {
+                            "$ref": "#/definitions/model.WebResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/replication": {
+            "get": {
+                "description": "이 서버(복제 모드의 Standby)가 반영한 변경 순번, Active와의 차이, 지연 시간, 마지막 에러를 보여줍니다.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Admin"
+                ],
+                "summary": "복제 상태",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/model.WebResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/model.ReplicationStatus"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "404": {
+                        "description": "복제 모드가 아님",
+                        "schema": {
+                            "$ref": "#/definitions/model.WebResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/analytics": {
+            "get": {
+                "description": "최근 weeks주 동안의 소요 시간 백분위(초), 주간 처리량, 연속 완료일, (project 지정 시) 일별 번다운을 계산합니다.\n날짜 경계는 tz 파라미터 또는 X-Timezone 헤더의 시간대(IANA 이름, 기본 UTC) 기준입니다.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Dashboard"
+                ],
+                "summary": "생산성 분석",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "시간대 (예: Asia/Seoul)",
+                        "name": "tz",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "시간대 (tz가 없을 때)",
+                        "name": "X-Timezone",
+                        "in": "header"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "분석 기간 (주, 기본 12, 최대 104)",
+                        "name": "weeks",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "번다운을 볼 프로젝트 (예: +work)",
+                        "name": "project",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/model.WebResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/model.AnalyticsResult"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "잘못된 시간대 / weeks",
+                        "schema": {
+                            "$ref": "#/definitions/model.WebResponse"
+                        }
+                    },
+                    "504": {
+                        "description": "조회 시간 초과",
+                        "schema": {
+                            "$ref": "#/definitions/model.WebResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/calendar/{file}": {
+            "get": {
+                "description": "할 일을 VTODO로 담은 iCalendar 피드입니다. 달력 앱에서 이 주소를 \"구독\"하면 됩니다.\nevents=true 를 붙이면 마감일이 있는 할 일을 VEVENT로도 넣어줍니다.",
+                "produces": [
+                    "text/plain"
+                ],
+                "tags": [
+                    "Calendar"
+                ],
+                "summary": "할 일 달력 피드 (ICS)",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "{token}.ics",
+                        "name": "file",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "마감일을 VEVENT로도 출력",
+                        "name": "events",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "file"
+                        }
+                    },
+                    "404": {
+                        "description": "토큰이 없거나 잘못됨",
+                        "schema": {
+                            "$ref": "#/definitions/model.WebResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/cluster/heartbeat": {
+            "post": {
+                "description": "peer가 자기 상태를 보내면 기록하고 이 서버의 상태를 돌려줍니다. 둘 다 Active면 임기가 작은 쪽이 Standby가 됩니다.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "System"
+                ],
+                "summary": "heartbeat 수신 (서버 간 통신용)",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "cluster.token (설정하지 않은 서버는 heartbeat를 받지 않음)",
+                        "name": "X-Cluster-Token",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "description": "보내는 서버의 상태",
+                        "name": "node",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.NodeStatus"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/model.WebResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/model.NodeStatus"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/model.WebResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "토큰이 없거나 틀림 (middleware.RequirePeerToken)",
+                        "schema": {
+                            "$ref": "#/definitions/model.WebResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/dashboard": {
+            "get": {
+                "description": "전체/완료/기한 초과 개수, 완료율, 최근 N일 생성·완료 추이, 평균 완료 소요 시간, 프로젝트/컨텍스트별 개수를 병렬로 집계합니다.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Dashboard"
+                ],
+                "summary": "대시보드 데이터 조회",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "최근 며칠 (기본 7, 최대 90)",
+                        "name": "days",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/model.WebResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/model.DashboardData"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "잘못된 days",
+                        "schema": {
+                            "$ref": "#/definitions/model.WebResponse"
+                        }
+                    },
+                    "504": {
+                        "description": "집계 시간 초과",
+                        "schema": {
+                            "$ref": "#/definitions/model.WebResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/health": {
+            "get": {
+                "description": "로드밸런서(L4/Nginx)가 서버 상태를 확인합니다. (Active/Standby)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "System"
+                ],
+                "summary": "서버 생존 및 상태 확인",
+                "responses": {
+                    "200": {
+                        "description": "Active (정상)",
+                        "schema": {
+                            "$ref": "#/definitions/model.WebResponse"
+                        }
+                    },
+                    "503": {
+                        "description": "Standby (대기중) 또는 DB 에러",
+                        "schema": {
+                            "$ref": "#/definitions/model.WebResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/health/details": {
+            "get": {
+                "description": "점검별 상태, 지연 시간, 마지막 에러를 항상 200으로 돌려줍니다. (사람/대시보드용)",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "System"
+                ],
+                "summary": "상세 상태",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/model.WebResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/model.HealthReport"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/health/live": {
+            "get": {
+                "description": "프로세스가 요청을 처리할 수 있으면 항상 200입니다. (Standby여도 200)",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "System"
+                ],
+                "summary": "Liveness 프로브",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/model.WebResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/model.LiveStatus"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/health/ready": {
+            "get": {
+                "description": "Active이고 DB, /data 디스크 여유 공간, 로그 디렉터리 쓰기가 모두 정상이면 200, 아니면 503과 실패한 점검 목록을 돌려줍니다.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "System"
+                ],
+                "summary": "Readiness 프로브",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/model.WebResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/model.HealthReport"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/model.WebResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/model.HealthReport"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/replication/changes": {
+            "get": {
+                "description": "since 이후 할 일 변경분을 변경 순번 순서대로 돌려줍니다. 새 변경이 없으면 wait만큼 기다렸다가 응답합니다. (Active만)",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "System"
+                ],
+                "summary": "변경분 스트림 (복제용, 서버 간 통신)",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "cluster.token (설정하지 않은 서버는 변경분을 내보내지 않음)",
+                        "name": "X-Cluster-Token",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "복제본이 반영한 마지막 변경 순번",
+                        "name": "since",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "최대 할 일 개수 (기본 500, 최대 5000)",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "새 변경이 없을 때 기다릴 시간 (예: 10s, 최대 1m)",
+                        "name": "wait",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/model.WebResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/model.ChangeBatch"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/model.WebResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "토큰이 없거나 틀림 (middleware.RequirePeerToken)",
+                        "schema": {
+                            "$ref": "#/definitions/model.WebResponse"
+                        }
+                    },
+                    "503": {
+                        "description": "Standby",
+                        "schema": {
+                            "$ref": "#/definitions/model.WebResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/reports": {
+            "post": {
+                "description": "리포트 생성 작업을 작업 큐에 넣고 바로 응답합니다. (처리 결과는 이메일 발송 등, 진행 상황은 GET /admin/queue)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Reports"
+                ],
+                "summary": "일일 리포트 생성 요청",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "재시도 시 중복 요청을 막기 위한 키",
+                        "name": "Idempotency-Key",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "202": {
+                        "description": "요청 접수됨",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/model.WebResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/model.Job"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/sync": {
+            "get": {
+                "description": "since(이전 응답의 token) 이후 바뀐 할 일과 삭제된 할 일을 돌려줍니다. since가 없으면 전체 목록을 돌려줍니다.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Sync"
+                ],
+                "summary": "변경분 가져오기",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "이전 응답의 token",
+                        "name": "since",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/model.WebResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/model.SyncPullResult"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "잘못된 token",
+                        "schema": {
+                            "$ref": "#/definitions/model.WebResponse"
+                        }
+                    },
+                    "410": {
+                        "description": "서버가 모르는 token (DB 복구 등) - since 없이 전체 동기화 필요",
+                        "schema": {
+                            "$ref": "#/definitions/model.WebResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "클라이언트가 쌓아둔 변경을 한 번에 적용합니다.\nbase_seq 이후 서버에서 바뀐 항목은 충돌로 보고, strategy=lww면 updated_at이 더 최근인 쪽을, report면 서버 값을 유지하고 충돌로 알려줍니다.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Sync"
+                ],
+                "summary": "오프라인 변경 올리기",
+                "parameters": [
+                    {
+                        "description": "변경 목록",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.SyncPushInput"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/model.WebResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/model.SyncPushResult"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/model.WebResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/todos": {
+            "get": {
+                "description": "저장된 할 일 목록을 반환합니다. (done / q로 거르기 가능)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Todos"
+                ],
+                "summary": "할 일 목록 조회",
+                "parameters": [
+                    {
+                        "type": "boolean",
+                        "description": "완료 여부 필터",
+                        "name": "done",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "task 검색어",
+                        "name": "q",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/model.WebResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/model.Todo"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "잘못된 필터",
+                        "schema": {
+                            "$ref": "#/definitions/model.WebResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "새로운 할 일을 목록에 추가합니다.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Todos"
+                ],
+                "summary": "할 일 추가",
+                "parameters": [
+                    {
+                        "description": "할 일 정보",
+                        "name": "todo",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.CreateTodoInput"
+                        }
+                    },
+                    {
+                        "type": "string",
+                        "description": "재시도 시 중복 생성을 막기 위한 키",
+                        "name": "Idempotency-Key",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/model.Todo"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/model.WebResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "object"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/todos/export": {
+            "get": {
+                "description": "할 일 목록을 JSON / CSV / todo.txt 형식으로 내려받습니다. (스트리밍)",
+                "produces": [
+                    "application/json",
+                    "text/plain"
+                ],
+                "tags": [
+                    "Todos"
+                ],
+                "summary": "할 일 내보내기",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "json | csv | todotxt (기본 json)",
+                        "name": "format",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "완료 여부 필터",
+                        "name": "done",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "task 검색어",
+                        "name": "q",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "file"
+                        }
+                    },
+                    "400": {
+                        "description": "잘못된 형식/필터",
+                        "schema": {
+                            "$ref": "#/definitions/model.WebResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/todos/import": {
+            "post": {
+                "description": "JSON 배열 / CSV(task, done, created_at 헤더) / todo.txt 파일을 읽어서 할 일을 한 번에 추가합니다.\n검증에 실패한 줄과 중복(기존 데이터 또는 파일 내부)은 건너뛰고 결과 리포트에 줄 번호와 함께 담깁니다.",
+                "consumes": [
+                    "application/json",
+                    "text/plain"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Todos"
+                ],
+                "summary": "할 일 가져오기",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "json | csv | todotxt (기본 json)",
+                        "name": "format",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "true면 저장하지 않고 결과만 미리보기",
+                        "name": "dry_run",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/model.WebResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/model.ImportResult"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "파일을 읽을 수 없음",
+                        "schema": {
+                            "$ref": "#/definitions/model.WebResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "서버 내부 에러",
+                        "schema": {
+                            "$ref": "#/definitions/model.WebResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/todos/{id}": {
+            "delete": {
+                "description": "특정 ID의 할 일을 영구적으로 삭제합니다.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Todos"
+                ],
+                "summary": "할 일 삭제",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "삭제할 할 일 ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "삭제 성공",
+                        "schema": {
+                            "$ref": "#/definitions/model.WebResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "잘못된 ID 형식",
+                        "schema": {
+                            "$ref": "#/definitions/model.WebResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "ID를 찾을 수 없음",
+                        "schema": {
+                            "$ref": "#/definitions/model.WebResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "서버 내부 에러",
+                        "schema": {
+                            "$ref": "#/definitions/model.WebResponse"
+                        }
+                    }
+                }
+            },
+            "patch": {
+                "description": "바디가 없으면 특정 ID의 할 일 완료 상태(Done)를 반전시킵니다.\nJSON 바디가 있으면 보낸 필드(task, done, priority, due_at, clear_due)만 바꿉니다.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Todos"
+                ],
+                "summary": "할 일 완료 여부 토글 / 수정",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "수정할 할 일 ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "바꿀 필드 (없으면 완료 여부 토글)",
+                        "name": "todo",
+                        "in": "body",
+                        "schema": {
+                            "$ref": "#/definitions/model.UpdateTodoInput"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.Todo"
+                        }
+                    },
+                    "400": {
+                        "description": "잘못된 ID 형식 / 바디",
+                        "schema": {
+                            "$ref": "#/definitions/model.WebResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "ID를 찾을 수 없음",
+                        "schema": {
+                            "$ref": "#/definitions/model.WebResponse"
+                        }
+                    },
+                    "413": {
+                        "description": "바디가 1MB를 넘음",
+                        "schema": {
+                            "$ref": "#/definitions/model.WebResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/todos/{id}/history": {
+            "get": {
+                "description": "할 일의 모든 리비전을 오래된 순으로, 직전 리비전과 달라진 필드와 함께 돌려줍니다. (삭제된 할 일 포함)",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Todos"
+                ],
+                "summary": "할 일 변경 이력",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "할 일 ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/model.WebResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/model.RevisionEntry"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "404": {
+                        "description": "이력 없음",
+                        "schema": {
+                            "$ref": "#/definitions/model.WebResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/todos/{id}/restore": {
+            "post": {
+                "description": "Soft Delete된 할 일을 다시 목록에 보이게 합니다.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Todos"
+                ],
+                "summary": "삭제된 할 일 되살리기",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "되살릴 할 일 ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/model.WebResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/model.Todo"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "404": {
+                        "description": "삭제된 할 일이 아님",
+                        "schema": {
+                            "$ref": "#/definitions/model.WebResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/todos/{id}/revert": {
+            "post": {
+                "description": "할 일 내용을 rev번 리비전 때로 되돌립니다. 되돌린 결과도 새 리비전으로 남습니다.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Todos"
+                ],
+                "summary": "리비전으로 되돌리기",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "할 일 ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "리비전 번호 (history의 rev)",
+                        "name": "rev",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/model.WebResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/model.Todo"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "잘못된 rev",
+                        "schema": {
+                            "$ref": "#/definitions/model.WebResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "할 일 또는 리비전 없음",
+                        "schema": {
+                            "$ref": "#/definitions/model.WebResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/undo": {
+            "post": {
+                "description": "요청자(X-Actor)가 undo 기간 안에 한 마지막 할 일 변경을 취소합니다. 여러 번 부르면 그 이전 변경을 차례로 취소합니다.\n그 뒤에 다른 사람이 같은 할 일을 바꿨으면 덮어쓰지 않고 409를 돌려줍니다.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Todos"
+                ],
+                "summary": "직전 변경 취소",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "요청자",
+                        "name": "X-Actor",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/model.WebResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/model.UndoResult"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "X-Actor 없음",
+                        "schema": {
+                            "$ref": "#/definitions/model.WebResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "취소할 변경 없음",
+                        "schema": {
+                            "$ref": "#/definitions/model.WebResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "이후에 다른 변경이 있음",
+                        "schema": {
+                            "$ref": "#/definitions/model.WebResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/webhooks": {
+            "get": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Webhooks"
+                ],
+                "summary": "웹훅 구독 목록",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/model.WebResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/model.Webhook"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "할 일이 바뀌면 url로 POST 합니다. 본문은 X-Signature 헤더(sha256=HMAC-SHA256(secret, body))로 서명됩니다.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Webhooks"
+                ],
+                "summary": "웹훅 구독 추가",
+                "parameters": [
+                    {
+                        "description": "구독 정보",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.CreateWebhookInput"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/model.WebResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/model.WebhookWithSecret"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/model.WebResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/webhooks/{id}": {
+            "get": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Webhooks"
+                ],
+                "summary": "웹훅 구독 조회",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "웹훅 ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/model.WebResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/model.Webhook"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/model.WebResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "구독과 전송 기록을 함께 삭제합니다.",
+                "tags": [
+                    "Webhooks"
+                ],
+                "summary": "웹훅 구독 삭제",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "웹훅 ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.WebResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/model.WebResponse"
+                        }
+                    }
+                }
+            },
+            "patch": {
+                "description": "보낸 필드만 바꿉니다. active=false면 전송을 멈추고 대기 중인 전송은 failed가 됩니다.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Webhooks"
+                ],
+                "summary": "웹훅 구독 수정",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "웹훅 ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "바꿀 값",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.UpdateWebhookInput"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/model.WebResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/model.Webhook"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/model.WebResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/model.WebResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/webhooks/{id}/deliveries": {
+            "get": {
+                "description": "구독자 하나에게 보냈거나 보낼 전송 목록을 최신순으로 돌려줍니다.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Webhooks"
+                ],
+                "summary": "웹훅 전송 기록",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "웹훅 ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "개수 (기본 50, 최대 500)",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "건너뛸 개수",
+                        "name": "offset",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/model.WebResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/model.WebhookDelivery"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/model.WebResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/webhooks/{id}/deliveries/{delivery}": {
+            "get": {
+                "description": "전송 한 건과 모든 시도 기록(응답 코드, 에러, 소요 시간)을 돌려줍니다.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Webhooks"
+                ],
+                "summary": "웹훅 전송 상세",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "웹훅 ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "전송 ID",
+                        "name": "delivery",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/model.WebResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/model.DeliveryDetail"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/model.WebResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/webhooks/{id}/deliveries/{delivery}/redeliver": {
+            "post": {
+                "description": "전송을 대기열에 다시 넣습니다. (재시도 횟수는 0부터 다시 셈)",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Webhooks"
+                ],
+                "summary": "웹훅 재전송",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "웹훅 ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "전송 ID",
+                        "name": "delivery",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "202": {
+                        "description": "Accepted",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/model.WebResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/model.WebhookDelivery"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/model.WebResponse"
+                        }
+                    }
+                }
+            }
+        }
+    },
+    "definitions": {
+        "config.Change": {
+            "type": "object",
+            "properties": {
+                "key": {
+                    "type": "string",
+                    "example": "log.level"
+                },
+                "new": {
+                    "description": "예: \"debug\""
+                },
+                "old": {
+                    "description": "예: \"info\""
+                }
+            }
+        },
+        "config.FieldError": {
+            "type": "object",
+            "properties": {
+                "field": {
+                    "description": "예: server.port",
+                    "type": "string"
+                },
+                "message": {
+                    "type": "string"
+                }
+            }
+        },
+        "config.ReloadStatus": {
+            "type": "object",
+            "properties": {
+                "applied": {
+                    "description": "바로 적용된 항목",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/config.Change"
+                    }
+                },
+                "at": {
+                    "type": "string"
+                },
+                "error": {
+                    "description": "파일을 읽지 못한 경우 등",
+                    "type": "string"
+                },
+                "errors": {
+                    "description": "검증 실패 항목 (하나라도 있으면 아무것도 적용하지 않음)",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/config.FieldError"
+                    }
+                },
+                "restart_required": {
+                    "description": "파일에는 바뀌었지만 재시작해야 적용되는 항목 (실행 중인 값은 이전 값)",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/config.Change"
+                    }
+                }
+            }
+        },
+        "model.AnalyticsResult": {
+            "type": "object",
+            "properties": {
+                "burndown": {
+                    "description": "project를 지정했을 때만",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/model.ProjectBurndown"
+                        }
+                    ]
+                },
+                "from": {
+                    "description": "분석 시작일 (월요일)",
+                    "type": "string",
+                    "example": "2024-10-14"
+                },
+                "lead_time": {
+                    "$ref": "#/definitions/model.LeadTime"
+                },
+                "streaks": {
+                    "$ref": "#/definitions/model.Streaks"
+                },
+                "throughput": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.WeekCount"
+                    }
+                },
+                "time_zone": {
+                    "type": "string",
+                    "example": "Asia/Seoul"
+                },
+                "to": {
+                    "description": "분석 마지막 날 (오늘)",
+                    "type": "string",
+                    "example": "2025-01-05"
+                }
+            }
+        },
+        "model.AuditEvent": {
+            "type": "object",
+            "properties": {
+                "action": {
+                    "type": "string",
+                    "example": "todo.delete"
+                },
+                "actor": {
+                    "description": "요청자 (X-Actor 헤더, 없으면 anonymous)",
+                    "type": "string",
+                    "example": "gyong97"
+                },
+                "after": {
+                    "description": "변경 후 스냅샷 (삭제면 null)",
+                    "type": "object"
+                },
+                "before": {
+                    "description": "변경 전 스냅샷 (생성이면 null)",
+                    "type": "object"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "entity_id": {
+                    "type": "string",
+                    "example": "3"
+                },
+                "entity_type": {
+                    "type": "string",
+                    "example": "todo"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "ip": {
+                    "description": "요청자 IP",
+                    "type": "string",
+                    "example": "172.18.0.1"
+                },
+                "method": {
+                    "type": "string",
+                    "example": "DELETE"
+                },
+                "node": {
+                    "description": "요청을 처리한 서버 hostname",
+                    "type": "string",
+                    "example": "server-1"
+                },
+                "path": {
+                    "type": "string",
+                    "example": "/todos/3"
+                },
+                "request_id": {
+                    "description": "X-Request-ID",
+                    "type": "string"
+                },
+                "status": {
+                    "type": "integer",
+                    "example": 200
+                }
+            }
+        },
+        "model.BackupInfo": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string",
+                    "example": "todos-20250102T150405.000Z.db"
+                },
+                "size_bytes": {
+                    "type": "integer",
+                    "example": 40960
+                }
+            }
+        },
+        "model.BurndownPoint": {
+            "type": "object",
+            "properties": {
+                "date": {
+                    "type": "string",
+                    "example": "2025-01-06"
+                },
+                "remaining": {
+                    "type": "integer"
+                }
+            }
+        },
+        "model.CalendarToken": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "name": {
+                    "description": "누구에게 발급했는지 (메모용)",
+                    "type": "string",
+                    "example": "gyong97 iPhone"
+                },
+                "token": {
+                    "type": "string"
+                }
+            }
+        },
+        "model.ChangeBatch": {
+            "type": "object",
+            "properties": {
+                "head": {
+                    "description": "Active의 현재 변경 순번 (To \u003c Head면 더 받을 게 있음)",
+                    "type": "integer",
+                    "example": 42
+                },
+                "revisions": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.TodoRevision"
+                    }
+                },
+                "since": {
+                    "type": "integer",
+                    "example": 40
+                },
+                "to": {
+                    "description": "이 배치를 적용하면 복제본이 도달하는 변경 순번",
+                    "type": "integer",
+                    "example": 42
+                },
+                "todos": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.ReplicatedTodo"
+                    }
+                }
+            }
+        },
+        "model.ClusterStatus": {
+            "type": "object",
+            "properties": {
+                "lease_holder": {
+                    "description": "지금 Active로 인정되는 서버 (없으면 빈 값)",
+                    "type": "string",
+                    "example": "server-1"
+                },
+                "peers": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.PeerStatus"
+                    }
+                },
+                "self": {
+                    "$ref": "#/definitions/model.NodeStatus"
+                }
+            }
+        },
+        "model.CreateCalendarTokenInput": {
+            "type": "object",
+            "required": [
+                "name"
+            ],
+            "properties": {
+                "name": {
+                    "type": "string",
+                    "example": "gyong97 iPhone"
+                }
+            }
+        },
+        "model.CreateTodoInput": {
+            "type": "object",
+            "required": [
+                "task"
+            ],
+            "properties": {
+                "due_at": {
+                    "description": "마감 시각 (선택)",
+                    "type": "string",
+                    "example": "2025-12-31T18:00:00+09:00"
+                },
+                "priority": {
+                    "description": "0: 미지정, 1(높음) ~ 9(낮음)",
+                    "type": "integer",
+                    "maximum": 9,
+                    "minimum": 0,
+                    "example": 1
+                },
+                "task": {
+                    "type": "string",
+                    "example": "Swagger 문서 수정하기"
+                }
+            }
+        },
+        "model.CreateWebhookInput": {
+            "type": "object",
+            "required": [
+                "events",
+                "url"
+            ],
+            "properties": {
+                "active": {
+                    "description": "기본 true",
+                    "type": "boolean"
+                },
+                "events": {
+                    "description": "\"*\"이면 전부",
+                    "type": "array",
+                    "minItems": 1,
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "todo.created",
+                        "todo.deleted"
+                    ]
+                },
+                "secret": {
+                    "description": "비워두면 서버가 만들어줌",
+                    "type": "string",
+                    "example": ""
+                },
+                "url": {
+                    "type": "string",
+                    "example": "https://hooks.example.com/todos"
+                }
+            }
+        },
+        "model.DailyCount": {
+            "type": "object",
+            "properties": {
+                "completed": {
+                    "type": "integer"
+                },
+                "created": {
+                    "type": "integer"
+                },
+                "date": {
+                    "type": "string",
+                    "example": "2025-01-02"
+                }
+            }
+        },
+        "model.DashboardData": {
+            "type": "object",
+            "properties": {
+                "avg_completion_seconds": {
+                    "description": "완료된 할 일의 평균 소요 시간(초), 완료된 게 없으면 0",
+                    "type": "number",
+                    "example": 86400
+                },
+                "completion_rate": {
+                    "description": "완료율(%), 할 일이 없으면 0",
+                    "type": "number",
+                    "example": 40
+                },
+                "contexts": {
+                    "description": "task 속 @컨텍스트별",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.TagCount"
+                    }
+                },
+                "daily": {
+                    "description": "최근 days일 (오래된 날부터, 빈 날은 0)",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.DailyCount"
+                    }
+                },
+                "days": {
+                    "type": "integer",
+                    "example": 7
+                },
+                "done": {
+                    "type": "integer",
+                    "example": 4
+                },
+                "overdue": {
+                    "description": "마감이 지났는데 아직 안 끝난 할 일",
+                    "type": "integer",
+                    "example": 1
+                },
+                "projects": {
+                    "description": "task 속 +프로젝트별",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.TagCount"
+                    }
+                },
+                "total": {
+                    "type": "integer",
+                    "example": 10
+                }
+            }
+        },
+        "model.DeliveryDetail": {
+            "type": "object",
+            "properties": {
+                "attempt_logs": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.WebhookAttempt"
+                    }
+                },
+                "attempts": {
+                    "type": "integer"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "delivered_at": {
+                    "type": "string"
+                },
+                "event": {
+                    "type": "string",
+                    "example": "todo.created"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "last_error": {
+                    "type": "string"
+                },
+                "last_status_code": {
+                    "type": "integer"
+                },
+                "next_attempt_at": {
+                    "type": "string"
+                },
+                "payload": {
+                    "description": "보낸(보낼) 본문 그대로 (서명 대상)",
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string",
+                    "example": "pending"
+                },
+                "updated_at": {
+                    "type": "string"
+                },
+                "webhook_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "model.FieldChange": {
+            "type": "object",
+            "properties": {
+                "field": {
+                    "type": "string",
+                    "example": "task"
+                },
+                "from": {
+                    "description": "첫 리비전이면 null"
+                },
+                "to": {}
+            }
+        },
+        "model.HealthReport": {
+            "type": "object",
+            "properties": {
+                "checked_at": {
+                    "type": "string"
+                },
+                "checks": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.HealthResult"
+                    }
+                },
+                "ready": {
+                    "type": "boolean"
+                }
+            }
+        },
+        "model.HealthResult": {
+            "type": "object",
+            "properties": {
+                "detail": {},
+                "error": {
+                    "description": "이번 점검의 에러",
+                    "type": "string"
+                },
+                "last_error": {
+                    "description": "가장 최근에 실패했을 때의 에러 (지금은 정상이어도 남음)",
+                    "type": "string"
+                },
+                "last_error_at": {
+                    "type": "string"
+                },
+                "last_success_at": {
+                    "type": "string"
+                },
+                "latency_ms": {
+                    "type": "number",
+                    "example": 0.42
+                },
+                "name": {
+                    "type": "string",
+                    "example": "database"
+                },
+                "status": {
+                    "type": "string",
+                    "example": "ok"
+                }
+            }
+        },
+        "model.ImportLineError": {
+            "type": "object",
+            "properties": {
+                "line": {
+                    "type": "integer",
+                    "example": 3
+                },
+                "message": {
+                    "type": "string",
+                    "example": "task is required"
+                }
+            }
+        },
+        "model.ImportResult": {
+            "type": "object",
+            "properties": {
+                "dry_run": {
+                    "type": "boolean"
+                },
+                "duplicates": {
+                    "description": "이미 있거나 파일 안에서 중복된 항목",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.ImportLineError"
+                    }
+                },
+                "errors": {
+                    "description": "검증 실패 항목",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.ImportLineError"
+                    }
+                },
+                "imported": {
+                    "description": "저장된(dry-run이면 저장될) 항목 수",
+                    "type": "integer"
+                },
+                "todos": {
+                    "description": "저장된 항목 (dry-run이면 저장될 항목)",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.Todo"
+                    }
+                },
+                "total": {
+                    "description": "파일에서 읽은 항목 수",
+                    "type": "integer"
+                }
+            }
+        },
+        "model.Job": {
+            "type": "object",
+            "properties": {
+                "attempts": {
+                    "type": "integer"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "finished_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "key": {
+                    "description": "같은 키의 작업은 하나만 (주기 작업 중복 방지)",
+                    "type": "string"
+                },
+                "last_error": {
+                    "type": "string"
+                },
+                "locked_by": {
+                    "description": "실행 중인 서버 (hostname:pid)",
+                    "type": "string"
+                },
+                "locked_until": {
+                    "type": "string"
+                },
+                "max_attempts": {
+                    "type": "integer"
+                },
+                "payload": {
+                    "description": "작업 입력 JSON",
+                    "type": "string"
+                },
+                "run_at": {
+                    "description": "이 시각 이후에 실행 (재시도 백오프)",
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string",
+                    "example": "queued"
+                },
+                "type": {
+                    "type": "string",
+                    "example": "report.daily"
+                },
+                "updated_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "model.LeadTime": {
+            "type": "object",
+            "properties": {
+                "count": {
+                    "description": "기간 안에 완료된 할 일 수",
+                    "type": "integer",
+                    "example": 12
+                },
+                "max": {
+                    "type": "number"
+                },
+                "p50": {
+                    "type": "number",
+                    "example": 3600
+                },
+                "p75": {
+                    "type": "number"
+                },
+                "p90": {
+                    "type": "number"
+                },
+                "p95": {
+                    "type": "number"
+                }
+            }
+        },
+        "model.LiveStatus": {
+            "type": "object",
+            "properties": {
+                "node": {
+                    "type": "string",
+                    "example": "server-2"
+                },
+                "role": {
+                    "type": "string",
+                    "example": "standby"
+                },
+                "status": {
+                    "type": "string",
+                    "example": "alive"
+                },
+                "uptime_seconds": {
+                    "type": "integer",
+                    "example": 3600
+                }
+            }
+        },
+        "model.NodeStatus": {
+            "type": "object",
+            "properties": {
+                "name": {
+                    "type": "string",
+                    "example": "server-1"
+                },
+                "role": {
+                    "type": "string",
+                    "example": "active"
+                },
+                "started_at": {
+                    "type": "string"
+                },
+                "term": {
+                    "description": "Active 임기",
+                    "type": "integer",
+                    "example": 3
+                },
+                "uptime_seconds": {
+                    "type": "integer",
+                    "example": 3600
+                },
+                "version": {
+                    "type": "string",
+                    "example": "v1.2.3"
+                }
+            }
+        },
+        "model.PeerStatus": {
+            "type": "object",
+            "properties": {
+                "last_error": {
+                    "type": "string"
+                },
+                "last_heartbeat_at": {
+                    "type": "string"
+                },
+                "node": {
+                    "$ref": "#/definitions/model.NodeStatus"
+                },
+                "reachable": {
+                    "description": "peer_timeout 안에 heartbeat를 주고받았는지",
+                    "type": "boolean"
+                },
+                "url": {
+                    "type": "string",
+                    "example": "http://app-2:8080"
+                }
+            }
+        },
+        "model.ProjectBurndown": {
+            "type": "object",
+            "properties": {
+                "points": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.BurndownPoint"
+                    }
+                },
+                "project": {
+                    "type": "string",
+                    "example": "+work"
+                }
+            }
+        },
+        "model.QueueOverview": {
+            "type": "object",
+            "properties": {
+                "counts": {
+                    "description": "상태별 작업 수 (queued, running, succeeded, failed)",
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "integer",
+                        "format": "int64"
+                    }
+                },
+                "jobs": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.Job"
+                    }
+                }
+            }
+        },
+        "model.ReplicatedTodo": {
+            "type": "object",
+            "properties": {
+                "completed_at": {
+                    "description": "완료 시각: 완료될 때 기록되고 다시 열면 지워짐 (미완료면 null)",
+                    "type": "string"
+                },
+                "created_at": {
+                    "description": "생성 시간",
+                    "type": "string"
+                },
+                "deleted_at": {
+                    "type": "string"
+                },
+                "done": {
+                    "type": "boolean"
+                },
+                "due_at": {
+                    "description": "마감 시각 (없으면 null)",
+                    "type": "string"
+                },
+                "id": {
+                    "description": "gorm.Model 대신 필요한 것만 직접 정의합니다.\n` + "`" + `gorm:\"primaryKey\"` + "`" + ` 태그로 이게 PK임을 알려줍니다.",
+                    "type": "integer"
+                },
+                "priority": {
+                    "description": "0: 미지정, 1(가장 높음) ~ 9(가장 낮음) - iCalendar PRIORITY와 같은 범위",
+                    "type": "integer"
+                },
+                "seq": {
+                    "description": "변경 순번: 생성/수정/삭제될 때마다 전체 할 일 중 가장 큰 값으로 바뀜 (오프라인 동기화용)",
+                    "type": "integer"
+                },
+                "task": {
+                    "type": "string"
+                },
+                "uid": {
+                    "description": "iCalendar UID (CalDAV 클라이언트가 만든 항목만 값이 있음, 비어 있으면 \"todo-{id}@go-todo-api\"로 취급)",
+                    "type": "string"
+                },
+                "updated_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "model.ReplicationStatus": {
+            "type": "object",
+            "properties": {
+                "applied_seq": {
+                    "description": "이 DB가 반영한 변경 순번",
+                    "type": "integer",
+                    "example": 40
+                },
+                "caught_up_at": {
+                    "description": "마지막으로 Active와 같은 순번이었던 시각",
+                    "type": "string"
+                },
+                "lag_seconds": {
+                    "description": "마지막으로 Active와 같은 순번임을 확인한 뒤 지난 시간 (변경이 없어도 long_poll만큼은 쌓임)",
+                    "type": "number",
+                    "example": 1.5
+                },
+                "lag_seq": {
+                    "description": "아직 못 받은 변경 수 (순번 차이)",
+                    "type": "integer",
+                    "example": 2
+                },
+                "last_contact_at": {
+                    "description": "마지막으로 Active와 통신한 시각",
+                    "type": "string"
+                },
+                "last_error": {
+                    "type": "string"
+                },
+                "needs_reseed": {
+                    "description": "Active인 동안 로컬 변경이 있어서 이어서 따라갈 수 없음 (새 Active의 백업으로 복원 후 재시작)",
+                    "type": "boolean"
+                },
+                "running": {
+                    "description": "따라가는 중 (Active이거나 다시 채워야 하면 false)",
+                    "type": "boolean"
+                },
+                "source": {
+                    "type": "string",
+                    "example": "http://app-1:8080"
+                },
+                "source_seq": {
+                    "description": "마지막으로 본 Active의 변경 순번",
+                    "type": "integer",
+                    "example": 42
+                }
+            }
+        },
+        "model.RevisionEntry": {
+            "type": "object",
+            "properties": {
+                "action": {
+                    "description": "create | update | delete | restore | revert",
+                    "type": "string",
+                    "example": "update"
+                },
+                "changes": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.FieldChange"
+                    }
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "done": {
+                    "type": "boolean"
+                },
+                "due_at": {
+                    "type": "string"
+                },
+                "priority": {
+                    "type": "integer"
+                },
+                "rev": {
+                    "type": "integer",
+                    "example": 2
+                },
+                "seq": {
+                    "type": "integer",
+                    "example": 42
+                },
+                "task": {
+                    "type": "string"
+                },
+                "todo_id": {
+                    "type": "integer",
+                    "example": 3
+                }
+            }
+        },
+        "model.Streaks": {
+            "type": "object",
+            "properties": {
+                "current": {
+                    "description": "오늘(또는 어제)까지 이어지는 연속 일수",
+                    "type": "integer",
+                    "example": 3
+                },
+                "last_completed": {
+                    "description": "마지막으로 완료한 날",
+                    "type": "string",
+                    "example": "2025-01-08"
+                },
+                "longest": {
+                    "type": "integer",
+                    "example": 7
+                }
+            }
+        },
+        "model.SyncChange": {
+            "type": "object",
+            "properties": {
+                "base_seq": {
+                    "description": "클라이언트가 마지막으로 본 이 항목의 seq",
+                    "type": "integer",
+                    "example": 40
+                },
+                "client_id": {
+                    "description": "클라이언트 쪽 식별자 (결과를 맞춰보는 용도)",
+                    "type": "string",
+                    "example": "local-7"
+                },
+                "deleted": {
+                    "type": "boolean"
+                },
+                "done": {
+                    "type": "boolean"
+                },
+                "due_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "description": "0이면 새로 만들기",
+                    "type": "integer",
+                    "example": 0
+                },
+                "priority": {
+                    "type": "integer",
+                    "maximum": 9,
+                    "minimum": 0
+                },
+                "task": {
+                    "type": "string",
+                    "example": "오프라인에서 추가한 일"
+                },
+                "updated_at": {
+                    "description": "클라이언트에서 수정한 시각 (lww 비교용)",
+                    "type": "string"
+                }
+            }
+        },
+        "model.SyncChangeResult": {
+            "type": "object",
+            "properties": {
+                "client_id": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "message": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string",
+                    "example": "updated"
+                },
+                "todo": {
+                    "description": "적용된 결과 (충돌이면 서버 쪽 현재 값)",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/model.Todo"
+                        }
+                    ]
+                }
+            }
+        },
+        "model.SyncPullResult": {
+            "type": "object",
+            "properties": {
+                "changed": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.Todo"
+                    }
+                },
+                "deleted": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.SyncTombstone"
+                    }
+                },
+                "full": {
+                    "description": "true면 전체 목록 (클라이언트는 로컬 데이터를 통째로 교체)",
+                    "type": "boolean"
+                },
+                "token": {
+                    "description": "다음 요청의 since 값",
+                    "type": "string",
+                    "example": "42"
+                }
+            }
+        },
+        "model.SyncPushInput": {
+            "type": "object",
             "required": [
-                "task"
+                "changes"
             ],
             "properties": {
-                "task": {
+                "changes": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.SyncChange"
+                    }
+                },
+                "strategy": {
+                    "description": "기본값 lww",
                     "type": "string",
-                    "example": "Swagger 문서 수정하기"
+                    "enum": [
+                        "lww",
+                        "report"
+                    ],
+                    "example": "lww"
+                }
+            }
+        },
+        "model.SyncPushResult": {
+            "type": "object",
+            "properties": {
+                "results": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.SyncChangeResult"
+                    }
+                },
+                "token": {
+                    "type": "string"
+                }
+            }
+        },
+        "model.SyncTombstone": {
+            "type": "object",
+            "properties": {
+                "deleted_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer",
+                    "example": 3
+                },
+                "seq": {
+                    "type": "integer",
+                    "example": 42
+                },
+                "uid": {
+                    "type": "string"
+                }
+            }
+        },
+        "model.TagCount": {
+            "type": "object",
+            "properties": {
+                "done": {
+                    "type": "integer"
+                },
+                "name": {
+                    "type": "string",
+                    "example": "+work"
+                },
+                "total": {
+                    "type": "integer"
                 }
             }
         },
         "model.Todo": {
             "type": "object",
             "properties": {
+                "completed_at": {
+                    "description": "완료 시각: 완료될 때 기록되고 다시 열면 지워짐 (미완료면 null)",
+                    "type": "string"
+                },
                 "created_at": {
                     "description": "생성 시간",
                     "type": "string"
@@ -283,11 +3356,135 @@ const docTemplate = `{
                 "done": {
                     "type": "boolean"
                 },
+                "due_at": {
+                    "description": "마감 시각 (없으면 null)",
+                    "type": "string"
+                },
                 "id": {
                     "description": "gorm.Model 대신 필요한 것만 직접 정의합니다.\n` + "`" + `gorm:\"primaryKey\"` + "`" + ` 태그로 이게 PK임을 알려줍니다.",
                     "type": "integer"
                 },
+                "priority": {
+                    "description": "0: 미지정, 1(가장 높음) ~ 9(가장 낮음) - iCalendar PRIORITY와 같은 범위",
+                    "type": "integer"
+                },
+                "seq": {
+                    "description": "변경 순번: 생성/수정/삭제될 때마다 전체 할 일 중 가장 큰 값으로 바뀜 (오프라인 동기화용)",
+                    "type": "integer"
+                },
+                "task": {
+                    "type": "string"
+                },
+                "uid": {
+                    "description": "iCalendar UID (CalDAV 클라이언트가 만든 항목만 값이 있음, 비어 있으면 \"todo-{id}@go-todo-api\"로 취급)",
+                    "type": "string"
+                }
+            }
+        },
+        "model.TodoRevision": {
+            "type": "object",
+            "properties": {
+                "action": {
+                    "description": "create | update | delete | restore | revert",
+                    "type": "string",
+                    "example": "update"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "done": {
+                    "type": "boolean"
+                },
+                "due_at": {
+                    "type": "string"
+                },
+                "priority": {
+                    "type": "integer"
+                },
+                "rev": {
+                    "type": "integer",
+                    "example": 2
+                },
+                "seq": {
+                    "type": "integer",
+                    "example": 42
+                },
+                "task": {
+                    "type": "string"
+                },
+                "todo_id": {
+                    "type": "integer",
+                    "example": 3
+                }
+            }
+        },
+        "model.UndoResult": {
+            "type": "object",
+            "properties": {
+                "todo": {
+                    "description": "취소 후 상태 (생성을 취소했으면 null)",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/model.Todo"
+                        }
+                    ]
+                },
+                "todo_id": {
+                    "type": "integer",
+                    "example": 3
+                },
+                "undone": {
+                    "description": "취소한 변경의 감사 로그 동작",
+                    "type": "string",
+                    "example": "todo.update"
+                }
+            }
+        },
+        "model.UpdateTodoInput": {
+            "type": "object",
+            "properties": {
+                "clear_due": {
+                    "description": "true면 마감 시각 지우기",
+                    "type": "boolean",
+                    "example": false
+                },
+                "done": {
+                    "type": "boolean",
+                    "example": true
+                },
+                "due_at": {
+                    "type": "string",
+                    "example": "2025-12-31T18:00:00+09:00"
+                },
+                "priority": {
+                    "type": "integer",
+                    "maximum": 9,
+                    "minimum": 0,
+                    "example": 2
+                },
                 "task": {
+                    "type": "string",
+                    "minLength": 1,
+                    "example": "Swagger 문서 수정하기"
+                }
+            }
+        },
+        "model.UpdateWebhookInput": {
+            "type": "object",
+            "properties": {
+                "active": {
+                    "type": "boolean"
+                },
+                "events": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "secret": {
+                    "type": "string"
+                },
+                "url": {
                     "type": "string"
                 }
             }
@@ -309,6 +3506,153 @@ const docTemplate = `{
                     "example": "Success"
                 }
             }
+        },
+        "model.Webhook": {
+            "type": "object",
+            "properties": {
+                "active": {
+                    "type": "boolean"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "events": {
+                    "description": "\"*\"이면 전부",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "todo.created",
+                        "todo.deleted"
+                    ]
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "updated_at": {
+                    "type": "string"
+                },
+                "url": {
+                    "type": "string",
+                    "example": "https://hooks.example.com/todos"
+                }
+            }
+        },
+        "model.WebhookAttempt": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "delivery_id": {
+                    "type": "integer"
+                },
+                "duration_ms": {
+                    "type": "integer"
+                },
+                "error": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "status_code": {
+                    "description": "응답을 못 받았으면 0",
+                    "type": "integer"
+                }
+            }
+        },
+        "model.WebhookDelivery": {
+            "type": "object",
+            "properties": {
+                "attempts": {
+                    "type": "integer"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "delivered_at": {
+                    "type": "string"
+                },
+                "event": {
+                    "type": "string",
+                    "example": "todo.created"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "last_error": {
+                    "type": "string"
+                },
+                "last_status_code": {
+                    "type": "integer"
+                },
+                "next_attempt_at": {
+                    "type": "string"
+                },
+                "payload": {
+                    "description": "보낸(보낼) 본문 그대로 (서명 대상)",
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string",
+                    "example": "pending"
+                },
+                "updated_at": {
+                    "type": "string"
+                },
+                "webhook_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "model.WebhookWithSecret": {
+            "type": "object",
+            "properties": {
+                "active": {
+                    "type": "boolean"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "events": {
+                    "description": "\"*\"이면 전부",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "todo.created",
+                        "todo.deleted"
+                    ]
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "secret": {
+                    "type": "string"
+                },
+                "updated_at": {
+                    "type": "string"
+                },
+                "url": {
+                    "type": "string",
+                    "example": "https://hooks.example.com/todos"
+                }
+            }
+        },
+        "model.WeekCount": {
+            "type": "object",
+            "properties": {
+                "completed": {
+                    "type": "integer"
+                },
+                "week_start": {
+                    "type": "string",
+                    "example": "2025-01-06"
+                }
+            }
         }
     }
 }`
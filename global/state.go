@@ -12,9 +12,48 @@ const (
 // 안전한 동시성 제어를 위해 atomic을 사용합니다.
 var serverMode int32 = Standby
 
-// SetActive: 서버를 Active 상태로 변경
+// Active 임기(term): Active가 될 때마다 지금까지 본 가장 큰 값 + 1
+// 두 서버가 동시에 Active라고 주장하면 임기가 더 큰(더 나중에 승격된) 쪽이 이깁니다.
+var term int64
+
+// 임기가 바뀌면 부르는 함수 (재시작해도 임기가 0으로 돌아가지 않도록 DB에 저장)
+var termHook atomic.Pointer[func(int64)]
+
+// OnTermChange: 임기가 커질 때마다 fn(새 임기) 호출
+func OnTermChange(fn func(int64)) {
+	termHook.Store(&fn)
+}
+
+func termChanged(t int64) {
+	if fn := termHook.Load(); fn != nil {
+		(*fn)(t)
+	}
+}
+
+// SetActive: 서버를 Active 상태로 변경 (이미 Active면 임기 유지)
 func SetActive() {
-	atomic.StoreInt32(&serverMode, Active)
+	if atomic.SwapInt32(&serverMode, Active) != Active {
+		termChanged(atomic.AddInt64(&term, 1))
+	}
+}
+
+// Term: 현재 임기 (Standby면 마지막으로 본 임기)
+func Term() int64 {
+	return atomic.LoadInt64(&term)
+}
+
+// ObserveTerm: 다른 서버의 임기를 봄 (다음 승격 때 그보다 큰 임기를 받도록)
+func ObserveTerm(t int64) {
+	for {
+		cur := atomic.LoadInt64(&term)
+		if t <= cur {
+			return
+		}
+		if atomic.CompareAndSwapInt64(&term, cur, t) {
+			termChanged(t)
+			return
+		}
+	}
 }
 
 // SetStandby: 서버를 Standby 상태로 변경
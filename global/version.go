@@ -0,0 +1,4 @@
+package global
+
+// Version: 빌드 버전 (go build -ldflags "-X go_study/global.Version=v1.2.3")
+var Version = "dev"
@@ -0,0 +1,113 @@
+package handler
+
+import (
+	"context"
+	"go_study/analytics"
+	"go_study/model"
+	"go_study/repository"
+	"go_study/utils"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// 시간대를 알려주는 헤더 (tz 쿼리 파라미터가 없을 때 사용)
+const TimeZoneHeader = "X-Timezone"
+
+// AnalyticsHandler: 완료 시각 기반 생산성 분석
+type AnalyticsHandler struct {
+	repo repository.TodoRepository
+}
+
+// 생성자
+func NewAnalyticsHandler(r repository.TodoRepository) *AnalyticsHandler {
+	return &AnalyticsHandler{repo: r}
+}
+
+// GetAnalytics godoc
+// @Summary      생산성 분석
+// @Description  최근 weeks주 동안의 소요 시간 백분위(초), 주간 처리량, 연속 완료일, (project 지정 시) 일별 번다운을 계산합니다.
+// @Description  날짜 경계는 tz 파라미터 또는 X-Timezone 헤더의 시간대(IANA 이름, 기본 UTC) 기준입니다.
+// @Tags         Dashboard
+// @Produce      json
+// @Param        tz           query   string  false  "시간대 (예: Asia/Seoul)"
+// @Param        X-Timezone   header  string  false  "시간대 (tz가 없을 때)"
+// @Param        weeks        query   int     false  "분석 기간 (주, 기본 12, 최대 104)"
+// @Param        project      query   string  false  "번다운을 볼 프로젝트 (예: +work)"
+// @Success      200  {object}  model.WebResponse{data=model.AnalyticsResult}
+// @Failure      400  {object}  model.WebResponse  "잘못된 시간대 / weeks"
+// @Failure      504  {object}  model.WebResponse  "조회 시간 초과"
+// @Router       /analytics [get]
+func (h *AnalyticsHandler) GetAnalytics(c *gin.Context) {
+	tz := c.Query("tz")
+	if tz == "" {
+		tz = c.GetHeader(TimeZoneHeader)
+	}
+	if tz == "" {
+		tz = "UTC"
+	}
+	loc, err := time.LoadLocation(tz)
+	if err != nil {
+		utils.SendError(c, http.StatusBadRequest, "unknown time zone: "+tz)
+		return
+	}
+	weeks, err := strconv.Atoi(c.DefaultQuery("weeks", "12"))
+	if err != nil || weeks < 1 || weeks > 104 {
+		utils.SendError(c, http.StatusBadRequest, "weeks must be between 1 and 104")
+		return
+	}
+
+	now := time.Now().In(loc)
+	today := analytics.StartOfDay(now, loc)
+	from := analytics.StartOfWeek(now, loc).AddDate(0, 0, -7*(weeks-1))
+
+	ctx, cancel := context.WithTimeout(c.Request.Context(), dashboardTimeout)
+	defer cancel()
+	todos, err := h.repo.Timings(ctx, from)
+	if err != nil {
+		if ctx.Err() == context.DeadlineExceeded {
+			utils.SendError(c, http.StatusGatewayTimeout, "Analytics query timed out")
+			return
+		}
+		utils.SendError(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	result := model.AnalyticsResult{
+		TimeZone:   loc.String(),
+		From:       from.Format(time.DateOnly),
+		To:         today.Format(time.DateOnly),
+		LeadTime:   analytics.LeadTimes(todos, from, now),
+		Throughput: analytics.WeeklyThroughput(todos, from, weeks),
+		Streaks:    analytics.CompletionStreaks(todos, today),
+	}
+	if project := c.Query("project"); project != "" {
+		if !strings.HasPrefix(project, "+") {
+			project = "+" + project
+		}
+		days := int(today.Sub(from).Hours()/24+0.5) + 1
+		result.Burndown = &model.ProjectBurndown{
+			Project: project,
+			Points:  analytics.Burndown(inProject(todos, project), from, days),
+		}
+	}
+	utils.SendSuccess(c, result)
+}
+
+// task에 +프로젝트 태그가 있는 할 일만
+func inProject(todos []model.Todo, project string) []model.Todo {
+	var matched []model.Todo
+	for _, t := range todos {
+		projects, _ := model.TagsOf(t.Task)
+		for _, p := range projects {
+			if p == project {
+				matched = append(matched, t)
+				break
+			}
+		}
+	}
+	return matched
+}
@@ -0,0 +1,112 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"go_study/model"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func newAnalyticsRouter(repo *MockTodoRepository) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	r.GET("/analytics", NewAnalyticsHandler(repo).GetAnalytics)
+	return r
+}
+
+func getAnalytics(r *gin.Engine, url string, header http.Header) (*httptest.ResponseRecorder, model.AnalyticsResult) {
+	req, _ := http.NewRequest("GET", url, nil)
+	for k, v := range header {
+		req.Header[k] = v
+	}
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+	var data model.AnalyticsResult
+	json.Unmarshal(w.Body.Bytes(), &model.WebResponse{Data: &data})
+	return w, data
+}
+
+func TestGetAnalytics_UsesTimeZone(t *testing.T) {
+	seoul, _ := time.LoadLocation("Asia/Seoul")
+	now := time.Now().In(seoul)
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, seoul)
+	yesterdayNoon := today.Add(-12 * time.Hour)
+	completed := func(d time.Time) *time.Time { return &d }
+
+	mockRepo := new(MockTodoRepository)
+	// 조회 시작은 서울 기준 월요일 0시
+	mockRepo.On("Timings", mock.Anything, mock.MatchedBy(func(from time.Time) bool {
+		return from.Location().String() == "Asia/Seoul" && from.Weekday() == time.Monday && from.Hour() == 0
+	})).Return([]model.Todo{
+		{ID: 1, Task: "보고서 +work", CreatedAt: yesterdayNoon.Add(-time.Hour), Done: true, CompletedAt: completed(yesterdayNoon)},
+		{ID: 2, Task: "발표 +work", CreatedAt: yesterdayNoon.Add(-2 * time.Hour), Done: true, CompletedAt: completed(today)},
+		{ID: 3, Task: "청소 +home", CreatedAt: yesterdayNoon},
+	}, nil)
+	r := newAnalyticsRouter(mockRepo)
+
+	// 1. tz 파라미터: 날짜 경계와 응답 모양
+	w, data := getAnalytics(r, "/analytics?tz=Asia/Seoul&weeks=2&project=work", nil)
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "Asia/Seoul", data.TimeZone)
+	assert.Equal(t, today.Format(time.DateOnly), data.To)
+	assert.Equal(t, 2, data.LeadTime.Count)
+	assert.Equal(t, 3600.0, data.LeadTime.P50)
+	assert.Equal(t, (14 * time.Hour).Seconds(), data.LeadTime.Max)
+	assert.Len(t, data.Throughput, 2)
+	assert.Equal(t, data.From, data.Throughput[0].WeekStart)
+	assert.Equal(t, 2, data.Streaks.Current)
+	assert.Equal(t, today.Format(time.DateOnly), data.Streaks.LastCompleted)
+
+	// project는 + 없이 보내도 되고, 오늘까지 하루씩 (+work 두 개 모두 완료)
+	if assert.NotNil(t, data.Burndown) {
+		assert.Equal(t, "+work", data.Burndown.Project)
+		last := data.Burndown.Points[len(data.Burndown.Points)-1]
+		assert.Equal(t, today.Format(time.DateOnly), last.Date)
+		assert.Equal(t, 0, last.Remaining)
+	}
+
+	// 2. tz가 없으면 X-Timezone 헤더, project가 없으면 번다운 생략
+	w, data = getAnalytics(r, "/analytics", http.Header{"X-Timezone": {"Asia/Seoul"}})
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "Asia/Seoul", data.TimeZone)
+	assert.Len(t, data.Throughput, 12)
+	assert.Nil(t, data.Burndown)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestGetAnalytics_DefaultsToUTC(t *testing.T) {
+	mockRepo := new(MockTodoRepository)
+	mockRepo.On("Timings", mock.Anything, mock.Anything).Return([]model.Todo{}, nil)
+	r := newAnalyticsRouter(mockRepo)
+
+	w, data := getAnalytics(r, "/analytics", nil)
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "UTC", data.TimeZone)
+	assert.Equal(t, 0, data.LeadTime.Count)
+	assert.Equal(t, 0, data.Streaks.Current)
+}
+
+func TestGetAnalytics_BadRequest(t *testing.T) {
+	mockRepo := new(MockTodoRepository)
+	r := newAnalyticsRouter(mockRepo)
+
+	for _, url := range []string{
+		"/analytics?tz=Mars/Olympus",
+		"/analytics?weeks=0",
+		"/analytics?weeks=105",
+		"/analytics?weeks=abc",
+	} {
+		w, _ := getAnalytics(r, url, nil)
+		assert.Equal(t, http.StatusBadRequest, w.Code, url)
+	}
+	w, _ := getAnalytics(r, "/analytics", http.Header{"X-Timezone": {"Not/AZone"}})
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	mockRepo.AssertNotCalled(t, "Timings", mock.Anything, mock.Anything)
+}
@@ -0,0 +1,174 @@
+package handler
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"go_study/model"
+	"go_study/repository"
+	"go_study/utils"
+	"log"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// 감사 로그 조회 한 페이지 최대 개수
+const maxAuditPageSize = 1000
+
+// recordTodoAudit: 할 일 변경 감사 로그 저장
+// 감사 로그 저장 실패로 이미 끝난 변경을 되돌릴 수는 없으므로, 실패는 로그로만 남깁니다.
+func recordTodoAudit(store repository.AuditRepository, c *gin.Context, action string, id uint, before, after *model.Todo) {
+	ev := utils.NewAuditEvent(c, action, "todo", strconv.FormatUint(uint64(id), 10))
+	ev.Before, ev.After = snapshot(before), snapshot(after)
+	if err := store.Append(ev); err != nil {
+		log.Printf("❌ 감사 로그 저장 실패 (%s %d): %v\n", action, id, err)
+	}
+}
+
+// nil(포인터 포함)이면 null, 아니면 JSON
+func snapshot(v interface{}) json.RawMessage {
+	if v == nil {
+		return nil
+	}
+	if t, ok := v.(*model.Todo); ok && t == nil {
+		return nil
+	}
+	b, err := json.Marshal(v)
+	if err != nil {
+		return nil
+	}
+	return b
+}
+
+// AuditHandler: 감사 로그 조회 핸들러
+type AuditHandler struct {
+	store repository.AuditRepository
+}
+
+// 생성자
+func NewAuditHandler(store repository.AuditRepository) *AuditHandler {
+	return &AuditHandler{store: store}
+}
+
+// 쿼리 파라미터를 조회 조건으로 변환 (from/to는 RFC3339)
+func parseAuditFilter(c *gin.Context) (repository.AuditFilter, error) {
+	filter := repository.AuditFilter{
+		Actor:     c.Query("actor"),
+		Action:    c.Query("action"),
+		EntityID:  c.Query("entity_id"),
+		RequestID: c.Query("request_id"),
+	}
+	for name, dst := range map[string]**time.Time{"from": &filter.From, "to": &filter.To} {
+		if v := c.Query(name); v != "" {
+			t, err := time.Parse(time.RFC3339, v)
+			if err != nil {
+				return filter, fmt.Errorf("%s must be RFC3339 (e.g. 2025-01-02T15:04:05+09:00)", name)
+			}
+			*dst = &t
+		}
+	}
+	return filter, nil
+}
+
+// ListEvents godoc
+// @Summary      감사 로그 조회
+// @Description  할 일 변경과 관리자 API 호출 기록을 최신순으로 조회합니다.
+// @Tags         Admin
+// @Produce      json
+// @Param        actor       query  string  false  "요청자"
+// @Param        action      query  string  false  "todo.create | todo.update | todo.delete | todo.restore | admin.request"
+// @Param        entity_id   query  string  false  "대상 ID"
+// @Param        request_id  query  string  false  "요청 ID"
+// @Param        from        query  string  false  "시작 시각 (RFC3339, 포함)"
+// @Param        to          query  string  false  "끝 시각 (RFC3339, 미포함)"
+// @Param        limit       query  int     false  "개수 (기본 100, 최대 1000)"
+// @Param        offset      query  int     false  "건너뛸 개수"
+// @Success      200  {object}  model.WebResponse{data=[]model.AuditEvent}
+// @Failure      400  {object}  model.WebResponse
+// @Router       /admin/audit [get]
+func (h *AuditHandler) ListEvents(c *gin.Context) {
+	filter, err := parseAuditFilter(c)
+	if err != nil {
+		utils.SendError(c, http.StatusBadRequest, err.Error())
+		return
+	}
+	limit, errLimit := strconv.Atoi(c.DefaultQuery("limit", "100"))
+	offset, errOffset := strconv.Atoi(c.DefaultQuery("offset", "0"))
+	if err := errors.Join(errLimit, errOffset); err != nil || limit <= 0 || offset < 0 {
+		utils.SendError(c, http.StatusBadRequest, "limit and offset must be positive numbers")
+		return
+	}
+	limit = min(limit, maxAuditPageSize)
+
+	events, err := h.store.Find(filter, limit, offset)
+	if err != nil {
+		utils.SendError(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+	utils.SendSuccess(c, events)
+}
+
+// ExportEvents godoc
+// @Summary      감사 로그 내보내기
+// @Description  조건에 맞는 감사 로그 전체를 CSV 또는 JSON Lines로 내려받습니다. (오래된 순, 스트리밍)
+// @Tags         Admin
+// @Produce      plain
+// @Param        format  query  string  false  "csv | jsonl (기본 jsonl)"
+// @Param        actor   query  string  false  "요청자"
+// @Param        action  query  string  false  "동작"
+// @Param        from    query  string  false  "시작 시각 (RFC3339)"
+// @Param        to      query  string  false  "끝 시각 (RFC3339)"
+// @Success      200  {file}    file
+// @Failure      400  {object}  model.WebResponse
+// @Router       /admin/audit/export [get]
+func (h *AuditHandler) ExportEvents(c *gin.Context) {
+	filter, err := parseAuditFilter(c)
+	if err != nil {
+		utils.SendError(c, http.StatusBadRequest, err.Error())
+		return
+	}
+	format := c.DefaultQuery("format", "jsonl")
+	if format != "csv" && format != "jsonl" {
+		utils.SendError(c, http.StatusBadRequest, "format must be csv or jsonl")
+		return
+	}
+
+	if format == "csv" {
+		c.Header("Content-Type", "text/csv; charset=utf-8")
+	} else {
+		c.Header("Content-Type", "application/x-ndjson")
+	}
+	c.Header("Content-Disposition", `attachment; filename="audit.`+format+`"`)
+	c.Status(http.StatusOK)
+
+	var write func(model.AuditEvent) error
+	var flush func() error
+	if format == "csv" {
+		w := csv.NewWriter(c.Writer)
+		w.Write([]string{"id", "created_at", "actor", "ip", "request_id", "node", "action", "entity_type", "entity_id", "method", "path", "status", "before", "after"})
+		write = func(e model.AuditEvent) error {
+			return w.Write([]string{
+				strconv.FormatUint(uint64(e.ID), 10), e.CreatedAt.UTC().Format(time.RFC3339), e.Actor, e.IP, e.RequestID, e.Node,
+				e.Action, e.EntityType, e.EntityID, e.Method, e.Path, strconv.Itoa(e.Status), string(e.Before), string(e.After),
+			})
+		}
+		flush = func() error { w.Flush(); return w.Error() }
+	} else {
+		enc := json.NewEncoder(c.Writer)
+		write = func(e model.AuditEvent) error { return enc.Encode(e) }
+		flush = func() error { return nil }
+	}
+
+	// 헤더가 이미 나갔으므로 중간 에러는 로그로만 남김
+	if err := h.store.Stream(filter, write); err != nil {
+		log.Printf("❌ 감사 로그 내보내기 실패: %v\n", err)
+		return
+	}
+	if err := flush(); err != nil {
+		log.Printf("❌ 감사 로그 내보내기 마무리 실패: %v\n", err)
+	}
+}
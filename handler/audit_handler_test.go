@@ -0,0 +1,120 @@
+package handler
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"go_study/model"
+	"go_study/repository"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func newAuditRouter(store *MockAuditRepository) *gin.Engine {
+	h := NewAuditHandler(store)
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	r.GET("/admin/audit", h.ListEvents)
+	r.GET("/admin/audit/export", h.ExportEvents)
+	return r
+}
+
+func getAudit(r *gin.Engine, url string) *httptest.ResponseRecorder {
+	req, _ := http.NewRequest("GET", url, nil)
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+	return w
+}
+
+var testAuditEvents = []model.AuditEvent{
+	{ID: 1, CreatedAt: time.Date(2025, 3, 1, 9, 0, 0, 0, time.UTC), Actor: "alice", Action: model.AuditTodoCreate, EntityType: "todo", EntityID: "3", RequestID: "req-1", Status: 201, After: json.RawMessage(`{"id":3}`)},
+	{ID: 2, CreatedAt: time.Date(2025, 3, 1, 10, 0, 0, 0, time.UTC), Actor: "bob", Action: model.AuditTodoDelete, EntityType: "todo", EntityID: "3", RequestID: "req-2", Status: 200, Before: json.RawMessage(`{"id":3}`)},
+}
+
+func TestAuditHandler_ListEventsFilters(t *testing.T) {
+	store := new(MockAuditRepository)
+	from := time.Date(2025, 3, 1, 0, 0, 0, 0, time.FixedZone("KST", 9*3600))
+	to := from.Add(24 * time.Hour)
+	store.On("Find", mock.MatchedBy(func(f repository.AuditFilter) bool {
+		return f.Actor == "alice" && f.Action == model.AuditTodoCreate && f.EntityID == "3" && f.RequestID == "req-1" &&
+			f.From != nil && f.From.Equal(from) && f.To != nil && f.To.Equal(to)
+	}), 100, 0).Return(testAuditEvents[:1], nil)
+	r := newAuditRouter(store)
+
+	w := getAudit(r, "/admin/audit?actor=alice&action=todo.create&entity_id=3&request_id=req-1&from=2025-03-01T00:00:00%2B09:00&to=2025-03-02T00:00:00%2B09:00")
+	require.Equal(t, http.StatusOK, w.Code)
+	var events []model.AuditEvent
+	json.Unmarshal(w.Body.Bytes(), &model.WebResponse{Data: &events})
+	require.Len(t, events, 1)
+	assert.Equal(t, "alice", events[0].Actor)
+	store.AssertExpectations(t)
+}
+
+func TestAuditHandler_ListEventsPaging(t *testing.T) {
+	store := new(MockAuditRepository)
+	// 한 페이지는 최대 maxAuditPageSize개
+	store.On("Find", repository.AuditFilter{}, maxAuditPageSize, 20).Return([]model.AuditEvent{}, nil)
+	r := newAuditRouter(store)
+
+	assert.Equal(t, http.StatusOK, getAudit(r, "/admin/audit?limit=5000&offset=20").Code)
+	store.AssertExpectations(t)
+}
+
+func TestAuditHandler_BadRequest(t *testing.T) {
+	store := new(MockAuditRepository)
+	r := newAuditRouter(store)
+
+	for _, url := range []string{
+		"/admin/audit?from=2025-03-01",
+		"/admin/audit?to=yesterday",
+		"/admin/audit?limit=0",
+		"/admin/audit?offset=-1",
+		"/admin/audit/export?from=2025-03-01",
+		"/admin/audit/export?format=xml",
+	} {
+		w := getAudit(r, url)
+		assert.Equal(t, http.StatusBadRequest, w.Code, url)
+	}
+	w := getAudit(r, "/admin/audit?from=2025-03-01")
+	assert.Contains(t, w.Body.String(), "from must be RFC3339")
+	store.AssertNotCalled(t, "Find", mock.Anything, mock.Anything, mock.Anything)
+	store.AssertNotCalled(t, "Stream", mock.Anything, mock.Anything)
+}
+
+func TestAuditHandler_ExportEvents(t *testing.T) {
+	store := new(MockAuditRepository)
+	store.On("Stream", mock.MatchedBy(func(f repository.AuditFilter) bool { return f.Actor == "" }), mock.Anything).Return(testAuditEvents, nil)
+	store.On("Stream", mock.MatchedBy(func(f repository.AuditFilter) bool { return f.Actor == "bob" }), mock.Anything).Return(testAuditEvents[1:], nil)
+	r := newAuditRouter(store)
+
+	// 1. CSV: 헤더 한 줄 + 이벤트마다 한 줄, 스냅샷은 JSON 문자열 그대로
+	w := getAudit(r, "/admin/audit/export?format=csv")
+	require.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "text/csv; charset=utf-8", w.Header().Get("Content-Type"))
+	assert.Equal(t, `attachment; filename="audit.csv"`, w.Header().Get("Content-Disposition"))
+	rows, err := csv.NewReader(w.Body).ReadAll()
+	require.NoError(t, err)
+	require.Len(t, rows, 3)
+	assert.Equal(t, []string{"id", "created_at", "actor", "ip", "request_id", "node", "action", "entity_type", "entity_id", "method", "path", "status", "before", "after"}, rows[0])
+	assert.Equal(t, []string{"1", "2025-03-01T09:00:00Z", "alice", "", "req-1", "", "todo.create", "todo", "3", "", "", "201", "", `{"id":3}`}, rows[1])
+	assert.Equal(t, `{"id":3}`, rows[2][12])
+
+	// 2. JSON Lines (기본): 한 줄에 이벤트 하나, 필터 적용
+	w = getAudit(r, "/admin/audit/export?actor=bob")
+	require.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "application/x-ndjson", w.Header().Get("Content-Type"))
+	lines := strings.Split(strings.TrimSpace(w.Body.String()), "\n")
+	require.Len(t, lines, 1)
+	var ev model.AuditEvent
+	require.NoError(t, json.Unmarshal([]byte(lines[0]), &ev))
+	assert.Equal(t, uint(2), ev.ID)
+	assert.Equal(t, model.AuditTodoDelete, ev.Action)
+	store.AssertExpectations(t)
+}
@@ -0,0 +1,58 @@
+package handler
+
+import (
+	"go_study/backup"
+	"go_study/utils"
+	"log"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// BackupHandler: 온라인 DB 백업 (관리자용)
+type BackupHandler struct {
+	mgr  *backup.Manager
+	keep func() int
+}
+
+// 생성자 (keep: 남길 백업 개수, 0이면 지우지 않음 - 설정을 다시 읽으면 바뀌므로 함수로)
+func NewBackupHandler(mgr *backup.Manager, keep func() int) *BackupHandler {
+	return &BackupHandler{mgr: mgr, keep: keep}
+}
+
+// CreateBackup godoc
+// @Summary      DB 백업 만들기
+// @Description  서버를 멈추지 않고 VACUUM INTO로 지금 시점의 일관된 DB 스냅샷을 백업 디렉터리에 만듭니다. 보관 개수를 넘는 오래된 백업은 지웁니다.
+// @Tags         Admin
+// @Produce      json
+// @Success      201  {object}  model.WebResponse{data=model.BackupInfo}
+// @Failure      500  {object}  model.WebResponse
+// @Router       /admin/backup [post]
+func (h *BackupHandler) CreateBackup(c *gin.Context) {
+	info, err := h.mgr.Create(c.Request.Context())
+	if err != nil {
+		utils.SendError(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+	// 백업은 이미 만들어졌으므로 정리 실패는 로그로만
+	if _, err := h.mgr.Prune(h.keep()); err != nil {
+		log.Printf("❌ 오래된 백업 정리 실패: %v\n", err)
+	}
+	utils.SendCreated(c, info)
+}
+
+// ListBackups godoc
+// @Summary      DB 백업 목록
+// @Description  백업 디렉터리의 백업 파일을 최신순으로 보여줍니다. 복원은 서버를 멈추고 `./main restore <이름>`으로 합니다.
+// @Tags         Admin
+// @Produce      json
+// @Success      200  {object}  model.WebResponse{data=[]model.BackupInfo}
+// @Router       /admin/backups [get]
+func (h *BackupHandler) ListBackups(c *gin.Context) {
+	list, err := h.mgr.List()
+	if err != nil {
+		utils.SendError(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+	utils.SendSuccess(c, list)
+}
@@ -0,0 +1,50 @@
+package handler
+
+import (
+	"encoding/json"
+	"go_study/backup"
+	"go_study/model"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/glebarez/sqlite"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+)
+
+func TestBackupHandler_CreateAndList(t *testing.T) {
+	dir := t.TempDir()
+	db, err := gorm.Open(sqlite.Open(filepath.Join(dir, "todos.db")), &gorm.Config{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	db.AutoMigrate(&model.Todo{})
+
+	h := NewBackupHandler(backup.NewManager(db, filepath.Join(dir, "backups")), func() int { return 1 })
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	r.POST("/admin/backup", h.CreateBackup)
+	r.GET("/admin/backups", h.ListBackups)
+	do := func(method, path string) *httptest.ResponseRecorder {
+		req, _ := http.NewRequest(method, path, nil)
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+		return w
+	}
+
+	assert.Equal(t, http.StatusCreated, do("POST", "/admin/backup").Code)
+	time.Sleep(2 * time.Millisecond)
+	w := do("POST", "/admin/backup")
+	var latest model.BackupInfo
+	json.Unmarshal(w.Body.Bytes(), &model.WebResponse{Data: &latest})
+
+	// 보관 개수(1)를 넘는 백업은 지워짐
+	var list []model.BackupInfo
+	json.Unmarshal(do("GET", "/admin/backups").Body.Bytes(), &model.WebResponse{Data: &list})
+	assert.Len(t, list, 1)
+	assert.Equal(t, latest.Name, list[0].Name)
+}
@@ -0,0 +1,426 @@
+package handler
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"go_study/caldav"
+	"go_study/ical"
+	"go_study/model"
+	"go_study/repository"
+	"go_study/utils"
+	"io"
+	"net/http"
+	"net/url"
+	"path"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+// CalDAV 리소스(.ics) 최대 크기 (1MB)
+const maxCalDAVResourceSize = 1 << 20
+
+// CalDAV 경로 구조 (토큰은 달력 구독 토큰을 그대로 사용)
+// - /caldav/{token}/ : 사용자 홈 (principal 겸 calendar-home-set)
+// - /caldav/{token}/todos/ : 할 일 달력 컬렉션
+// - /caldav/{token}/todos/{uid}.ics : 할 일 하나
+
+// CalDAVHandler: iOS 미리 알림, Thunderbird 같은 앱이 할 일을 동기화하는 최소한의 CalDAV 서버
+type CalDAVHandler struct {
+	repo   repository.TodoRepository
+	tokens repository.CalendarTokenRepository
+	audits repository.AuditRepository
+}
+
+// 생성자
+func NewCalDAVHandler(r repository.TodoRepository, tokens repository.CalendarTokenRepository, audits repository.AuditRepository) *CalDAVHandler {
+	return &CalDAVHandler{repo: r, tokens: tokens, audits: audits}
+}
+
+// RequireToken : 경로의 토큰이 발급된 달력 토큰인지 확인하는 미들웨어
+func (h *CalDAVHandler) RequireToken(c *gin.Context) {
+	if _, err := h.tokens.FindByToken(c.Param("token")); err != nil {
+		if err == gorm.ErrRecordNotFound {
+			utils.SendError(c, http.StatusNotFound, "Calendar not found")
+		} else {
+			utils.SendError(c, http.StatusInternalServerError, "Fail to load calendar")
+		}
+		c.Abort()
+		return
+	}
+	c.Next()
+}
+
+// Options : 지원하는 메소드와 DAV 기능 안내
+func (h *CalDAVHandler) Options(c *gin.Context) {
+	c.Header("DAV", "1, 3, calendar-access")
+	c.Header("Allow", "OPTIONS, GET, PUT, DELETE, PROPFIND, REPORT")
+	c.Status(http.StatusOK)
+}
+
+// ---------------------------------------------------------------
+// PROPFIND
+// ---------------------------------------------------------------
+
+// PropFindHome : 홈(/caldav/{token}/) 조회 - Depth 1이면 할 일 달력도 함께
+func (h *CalDAVHandler) PropFindHome(c *gin.Context) {
+	req, ok := parsePropFind(c)
+	if !ok {
+		return
+	}
+	responses := []caldav.Response{
+		caldav.NewResponse(homePath(c), h.homeProps(c), req.Props, req.AllProp),
+	}
+	if c.GetHeader("Depth") != "0" {
+		props, err := h.calendarProps(c)
+		if err != nil {
+			utils.SendError(c, http.StatusInternalServerError, err.Error())
+			return
+		}
+		responses = append(responses, caldav.NewResponse(calendarPath(c), props, req.Props, req.AllProp))
+	}
+	sendMultiStatus(c, responses)
+}
+
+// PropFindCalendar : 할 일 달력 컬렉션 조회 - Depth 1이면 모든 할 일의 ETag도 함께
+func (h *CalDAVHandler) PropFindCalendar(c *gin.Context) {
+	req, ok := parsePropFind(c)
+	if !ok {
+		return
+	}
+	props, err := h.calendarProps(c)
+	if err != nil {
+		utils.SendError(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+	responses := []caldav.Response{caldav.NewResponse(calendarPath(c), props, req.Props, req.AllProp)}
+
+	if c.GetHeader("Depth") != "0" {
+		err := h.repo.Stream(repository.TodoFilter{}, func(t model.Todo) error {
+			// allprop일 때 calendar-data까지 주면 너무 커지므로 속성만
+			responses = append(responses, caldav.NewResponse(resourcePath(c, t), resourceProps(t, false), req.Props, req.AllProp))
+			return nil
+		})
+		if err != nil {
+			utils.SendError(c, http.StatusInternalServerError, err.Error())
+			return
+		}
+	}
+	sendMultiStatus(c, responses)
+}
+
+// PropFindResource : 할 일 하나의 속성 조회
+func (h *CalDAVHandler) PropFindResource(c *gin.Context) {
+	req, ok := parsePropFind(c)
+	if !ok {
+		return
+	}
+	todo, err := h.findResource(c.Param("name"))
+	if err != nil {
+		sendResourceError(c, err)
+		return
+	}
+	sendMultiStatus(c, []caldav.Response{
+		caldav.NewResponse(resourcePath(c, todo), resourceProps(todo, false), req.Props, req.AllProp),
+	})
+}
+
+// ---------------------------------------------------------------
+// REPORT
+// ---------------------------------------------------------------
+
+// Report : calendar-query (필터로 목록 조회) / calendar-multiget (href로 여러 개 조회)
+func (h *CalDAVHandler) Report(c *gin.Context) {
+	rep, err := caldav.ParseReport(c.Request.Body)
+	if err != nil {
+		if errors.Is(err, caldav.ErrUnsupportedReport) {
+			utils.SendError(c, http.StatusForbidden, "Unsupported REPORT (calendar-query, calendar-multiget only)")
+			return
+		}
+		utils.SendError(c, http.StatusBadRequest, "Invalid REPORT body: "+err.Error())
+		return
+	}
+
+	var responses []caldav.Response
+	switch rep.Kind {
+	case "calendar-query":
+		// 이 달력에는 VTODO만 있으므로 VEVENT 등을 찾으면 빈 결과
+		if rep.Component == "" || rep.Component == "VTODO" {
+			filter := repository.TodoFilter{}
+			if rep.OnlyNotCompleted {
+				notDone := false
+				filter.Done = &notDone
+			}
+			err = h.repo.Stream(filter, func(t model.Todo) error {
+				responses = append(responses, caldav.NewResponse(resourcePath(c, t), resourceProps(t, true), rep.Props, false))
+				return nil
+			})
+			if err != nil {
+				utils.SendError(c, http.StatusInternalServerError, err.Error())
+				return
+			}
+		}
+	case "calendar-multiget":
+		for _, href := range rep.Hrefs {
+			todo, err := h.findResource(hrefName(href))
+			if err != nil {
+				status := http.StatusNotFound
+				if err != gorm.ErrRecordNotFound {
+					status = http.StatusInternalServerError
+				}
+				responses = append(responses, caldav.Response{Href: href, Status: status})
+				continue
+			}
+			resp := caldav.NewResponse(href, resourceProps(todo, true), rep.Props, false)
+			responses = append(responses, resp)
+		}
+	}
+	sendMultiStatus(c, responses)
+}
+
+// ---------------------------------------------------------------
+// GET / PUT / DELETE
+// ---------------------------------------------------------------
+
+// GetResource : 할 일 하나를 .ics로 내려줌
+func (h *CalDAVHandler) GetResource(c *gin.Context) {
+	todo, err := h.findResource(c.Param("name"))
+	if err != nil {
+		sendResourceError(c, err)
+		return
+	}
+	c.Header("ETag", etagOf(todo))
+	c.Data(http.StatusOK, "text/calendar; charset=utf-8", []byte(singleICS(todo)))
+}
+
+// PutResource : .ics를 받아서 할 일을 만들거나 덮어씀
+// If-Match(ETag가 같을 때만 수정) / If-None-Match: *(없을 때만 생성) 조건부 요청을 지원합니다.
+func (h *CalDAVHandler) PutResource(c *gin.Context) {
+	uid, ok := strings.CutSuffix(c.Param("name"), ".ics")
+	if !ok || uid == "" {
+		utils.SendError(c, http.StatusBadRequest, "Resource name must end with .ics")
+		return
+	}
+
+	body, err := io.ReadAll(http.MaxBytesReader(c.Writer, c.Request.Body, maxCalDAVResourceSize))
+	if err != nil {
+		utils.SendError(c, http.StatusRequestEntityTooLarge, "Calendar resource is too large")
+		return
+	}
+	incoming, err := ical.ParseTodo(bytes.NewReader(body))
+	if err != nil {
+		if err == ical.ErrNoTodo {
+			// supported-calendar-component 전제조건 위반 (이 달력은 VTODO 전용)
+			utils.SendError(c, http.StatusForbidden, "Only VTODO components are supported")
+			return
+		}
+		utils.SendError(c, http.StatusBadRequest, "Invalid calendar data: "+err.Error())
+		return
+	}
+	if incoming.UID != uid {
+		utils.SendError(c, http.StatusBadRequest, "Resource name must match the VTODO UID")
+		return
+	}
+	if strings.TrimSpace(incoming.Task) == "" {
+		utils.SendError(c, http.StatusBadRequest, "SUMMARY is required")
+		return
+	}
+
+	existing, err := h.findResource(c.Param("name"))
+	switch {
+	case err == nil:
+		// 수정
+		if c.GetHeader("If-None-Match") == "*" || !ifMatch(c, existing) {
+			c.Status(http.StatusPreconditionFailed)
+			return
+		}
+		incoming.ID = existing.ID
+		incoming.UID = existing.UID // 서버에서 만든 할 일은 UID 컬럼을 비워둔 채로 유지
+		updated, err := h.repo.Replace(incoming)
+		if err != nil {
+			utils.SendError(c, http.StatusInternalServerError, "Fail to Update")
+			return
+		}
+		c.Header("ETag", etagOf(updated))
+		c.Status(http.StatusNoContent)
+		recordTodoAudit(h.audits, c, model.AuditTodoUpdate, updated.ID, &existing, &updated)
+	case err == gorm.ErrRecordNotFound:
+		// 생성
+		if c.GetHeader("If-Match") != "" {
+			c.Status(http.StatusPreconditionFailed)
+			return
+		}
+		if _, isDefault := ical.ParseDefaultUID(uid); isDefault {
+			// 서버가 쓰는 UID 형식은 클라이언트가 새로 만들 수 없음 (삭제된 할 일을 되살리는 것 방지)
+			utils.SendError(c, http.StatusConflict, "UID is reserved")
+			return
+		}
+		created, err := h.repo.Save(incoming)
+		if err != nil {
+			utils.SendError(c, http.StatusInternalServerError, err.Error())
+			return
+		}
+		c.Header("ETag", etagOf(created))
+		c.Status(http.StatusCreated)
+		recordTodoAudit(h.audits, c, model.AuditTodoCreate, created.ID, nil, &created)
+	default:
+		utils.SendError(c, http.StatusInternalServerError, err.Error())
+	}
+}
+
+// DeleteResource : 할 일 삭제
+func (h *CalDAVHandler) DeleteResource(c *gin.Context) {
+	todo, err := h.findResource(c.Param("name"))
+	if err != nil {
+		sendResourceError(c, err)
+		return
+	}
+	if !ifMatch(c, todo) {
+		c.Status(http.StatusPreconditionFailed)
+		return
+	}
+	if err := h.repo.Delete(fmt.Sprint(todo.ID)); err != nil {
+		sendResourceError(c, err)
+		return
+	}
+	c.Status(http.StatusNoContent)
+	recordTodoAudit(h.audits, c, model.AuditTodoDelete, todo.ID, &todo, nil)
+}
+
+// ---------------------------------------------------------------
+// 내부 함수
+// ---------------------------------------------------------------
+
+// "{uid}.ics" 이름으로 할 일 찾기 (서버에서 만든 할 일은 "todo-{id}@go-todo-api.ics")
+func (h *CalDAVHandler) findResource(name string) (model.Todo, error) {
+	uid, ok := strings.CutSuffix(name, ".ics")
+	if !ok {
+		return model.Todo{}, gorm.ErrRecordNotFound
+	}
+	if id, isDefault := ical.ParseDefaultUID(uid); isDefault {
+		todo, err := h.repo.FindByID(id)
+		if err == nil && todo.UID != "" {
+			return model.Todo{}, gorm.ErrRecordNotFound
+		}
+		return todo, err
+	}
+	return h.repo.FindByUID(uid)
+}
+
+func (h *CalDAVHandler) homeProps(c *gin.Context) map[caldav.PropName]string {
+	return map[caldav.PropName]string{
+		caldav.PropResourceType:         "<d:collection/>",
+		caldav.PropDisplayName:          "Go Todo",
+		caldav.PropCurrentUserPrincipal: caldav.Href(homePath(c)),
+		caldav.PropCalendarHomeSet:      caldav.Href(homePath(c)),
+	}
+}
+
+func (h *CalDAVHandler) calendarProps(c *gin.Context) (map[caldav.PropName]string, error) {
+	ctag, err := h.ctag()
+	if err != nil {
+		return nil, err
+	}
+	return map[caldav.PropName]string{
+		caldav.PropResourceType:         "<d:collection/><c:calendar/>",
+		caldav.PropDisplayName:          "할 일",
+		caldav.PropGetCTag:              caldav.Escape(ctag),
+		caldav.PropCurrentUserPrincipal: caldav.Href(homePath(c)),
+		caldav.PropCalendarHomeSet:      caldav.Href(homePath(c)),
+		caldav.PropSupportedComponents:  `<c:comp name="VTODO"/>`,
+		caldav.PropPrivilegeSet:         "<d:privilege><d:read/></d:privilege><d:privilege><d:write/></d:privilege>",
+		caldav.PropSupportedReportSet: "<d:supported-report><d:report><c:calendar-query/></d:report></d:supported-report>" +
+			"<d:supported-report><d:report><c:calendar-multiget/></d:report></d:supported-report>",
+	}, nil
+}
+
+// 컬렉션 전체의 변경 표시 (항목이 하나라도 바뀌거나 지워지면 달라짐)
+func (h *CalDAVHandler) ctag() (string, error) {
+	var b strings.Builder
+	err := h.repo.Stream(repository.TodoFilter{}, func(t model.Todo) error {
+		b.WriteString(etagOf(t))
+		return nil
+	})
+	if err != nil {
+		return "", err
+	}
+	sum := sha256.Sum256([]byte(b.String()))
+	return hex.EncodeToString(sum[:16]), nil
+}
+
+// 리소스 속성 (withData면 calendar-data 포함)
+func resourceProps(t model.Todo, withData bool) map[caldav.PropName]string {
+	props := map[caldav.PropName]string{
+		caldav.PropResourceType:   "",
+		caldav.PropGetETag:        caldav.Escape(etagOf(t)),
+		caldav.PropGetContentType: "text/calendar; charset=utf-8; component=VTODO",
+	}
+	if withData {
+		props[caldav.PropCalendarData] = caldav.Escape(singleICS(t))
+	}
+	return props
+}
+
+// ETag: 수정될 때마다 바뀌는 값 (ID + 수정 시각)
+func etagOf(t model.Todo) string {
+	return fmt.Sprintf(`"%d-%d"`, t.ID, t.UpdatedAt.UnixNano())
+}
+
+// If-Match 헤더가 없거나 현재 ETag와 같으면 true
+func ifMatch(c *gin.Context, t model.Todo) bool {
+	v := c.GetHeader("If-Match")
+	return v == "" || v == "*" || v == etagOf(t)
+}
+
+func singleICS(t model.Todo) string {
+	var buf bytes.Buffer
+	w := ical.NewWriter(&buf)
+	ical.WriteSingle(w, t)
+	w.Flush()
+	return buf.String()
+}
+
+func homePath(c *gin.Context) string {
+	return "/caldav/" + c.Param("token") + "/"
+}
+
+func calendarPath(c *gin.Context) string {
+	return homePath(c) + "todos/"
+}
+
+func resourcePath(c *gin.Context, t model.Todo) string {
+	return calendarPath(c) + url.PathEscape(ical.TodoUID(t)) + ".ics"
+}
+
+// multiget의 href(전체 경로 또는 URL)에서 마지막 이름만 꺼냄
+func hrefName(href string) string {
+	if u, err := url.Parse(href); err == nil {
+		href = u.Path
+	}
+	return path.Base(href)
+}
+
+func parsePropFind(c *gin.Context) (caldav.PropFind, bool) {
+	req, err := caldav.ParsePropFind(c.Request.Body)
+	if err != nil {
+		utils.SendError(c, http.StatusBadRequest, "Invalid PROPFIND body: "+err.Error())
+		return req, false
+	}
+	return req, true
+}
+
+func sendMultiStatus(c *gin.Context, responses []caldav.Response) {
+	c.Data(http.StatusMultiStatus, "application/xml; charset=utf-8", []byte(caldav.MultiStatus(responses)))
+}
+
+func sendResourceError(c *gin.Context, err error) {
+	if err == gorm.ErrRecordNotFound {
+		utils.SendError(c, http.StatusNotFound, "Data not found")
+		return
+	}
+	utils.SendError(c, http.StatusInternalServerError, err.Error())
+}
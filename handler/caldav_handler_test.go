@@ -0,0 +1,145 @@
+package handler
+
+import (
+	"bytes"
+	"go_study/model"
+	"go_study/repository"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/glebarez/sqlite"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+)
+
+// CalDAV는 요청이 여러 번 오가는 프로토콜이라 Mock 대신 인메모리 DB를 쓰는 실제 저장소로 테스트합니다.
+func newCalDAVTestRouter(t *testing.T) (*gin.Engine, *repository.SQLiteRepository) {
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	db.AutoMigrate(&model.Todo{}, &model.Sequence{}, &model.CalendarToken{}, &model.AuditEvent{}, &model.TodoRevision{}, &model.OutboxEvent{})
+	todoRepo := repository.NewSQLiteRepository(db)
+	tokenRepo := repository.NewSQLiteCalendarTokenRepository(db)
+	tokenRepo.Create(model.CalendarToken{Name: "test", Token: "secret"})
+
+	h := NewCalDAVHandler(todoRepo, tokenRepo, repository.NewSQLiteAuditRepository(db))
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	dav := r.Group("/caldav/:token", h.RequireToken)
+	dav.Handle("PROPFIND", "/", h.PropFindHome)
+	dav.Handle("PROPFIND", "/todos/", h.PropFindCalendar)
+	dav.Handle("REPORT", "/todos/", h.Report)
+	dav.GET("/todos/:name", h.GetResource)
+	dav.PUT("/todos/:name", h.PutResource)
+	dav.DELETE("/todos/:name", h.DeleteResource)
+	return r, todoRepo
+}
+
+// testdata/caldav 아래의 XML / ICS 파일 읽기
+func caldavFixture(t *testing.T, name string) []byte {
+	b, err := os.ReadFile(filepath.Join("testdata", "caldav", name))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return b
+}
+
+func davRequest(r *gin.Engine, method, path string, body []byte, headers map[string]string) *httptest.ResponseRecorder {
+	req, _ := http.NewRequest(method, path, bytes.NewReader(body))
+	for k, v := range headers {
+		req.Header.Set(k, v)
+	}
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+	return w
+}
+
+func TestCalDAV_PutGetAndConditionalRequests(t *testing.T) {
+	r, repo := newCalDAVTestRouter(t)
+	path := "/caldav/secret/todos/ABC-123.ics"
+
+	// 1. 생성 (If-None-Match: * -> 없을 때만)
+	w := davRequest(r, "PUT", path, caldavFixture(t, "put_vtodo.ics"), map[string]string{"If-None-Match": "*"})
+	assert.Equal(t, http.StatusCreated, w.Code)
+	etag := w.Header().Get("ETag")
+	assert.NotEmpty(t, etag)
+
+	saved, err := repo.FindByUID("ABC-123")
+	assert.NoError(t, err)
+	assert.Equal(t, "우유 사기, 빵 사기", saved.Task)
+	assert.Equal(t, 5, saved.Priority)
+	assert.Equal(t, "2025-03-05T00:00:00Z", saved.DueAt.UTC().Format("2006-01-02T15:04:05Z07:00"))
+
+	// 2. 같은 이름으로 다시 생성 시도 -> 412
+	w = davRequest(r, "PUT", path, caldavFixture(t, "put_vtodo.ics"), map[string]string{"If-None-Match": "*"})
+	assert.Equal(t, http.StatusPreconditionFailed, w.Code)
+
+	// 3. GET -> 같은 ETag + ICS
+	w = davRequest(r, "GET", path, nil, nil)
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, etag, w.Header().Get("ETag"))
+	assert.Contains(t, w.Body.String(), "UID:ABC-123\r\n")
+
+	// 4. 오래된 ETag로 수정 -> 412, 올바른 ETag -> 204 (완료 처리)
+	w = davRequest(r, "PUT", path, caldavFixture(t, "put_vtodo_completed.ics"), map[string]string{"If-Match": `"stale"`})
+	assert.Equal(t, http.StatusPreconditionFailed, w.Code)
+	w = davRequest(r, "PUT", path, caldavFixture(t, "put_vtodo_completed.ics"), map[string]string{"If-Match": etag})
+	assert.Equal(t, http.StatusNoContent, w.Code)
+	updated, _ := repo.FindByUID("ABC-123")
+	assert.True(t, updated.Done)
+	assert.Nil(t, updated.DueAt, "PUT은 전체 덮어쓰기이므로 DUE가 없으면 지워져야 함")
+
+	// 5. VEVENT는 받지 않음
+	w = davRequest(r, "PUT", "/caldav/secret/todos/EVT-1.ics", caldavFixture(t, "put_vevent.ics"), nil)
+	assert.Equal(t, http.StatusForbidden, w.Code)
+
+	// 6. 삭제
+	w = davRequest(r, "DELETE", path, nil, nil)
+	assert.Equal(t, http.StatusNoContent, w.Code)
+	w = davRequest(r, "GET", path, nil, nil)
+	assert.Equal(t, http.StatusNotFound, w.Code)
+}
+
+func TestCalDAV_PropFindAndReports(t *testing.T) {
+	r, repo := newCalDAVTestRouter(t)
+	apiTodo, _ := repo.Save(model.Todo{Task: "API로 만든 할 일"})
+	done, _ := repo.Save(model.Todo{Task: "끝난 일", Done: true})
+	davRequest(r, "PUT", "/caldav/secret/todos/ABC-123.ics", caldavFixture(t, "put_vtodo.ics"), nil)
+
+	// 1. PROPFIND Depth 1: 컬렉션 + 리소스 3개, 모르는 속성은 404 propstat
+	w := davRequest(r, "PROPFIND", "/caldav/secret/todos/", caldavFixture(t, "propfind_calendar.xml"), map[string]string{"Depth": "1"})
+	assert.Equal(t, http.StatusMultiStatus, w.Code)
+	body := w.Body.String()
+	assert.Equal(t, 4, strings.Count(body, "<d:response>"))
+	assert.Contains(t, body, "<d:resourcetype><d:collection/><c:calendar/></d:resourcetype>")
+	assert.Contains(t, body, `<c:supported-calendar-component-set><c:comp name="VTODO"/></c:supported-calendar-component-set>`)
+	assert.Contains(t, body, "<cs:getctag>")
+	assert.Contains(t, body, `<unknown-prop xmlns="http://example.com/ns/"/>`)
+	assert.Contains(t, body, "HTTP/1.1 404 Not Found")
+	assert.Contains(t, body, "/caldav/secret/todos/todo-1@go-todo-api.ics")
+
+	// 2. calendar-query (미완료만) -> 완료된 항목은 빠짐
+	w = davRequest(r, "REPORT", "/caldav/secret/todos/", caldavFixture(t, "report_query_vtodo.xml"), map[string]string{"Depth": "1"})
+	assert.Equal(t, http.StatusMultiStatus, w.Code)
+	body = w.Body.String()
+	assert.Contains(t, body, "SUMMARY:"+apiTodo.Task)
+	assert.NotContains(t, body, "SUMMARY:"+done.Task)
+	assert.Contains(t, body, "<c:calendar-data>BEGIN:VCALENDAR")
+
+	// 3. calendar-multiget -> 있는 건 데이터, 없는 건 404 상태
+	w = davRequest(r, "REPORT", "/caldav/secret/todos/", caldavFixture(t, "report_multiget.xml"), nil)
+	assert.Equal(t, http.StatusMultiStatus, w.Code)
+	body = w.Body.String()
+	assert.Contains(t, body, "UID:ABC-123")
+	assert.Contains(t, body, "<d:href>/caldav/secret/todos/missing.ics</d:href><d:status>HTTP/1.1 404 Not Found</d:status>")
+
+	// 4. 토큰이 틀리면 404
+	w = davRequest(r, "PROPFIND", "/caldav/wrong/todos/", nil, map[string]string{"Depth": "0"})
+	assert.Equal(t, http.StatusNotFound, w.Code)
+}
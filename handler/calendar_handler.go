@@ -0,0 +1,141 @@
+package handler
+
+import (
+	"go_study/ical"
+	"go_study/model"
+	"go_study/repository"
+	"go_study/utils"
+	"log"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+// CalendarHandler: 달력(iCalendar) 피드 핸들러
+type CalendarHandler struct {
+	repo   repository.TodoRepository
+	tokens repository.CalendarTokenRepository
+}
+
+// 생성자: 할 일 저장소와 토큰 저장소를 주입받습니다.
+func NewCalendarHandler(r repository.TodoRepository, tokens repository.CalendarTokenRepository) *CalendarHandler {
+	return &CalendarHandler{repo: r, tokens: tokens}
+}
+
+// GetFeed godoc
+// @Summary      할 일 달력 피드 (ICS)
+// @Description  할 일을 VTODO로 담은 iCalendar 피드입니다. 달력 앱에서 이 주소를 "구독"하면 됩니다.
+// @Description  events=true 를 붙이면 마감일이 있는 할 일을 VEVENT로도 넣어줍니다.
+// @Tags         Calendar
+// @Produce      plain
+// @Param        file    path   string  true   "{token}.ics"
+// @Param        events  query  bool    false  "마감일을 VEVENT로도 출력"
+// @Success      200  {file}    file
+// @Failure      404  {object}  model.WebResponse  "토큰이 없거나 잘못됨"
+// @Router       /calendar/{file} [get]
+func (h *CalendarHandler) GetFeed(c *gin.Context) {
+	token, ok := strings.CutSuffix(c.Param("file"), ".ics")
+	if !ok || token == "" {
+		utils.SendError(c, http.StatusNotFound, "Calendar not found")
+		return
+	}
+	// 토큰이 틀린 경우와 없는 경우를 구분하지 않음 (토큰 추측 방지)
+	if _, err := h.tokens.FindByToken(token); err != nil {
+		if err == gorm.ErrRecordNotFound {
+			utils.SendError(c, http.StatusNotFound, "Calendar not found")
+			return
+		}
+		utils.SendError(c, http.StatusInternalServerError, "Fail to load calendar")
+		return
+	}
+
+	opts := ical.Options{Name: "Go Todo"}
+	opts.Events, _ = strconv.ParseBool(c.Query("events"))
+
+	c.Header("Content-Type", "text/calendar; charset=utf-8")
+	c.Header("Cache-Control", "private, max-age=300")
+	c.Status(http.StatusOK)
+
+	w := ical.NewWriter(c.Writer)
+	ical.BeginCalendar(w, opts)
+	err := h.repo.Stream(repository.TodoFilter{}, func(t model.Todo) error {
+		ical.WriteTodo(w, t, opts)
+		return nil
+	})
+	if err != nil {
+		// 헤더가 이미 나갔으므로 로그만 남김 (닫히지 않은 달력은 앱이 버림)
+		log.Printf("❌ 달력 피드 생성 실패: %v\n", err)
+		return
+	}
+	ical.EndCalendar(w)
+	if err := w.Flush(); err != nil {
+		log.Printf("❌ 달력 피드 전송 실패: %v\n", err)
+	}
+}
+
+// CreateToken godoc
+// @Summary      달력 구독 토큰 발급
+// @Description  새 비밀 토큰을 발급합니다. 구독 주소는 /calendar/{token}.ics 입니다.
+// @Tags         Calendar
+// @Accept       json
+// @Produce      json
+// @Param        input  body  model.CreateCalendarTokenInput  true  "토큰 이름"
+// @Success      201  {object}  model.WebResponse{data=model.CalendarToken}
+// @Failure      400  {object}  model.WebResponse
+// @Router       /admin/calendar-tokens [post]
+func (h *CalendarHandler) CreateToken(c *gin.Context) {
+	var input model.CreateCalendarTokenInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		utils.SendError(c, http.StatusBadRequest, err.Error())
+		return
+	}
+	secret, err := utils.RandomToken(24)
+	if err != nil {
+		utils.SendError(c, http.StatusInternalServerError, "Fail to generate token")
+		return
+	}
+	created, err := h.tokens.Create(model.CalendarToken{Name: input.Name, Token: secret})
+	if err != nil {
+		utils.SendError(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+	utils.SendCreated(c, created)
+}
+
+// ListTokens godoc
+// @Summary      달력 구독 토큰 목록
+// @Tags         Calendar
+// @Produce      json
+// @Success      200  {object}  model.WebResponse{data=[]model.CalendarToken}
+// @Router       /admin/calendar-tokens [get]
+func (h *CalendarHandler) ListTokens(c *gin.Context) {
+	tokens, err := h.tokens.List()
+	if err != nil {
+		utils.SendError(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+	utils.SendSuccess(c, tokens)
+}
+
+// DeleteToken godoc
+// @Summary      달력 구독 토큰 폐기
+// @Description  토큰을 삭제하면 그 주소로는 더 이상 피드를 받을 수 없습니다.
+// @Tags         Calendar
+// @Param        id   path      int  true  "토큰 ID"
+// @Success      200  {object}  model.WebResponse
+// @Failure      404  {object}  model.WebResponse
+// @Router       /admin/calendar-tokens/{id} [delete]
+func (h *CalendarHandler) DeleteToken(c *gin.Context) {
+	if err := h.tokens.Delete(c.Param("id")); err != nil {
+		if err == gorm.ErrRecordNotFound {
+			utils.SendError(c, http.StatusNotFound, "Data not found")
+			return
+		}
+		utils.SendError(c, http.StatusInternalServerError, "Fail to Delete")
+		return
+	}
+	utils.SendSuccessWithMessage(c, "삭제 성공", nil)
+}
@@ -0,0 +1,70 @@
+package handler
+
+import (
+	"go_study/model"
+	"go_study/repository"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"gorm.io/gorm"
+)
+
+// 달력 토큰 저장소 Mock
+type MockCalendarTokenRepository struct {
+	mock.Mock
+}
+
+func (m *MockCalendarTokenRepository) Create(t model.CalendarToken) (model.CalendarToken, error) {
+	args := m.Called(t)
+	return args.Get(0).(model.CalendarToken), args.Error(1)
+}
+
+func (m *MockCalendarTokenRepository) List() ([]model.CalendarToken, error) {
+	args := m.Called()
+	return args.Get(0).([]model.CalendarToken), args.Error(1)
+}
+
+func (m *MockCalendarTokenRepository) FindByToken(token string) (model.CalendarToken, error) {
+	args := m.Called(token)
+	return args.Get(0).(model.CalendarToken), args.Error(1)
+}
+
+func (m *MockCalendarTokenRepository) Delete(id string) error {
+	args := m.Called(id)
+	return args.Error(0)
+}
+
+func TestGetFeed(t *testing.T) {
+	mockRepo := new(MockTodoRepository)
+	mockTokens := new(MockCalendarTokenRepository)
+	mockTokens.On("FindByToken", "secret").Return(model.CalendarToken{ID: 1, Token: "secret"}, nil)
+	mockTokens.On("FindByToken", "wrong").Return(model.CalendarToken{}, gorm.ErrRecordNotFound)
+	mockRepo.On("Stream", repository.TodoFilter{}).Return([]model.Todo{{ID: 1, Task: "달력 테스트"}}, nil)
+
+	h := NewCalendarHandler(mockRepo, mockTokens)
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	r.GET("/calendar/:file", h.GetFeed)
+
+	// 1. 올바른 토큰 -> ICS
+	req, _ := http.NewRequest("GET", "/calendar/secret.ics", nil)
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.True(t, strings.HasPrefix(w.Header().Get("Content-Type"), "text/calendar"))
+	assert.Contains(t, w.Body.String(), "SUMMARY:달력 테스트\r\n")
+	assert.True(t, strings.HasSuffix(w.Body.String(), "END:VCALENDAR\r\n"))
+
+	// 2. 틀린 토큰 / 확장자 없음 -> 404
+	for _, path := range []string{"/calendar/wrong.ics", "/calendar/secret"} {
+		req, _ = http.NewRequest("GET", path, nil)
+		w = httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusNotFound, w.Code, path)
+	}
+}
@@ -0,0 +1,52 @@
+package handler
+
+import (
+	"go_study/cluster"
+	"go_study/model"
+	"go_study/utils"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// ClusterHandler: 서버끼리 주고받는 heartbeat와 클러스터 상태 조회
+type ClusterHandler struct {
+	monitor *cluster.Monitor
+}
+
+// 생성자
+func NewClusterHandler(monitor *cluster.Monitor) *ClusterHandler {
+	return &ClusterHandler{monitor: monitor}
+}
+
+// Heartbeat godoc
+// @Summary      heartbeat 수신 (서버 간 통신용)
+// @Description  peer가 자기 상태를 보내면 기록하고 이 서버의 상태를 돌려줍니다. 둘 다 Active면 임기가 작은 쪽이 Standby가 됩니다.
+// @Tags         System
+// @Accept       json
+// @Produce      json
+// @Param        X-Cluster-Token  header  string              true   "cluster.token (설정하지 않은 서버는 heartbeat를 받지 않음)"
+// @Param        node             body    model.NodeStatus  true   "보내는 서버의 상태"
+// @Success      200  {object}  model.WebResponse{data=model.NodeStatus}
+// @Failure      400  {object}  model.WebResponse
+// @Failure      401  {object}  model.WebResponse  "토큰이 없거나 틀림 (middleware.RequirePeerToken)"
+// @Router       /cluster/heartbeat [post]
+func (h *ClusterHandler) Heartbeat(c *gin.Context) {
+	var peer model.NodeStatus
+	if err := c.ShouldBindJSON(&peer); err != nil || peer.Name == "" {
+		utils.SendError(c, http.StatusBadRequest, "node name is required")
+		return
+	}
+	utils.SendSuccess(c, h.monitor.Receive(peer))
+}
+
+// GetCluster godoc
+// @Summary      클러스터 상태
+// @Description  이 서버와 peer들의 이름, 역할, 임기, 가동 시간, 마지막 heartbeat, 빌드 버전과 현재 Active(lease holder)를 보여줍니다.
+// @Tags         Admin
+// @Produce      json
+// @Success      200  {object}  model.WebResponse{data=model.ClusterStatus}
+// @Router       /admin/cluster [get]
+func (h *ClusterHandler) GetCluster(c *gin.Context) {
+	utils.SendSuccess(c, h.monitor.Status())
+}
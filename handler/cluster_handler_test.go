@@ -0,0 +1,53 @@
+package handler
+
+import (
+	"bytes"
+	"encoding/json"
+	"go_study/cluster"
+	"go_study/global"
+	"go_study/model"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestClusterHandler_HeartbeatAndStatus(t *testing.T) {
+	defer global.SetStandby()
+	global.SetStandby()
+
+	h := NewClusterHandler(cluster.New(cluster.Options{NodeName: "server-1", Token: "secret"}))
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	r.POST("/cluster/heartbeat", h.Heartbeat)
+	r.GET("/admin/cluster", h.GetCluster)
+	beat := func(node model.NodeStatus) *httptest.ResponseRecorder {
+		body, _ := json.Marshal(node)
+		req, _ := http.NewRequest("POST", "/cluster/heartbeat", bytes.NewReader(body))
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+		return w
+	}
+
+	// 1. 이름이 없으면 거절 (토큰 확인은 middleware.RequirePeerToken)
+	assert.Equal(t, http.StatusBadRequest, beat(model.NodeStatus{}).Code)
+
+	// 2. heartbeat 응답은 내 상태
+	w := beat(model.NodeStatus{Name: "server-2", Role: cluster.RoleActive, Term: 5, Version: "v2"})
+	assert.Equal(t, http.StatusOK, w.Code)
+	var self model.NodeStatus
+	json.Unmarshal(w.Body.Bytes(), &model.WebResponse{Data: &self})
+	assert.Equal(t, "server-1", self.Name)
+	assert.Equal(t, cluster.RoleStandby, self.Role)
+
+	// 3. 관리자 조회에 peer와 Active가 보임
+	req, _ := http.NewRequest("GET", "/admin/cluster", nil)
+	w = httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+	var status model.ClusterStatus
+	json.Unmarshal(w.Body.Bytes(), &model.WebResponse{Data: &status})
+	assert.Equal(t, "server-2", status.LeaseHolder)
+	assert.Equal(t, "v2", status.Peers[0].Node.Version)
+}
@@ -0,0 +1,69 @@
+package handler
+
+import (
+	"go_study/config"
+	"go_study/model"
+	"go_study/utils"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// ConfigHandler: 지금 적용된 설정 조회와 다시 읽기 (관리자용)
+type ConfigHandler struct {
+	reloader *config.Reloader
+}
+
+// 생성자
+func NewConfigHandler(reloader *config.Reloader) *ConfigHandler {
+	return &ConfigHandler{reloader: reloader}
+}
+
+// GetConfig godoc
+// @Summary      적용된 설정 조회
+// @Description  기본값, config.yaml, 환경 변수(TODO_*), 명령줄 플래그를 합쳐 실제로 적용된 설정을 보여줍니다. 비밀 항목(cluster.token 등)은 가려집니다.
+// @Tags         Admin
+// @Produce      json
+// @Success      200  {object}  model.WebResponse{data=map[string]interface{}}
+// @Router       /admin/config [get]
+func (h *ConfigHandler) GetConfig(c *gin.Context) {
+	utils.SendSuccess(c, h.reloader.Current().Effective())
+}
+
+// ReloadConfig godoc
+// @Summary      설정 다시 읽기
+// @Description  config.yaml을 지금 다시 읽어 검증한 뒤, 재시작 없이 바꿀 수 있는 항목(log.level, backup.interval, backup.keep, cors.*, security.*)만 한꺼번에 적용합니다. 재시작해야 적용되는 항목은 restart_required로 알려주고 실행 중인 값은 그대로 둡니다. (파일이 바뀌면 자동으로도 다시 읽음)
+// @Tags         Admin
+// @Produce      json
+// @Success      200  {object}  model.WebResponse{data=config.ReloadStatus}
+// @Failure      422  {object}  model.WebResponse{data=config.ReloadStatus}
+// @Router       /admin/config/reload [post]
+func (h *ConfigHandler) ReloadConfig(c *gin.Context) {
+	status := h.reloader.Reload()
+	if status.Error != "" {
+		c.JSON(http.StatusUnprocessableEntity, model.WebResponse{
+			Code:    http.StatusUnprocessableEntity,
+			Message: status.Error,
+			Data:    status,
+		})
+		return
+	}
+	utils.SendSuccess(c, status)
+}
+
+// GetReloadStatus godoc
+// @Summary      마지막 설정 다시 읽기 결과
+// @Description  파일 변경 감지나 POST /admin/config/reload로 마지막에 다시 읽은 결과 (적용된 항목, 재시작이 필요한 항목, 검증 오류)
+// @Tags         Admin
+// @Produce      json
+// @Success      200  {object}  model.WebResponse{data=config.ReloadStatus}
+// @Failure      404  {object}  model.WebResponse
+// @Router       /admin/config/reload [get]
+func (h *ConfigHandler) GetReloadStatus(c *gin.Context) {
+	status, ok := h.reloader.LastReload()
+	if !ok {
+		utils.SendError(c, http.StatusNotFound, "config has not been reloaded since start")
+		return
+	}
+	utils.SendSuccess(c, status)
+}
@@ -0,0 +1,32 @@
+package handler
+
+import (
+	"encoding/json"
+	"go_study/config"
+	"go_study/model"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestConfigHandler_RedactsSecrets(t *testing.T) {
+	cfg := config.Default()
+	cfg.Cluster.Token = "s3cret"
+
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	r.GET("/admin/config", NewConfigHandler(config.NewReloader(cfg)).GetConfig)
+	req, _ := http.NewRequest("GET", "/admin/config", nil)
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.NotContains(t, w.Body.String(), "s3cret")
+	var got map[string]map[string]interface{}
+	json.Unmarshal(w.Body.Bytes(), &model.WebResponse{Data: &got})
+	assert.Equal(t, config.Redacted, got["cluster"]["token"])
+	assert.Equal(t, ":8080", got["server"]["port"])
+}
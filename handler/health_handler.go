@@ -0,0 +1,82 @@
+package handler
+
+import (
+	"go_study/global"
+	"go_study/healthcheck"
+	"go_study/model"
+	"go_study/utils"
+	"net/http"
+	"os"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// HealthHandler: 쿠버네티스/도커/모니터링용 프로브
+// live는 프로세스가 살아 있는지만, ready는 트래픽을 받을 수 있는지(역할 + 의존성)를 봅니다.
+type HealthHandler struct {
+	checker   *healthcheck.Checker
+	startedAt time.Time
+}
+
+// 생성자
+func NewHealthHandler(checker *healthcheck.Checker) *HealthHandler {
+	return &HealthHandler{checker: checker, startedAt: time.Now()}
+}
+
+// 현재 역할 이름
+func roleName() string {
+	if global.IsActive() {
+		return "active"
+	}
+	return "standby"
+}
+
+// Live godoc
+// @Summary      Liveness 프로브
+// @Description  프로세스가 요청을 처리할 수 있으면 항상 200입니다. (Standby여도 200)
+// @Tags         System
+// @Produce      json
+// @Success      200  {object}  model.WebResponse{data=model.LiveStatus}
+// @Router       /health/live [get]
+func (h *HealthHandler) Live(c *gin.Context) {
+	node, _ := os.Hostname()
+	utils.SendSuccess(c, model.LiveStatus{
+		Status:        "alive",
+		Role:          roleName(),
+		Node:          node,
+		UptimeSeconds: int64(time.Since(h.startedAt).Seconds()),
+	})
+}
+
+// Ready godoc
+// @Summary      Readiness 프로브
+// @Description  Active이고 DB, /data 디스크 여유 공간, 로그 디렉터리 쓰기가 모두 정상이면 200, 아니면 503과 실패한 점검 목록을 돌려줍니다.
+// @Tags         System
+// @Produce      json
+// @Success      200  {object}  model.WebResponse{data=model.HealthReport}
+// @Failure      503  {object}  model.WebResponse{data=model.HealthReport}
+// @Router       /health/ready [get]
+func (h *HealthHandler) Ready(c *gin.Context) {
+	report := h.checker.Run(c.Request.Context())
+	if !report.Ready {
+		c.JSON(http.StatusServiceUnavailable, model.WebResponse{
+			Code:    http.StatusServiceUnavailable,
+			Message: "Not ready",
+			Data:    report,
+		})
+		return
+	}
+	utils.SendSuccess(c, report)
+}
+
+// Details godoc
+// @Summary      상세 상태
+// @Description  점검별 상태, 지연 시간, 마지막 에러를 항상 200으로 돌려줍니다. (사람/대시보드용)
+// @Tags         System
+// @Produce      json
+// @Success      200  {object}  model.WebResponse{data=model.HealthReport}
+// @Router       /health/details [get]
+func (h *HealthHandler) Details(c *gin.Context) {
+	utils.SendSuccess(c, h.checker.Run(c.Request.Context()))
+}
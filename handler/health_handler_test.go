@@ -0,0 +1,58 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"go_study/global"
+	"go_study/healthcheck"
+	"go_study/model"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHealthProbes(t *testing.T) {
+	defer global.SetStandby()
+	global.SetStandby()
+
+	dbErr := errors.New("database is locked")
+	checker := healthcheck.New(0)
+	checker.Add("role", healthcheck.Role())
+	checker.Add("database", func(ctx context.Context) error { return dbErr })
+
+	h := NewHealthHandler(checker)
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	r.GET("/health/live", h.Live)
+	r.GET("/health/ready", h.Ready)
+	r.GET("/health/details", h.Details)
+	get := func(path string) *httptest.ResponseRecorder {
+		req, _ := http.NewRequest("GET", path, nil)
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+		return w
+	}
+
+	// 1. Standby여도 살아 있음
+	w := get("/health/live")
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), `"role":"standby"`)
+
+	// 2. Standby + DB 실패 → not ready, details는 200으로 점검별 상태
+	assert.Equal(t, http.StatusServiceUnavailable, get("/health/ready").Code)
+	w = get("/health/details")
+	assert.Equal(t, http.StatusOK, w.Code)
+	var report model.HealthReport
+	json.Unmarshal(w.Body.Bytes(), &model.WebResponse{Data: &report})
+	assert.False(t, report.Ready)
+	assert.Equal(t, "database is locked", report.Checks[1].Error)
+
+	// 3. Active + DB 정상 → ready
+	global.SetActive()
+	dbErr = nil
+	assert.Equal(t, http.StatusOK, get("/health/ready").Code)
+}
@@ -0,0 +1,250 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"go_study/model"
+	"go_study/repository"
+	"go_study/utils"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+// undo 대상을 찾을 때 살펴볼 최근 감사 로그 개수
+const maxUndoScan = 200
+
+// HistoryHandler: 할 일 변경 이력 조회, 되돌리기, 실행 취소
+type HistoryHandler struct {
+	repo       repository.TodoRepository
+	audits     repository.AuditRepository
+	undoWindow time.Duration
+}
+
+// 생성자
+func NewHistoryHandler(r repository.TodoRepository, audits repository.AuditRepository, undoWindow time.Duration) *HistoryHandler {
+	return &HistoryHandler{repo: r, audits: audits, undoWindow: undoWindow}
+}
+
+// GetHistory godoc
+// @Summary      할 일 변경 이력
+// @Description  할 일의 모든 리비전을 오래된 순으로, 직전 리비전과 달라진 필드와 함께 돌려줍니다. (삭제된 할 일 포함)
+// @Tags         Todos
+// @Produce      json
+// @Param        id   path      int  true  "할 일 ID"
+// @Success      200  {object}  model.WebResponse{data=[]model.RevisionEntry}
+// @Failure      404  {object}  model.WebResponse  "이력 없음"
+// @Router       /todos/{id}/history [get]
+func (h *HistoryHandler) GetHistory(c *gin.Context) {
+	revs, err := h.repo.Revisions(parseID(c.Param("id")))
+	if err != nil {
+		utils.SendError(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+	if len(revs) == 0 {
+		utils.SendError(c, http.StatusNotFound, "No history for this todo")
+		return
+	}
+
+	entries := make([]model.RevisionEntry, len(revs))
+	for i, rev := range revs {
+		var prev *model.TodoRevision
+		if i > 0 {
+			prev = &revs[i-1]
+		}
+		entries[i] = model.RevisionEntry{TodoRevision: rev, Changes: diffRevisions(prev, rev)}
+	}
+	utils.SendSuccess(c, entries)
+}
+
+// RevertTodo godoc
+// @Summary      리비전으로 되돌리기
+// @Description  할 일 내용을 rev번 리비전 때로 되돌립니다. 되돌린 결과도 새 리비전으로 남습니다.
+// @Tags         Todos
+// @Produce      json
+// @Param        id   path      int  true  "할 일 ID"
+// @Param        rev  query     int  true  "리비전 번호 (history의 rev)"
+// @Success      200  {object}  model.WebResponse{data=model.Todo}
+// @Failure      400  {object}  model.WebResponse  "잘못된 rev"
+// @Failure      404  {object}  model.WebResponse  "할 일 또는 리비전 없음"
+// @Router       /todos/{id}/revert [post]
+func (h *HistoryHandler) RevertTodo(c *gin.Context) {
+	rev, err := strconv.Atoi(c.Query("rev"))
+	if err != nil || rev <= 0 {
+		utils.SendError(c, http.StatusBadRequest, "rev must be a positive number")
+		return
+	}
+	id := parseID(c.Param("id"))
+	before, _ := h.repo.FindByID(id)
+	reverted, err := h.repo.Revert(id, rev)
+	if err != nil {
+		switch {
+		case errors.Is(err, gorm.ErrRecordNotFound):
+			utils.SendError(c, http.StatusNotFound, "Data not found")
+		case errors.Is(err, repository.ErrRevisionNotFound):
+			utils.SendError(c, http.StatusNotFound, "Revision not found")
+		default:
+			utils.SendError(c, http.StatusInternalServerError, err.Error())
+		}
+		return
+	}
+	utils.SendSuccess(c, reverted)
+	recordTodoAudit(h.audits, c, model.AuditTodoRevert, reverted.ID, &before, &reverted)
+}
+
+// Undo godoc
+// @Summary      직전 변경 취소
+// @Description  요청자(X-Actor)가 undo 기간 안에 한 마지막 할 일 변경을 취소합니다. 여러 번 부르면 그 이전 변경을 차례로 취소합니다.
+// @Description  그 뒤에 다른 사람이 같은 할 일을 바꿨으면 덮어쓰지 않고 409를 돌려줍니다.
+// @Tags         Todos
+// @Produce      json
+// @Param        X-Actor  header  string  true  "요청자"
+// @Success      200  {object}  model.WebResponse{data=model.UndoResult}
+// @Failure      400  {object}  model.WebResponse  "X-Actor 없음"
+// @Failure      404  {object}  model.WebResponse  "취소할 변경 없음"
+// @Failure      409  {object}  model.WebResponse  "이후에 다른 변경이 있음"
+// @Router       /undo [post]
+func (h *HistoryHandler) Undo(c *gin.Context) {
+	// 익명 요청끼리는 서로의 변경을 취소할 수 있으므로 요청자를 꼭 밝혀야 함
+	if c.GetHeader(utils.ActorHeader) == "" {
+		utils.SendError(c, http.StatusBadRequest, "X-Actor header is required for undo")
+		return
+	}
+	since := time.Now().Add(-h.undoWindow)
+	events, err := h.audits.Find(repository.AuditFilter{Actor: utils.ActorOf(c), From: &since}, maxUndoScan, 0)
+	if err != nil {
+		utils.SendError(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+	target, ok := findUndoTarget(events)
+	if !ok {
+		utils.SendError(c, http.StatusNotFound, "Nothing to undo")
+		return
+	}
+
+	id := parseID(target.EntityID)
+	var before, after model.Todo
+	json.Unmarshal(target.Before, &before)
+	json.Unmarshal(target.After, &after)
+
+	// 취소 전 상태 (이후 다른 변경이 있었는지 확인)
+	current, err := h.repo.FindByID(id)
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		utils.SendError(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+	exists := err == nil
+
+	var result *model.Todo
+	switch target.Action {
+	case model.AuditTodoCreate, model.AuditTodoRestore:
+		// 만들기/되살리기 취소 = 삭제
+		if !exists || !sameContent(current, after) {
+			utils.SendError(c, http.StatusConflict, "Todo has changed since; nothing was undone")
+			return
+		}
+		err = h.repo.Delete(target.EntityID)
+	case model.AuditTodoDelete:
+		// 삭제 취소 = 되살리기 (그 사이 누가 되살렸으면 Restore가 ErrRecordNotFound)
+		var restored model.Todo
+		if restored, err = h.repo.Restore(target.EntityID); err == nil {
+			result = &restored
+		}
+	default:
+		// 수정/되돌리기 취소 = 이전 내용으로 덮어쓰기
+		if !exists || !sameContent(current, after) {
+			utils.SendError(c, http.StatusConflict, "Todo has changed since; nothing was undone")
+			return
+		}
+		var replaced model.Todo
+		replaced, err = h.repo.Replace(model.Todo{
+			ID: id, Task: before.Task, Done: before.Done, Priority: before.Priority, DueAt: before.DueAt, UID: current.UID,
+		})
+		if err == nil {
+			result = &replaced
+		}
+	}
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			utils.SendError(c, http.StatusConflict, "Todo has changed since; nothing was undone")
+			return
+		}
+		utils.SendError(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	utils.SendSuccess(c, model.UndoResult{Undone: target.Action, TodoID: id, Todo: result})
+	var prev *model.Todo
+	if exists {
+		prev = &current
+	}
+	recordTodoAudit(h.audits, c, model.AuditTodoUndo, id, prev, result)
+}
+
+// findUndoTarget: 최신순 감사 로그에서 아직 취소되지 않은 가장 최근 할 일 변경을 찾음
+// undo 기록 하나는 그보다 앞선 변경 하나를 이미 취소한 것으로 칩니다. (여러 단계 undo)
+func findUndoTarget(events []model.AuditEvent) (model.AuditEvent, bool) {
+	undone := 0
+	for _, e := range events {
+		if e.EntityType != "todo" {
+			continue
+		}
+		switch e.Action {
+		case model.AuditTodoUndo:
+			undone++
+		case model.AuditTodoCreate, model.AuditTodoUpdate, model.AuditTodoDelete, model.AuditTodoRestore, model.AuditTodoRevert:
+			if undone > 0 {
+				undone--
+				continue
+			}
+			return e, true
+		}
+	}
+	return model.AuditEvent{}, false
+}
+
+// 사용자가 바꿀 수 있는 내용이 같은지 (수정 시각, seq 제외)
+func sameContent(a, b model.Todo) bool {
+	return a.Task == b.Task && a.Done == b.Done && a.Priority == b.Priority && sameTime(a.DueAt, b.DueAt)
+}
+
+func sameTime(a, b *time.Time) bool {
+	if a == nil || b == nil {
+		return a == nil && b == nil
+	}
+	return a.Equal(*b)
+}
+
+// 두 리비전 사이에 달라진 필드 (prev가 nil이면 첫 리비전이므로 값이 있는 필드 전부)
+func diffRevisions(prev *model.TodoRevision, cur model.TodoRevision) []model.FieldChange {
+	changes := []model.FieldChange{}
+	if prev == nil {
+		changes = append(changes, model.FieldChange{Field: "task", To: cur.Task})
+		if cur.Done {
+			changes = append(changes, model.FieldChange{Field: "done", To: cur.Done})
+		}
+		if cur.Priority != 0 {
+			changes = append(changes, model.FieldChange{Field: "priority", To: cur.Priority})
+		}
+		if cur.DueAt != nil {
+			changes = append(changes, model.FieldChange{Field: "due_at", To: cur.DueAt})
+		}
+		return changes
+	}
+	if prev.Task != cur.Task {
+		changes = append(changes, model.FieldChange{Field: "task", From: prev.Task, To: cur.Task})
+	}
+	if prev.Done != cur.Done {
+		changes = append(changes, model.FieldChange{Field: "done", From: prev.Done, To: cur.Done})
+	}
+	if prev.Priority != cur.Priority {
+		changes = append(changes, model.FieldChange{Field: "priority", From: prev.Priority, To: cur.Priority})
+	}
+	if !sameTime(prev.DueAt, cur.DueAt) {
+		changes = append(changes, model.FieldChange{Field: "due_at", From: prev.DueAt, To: cur.DueAt})
+	}
+	return changes
+}
@@ -0,0 +1,117 @@
+package handler
+
+import (
+	"bytes"
+	"encoding/json"
+	"go_study/model"
+	"go_study/repository"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/glebarez/sqlite"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+)
+
+// undo는 감사 로그와 리비전을 함께 보므로 인메모리 DB를 쓰는 실제 저장소로 테스트합니다.
+func newHistoryTestRouter(t *testing.T) (*gin.Engine, *repository.SQLiteRepository) {
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	db.AutoMigrate(&model.Todo{}, &model.Sequence{}, &model.AuditEvent{}, &model.TodoRevision{}, &model.OutboxEvent{})
+	repo := repository.NewSQLiteRepository(db)
+	audits := repository.NewSQLiteAuditRepository(db)
+
+	todos := NewTodoHandler(repo, audits, nil)
+	h := NewHistoryHandler(repo, audits, 10*time.Minute)
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	r.POST("/todos", todos.AddTodo)
+	r.PATCH("/todos/:id", todos.ToggleTodoStatus)
+	r.DELETE("/todos/:id", todos.DeleteTodo)
+	r.GET("/todos/:id/history", h.GetHistory)
+	r.POST("/todos/:id/revert", h.RevertTodo)
+	r.POST("/undo", h.Undo)
+	return r, repo
+}
+
+func doAs(r *gin.Engine, actor, method, path, body string) *httptest.ResponseRecorder {
+	req, _ := http.NewRequest(method, path, bytes.NewBufferString(body))
+	if actor != "" {
+		req.Header.Set("X-Actor", actor)
+	}
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+	return w
+}
+
+func TestHistory_DiffsAndRevert(t *testing.T) {
+	r, repo := newHistoryTestRouter(t)
+	created, _ := repo.Save(model.Todo{Task: "보고서 쓰기"})
+	repo.Replace(model.Todo{ID: created.ID, Task: "보고서 제출하기", Priority: 1})
+
+	w := doAs(r, "", "GET", "/todos/1/history", "")
+	assert.Equal(t, http.StatusOK, w.Code)
+	var entries []model.RevisionEntry
+	json.Unmarshal(w.Body.Bytes(), &model.WebResponse{Data: &entries})
+	assert.Len(t, entries, 2)
+	assert.Equal(t, []model.FieldChange{
+		{Field: "task", From: "보고서 쓰기", To: "보고서 제출하기"},
+		{Field: "priority", From: float64(0), To: float64(1)},
+	}, entries[1].Changes)
+
+	w = doAs(r, "", "POST", "/todos/1/revert?rev=1", "")
+	assert.Equal(t, http.StatusOK, w.Code)
+	current, _ := repo.FindByID(created.ID)
+	assert.Equal(t, "보고서 쓰기", current.Task)
+
+	assert.Equal(t, http.StatusNotFound, doAs(r, "", "POST", "/todos/1/revert?rev=9", "").Code)
+	assert.Equal(t, http.StatusBadRequest, doAs(r, "", "POST", "/todos/1/revert", "").Code)
+	assert.Equal(t, http.StatusNotFound, doAs(r, "", "GET", "/todos/99/history", "").Code)
+}
+
+func TestUndo_RevertsCallersLastMutations(t *testing.T) {
+	r, repo := newHistoryTestRouter(t)
+	doAs(r, "alice", "POST", "/todos", `{"task":"장보기"}`)
+	doAs(r, "alice", "PATCH", "/todos/1", "")
+	doAs(r, "bob", "POST", "/todos", `{"task":"bob의 할 일"}`)
+
+	// 1. alice의 마지막 변경(완료 처리)만 취소됨
+	w := doAs(r, "alice", "POST", "/undo", "")
+	assert.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	var result model.UndoResult
+	json.Unmarshal(w.Body.Bytes(), &model.WebResponse{Data: &result})
+	assert.Equal(t, model.AuditTodoUpdate, result.Undone)
+	todo, _ := repo.FindByID(1)
+	assert.False(t, todo.Done)
+
+	// 2. 한 번 더 부르면 그 이전 변경(생성)을 취소
+	w = doAs(r, "alice", "POST", "/undo", "")
+	assert.Equal(t, http.StatusOK, w.Code)
+	_, err := repo.FindByID(1)
+	assert.ErrorIs(t, err, gorm.ErrRecordNotFound)
+
+	// 3. 더 취소할 게 없음, bob의 할 일은 그대로
+	assert.Equal(t, http.StatusNotFound, doAs(r, "alice", "POST", "/undo", "").Code)
+	_, err = repo.FindByID(2)
+	assert.NoError(t, err)
+
+	// 4. 요청자 없이 부르면 거절
+	assert.Equal(t, http.StatusBadRequest, doAs(r, "", "POST", "/undo", "").Code)
+}
+
+func TestUndo_ConflictWhenChangedByOthers(t *testing.T) {
+	r, repo := newHistoryTestRouter(t)
+	doAs(r, "alice", "POST", "/todos", `{"task":"회의 준비"}`)
+	// bob이 그 뒤에 완료 처리
+	doAs(r, "bob", "PATCH", "/todos/1", "")
+
+	w := doAs(r, "alice", "POST", "/undo", "")
+	assert.Equal(t, http.StatusConflict, w.Code)
+	todo, _ := repo.FindByID(1)
+	assert.True(t, todo.Done)
+}
@@ -0,0 +1,104 @@
+package handler
+
+import (
+	"errors"
+	"go_study/model"
+	"go_study/repository"
+	"go_study/utils"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// QueueHandler: 백그라운드 작업 큐 조회 / 실패 작업 재시도 (관리자용)
+type QueueHandler struct {
+	repo repository.JobRepository
+}
+
+// 생성자
+func NewQueueHandler(repo repository.JobRepository) *QueueHandler {
+	return &QueueHandler{repo: repo}
+}
+
+// GetQueue godoc
+// @Summary      작업 큐 조회
+// @Description  상태별 작업 수와 조건에 맞는 작업 목록(최신순)을 조회합니다.
+// @Tags         Admin
+// @Produce      json
+// @Param        status  query  string  false  "queued | running | succeeded | failed"
+// @Param        type    query  string  false  "작업 종류 (예: report.daily)"
+// @Param        limit   query  int     false  "개수 (기본 100, 최대 1000)"
+// @Param        offset  query  int     false  "건너뛸 개수"
+// @Success      200  {object}  model.WebResponse{data=model.QueueOverview}
+// @Failure      400  {object}  model.WebResponse
+// @Router       /admin/queue [get]
+func (h *QueueHandler) GetQueue(c *gin.Context) {
+	filter := repository.JobFilter{Status: c.Query("status"), Type: c.Query("type")}
+	switch filter.Status {
+	case "", model.JobQueued, model.JobRunning, model.JobSucceeded, model.JobFailed:
+	default:
+		utils.SendError(c, http.StatusBadRequest, "status must be one of queued, running, succeeded, failed")
+		return
+	}
+	limit, errLimit := strconv.Atoi(c.DefaultQuery("limit", "100"))
+	offset, errOffset := strconv.Atoi(c.DefaultQuery("offset", "0"))
+	if err := errors.Join(errLimit, errOffset); err != nil || limit <= 0 || offset < 0 {
+		utils.SendError(c, http.StatusBadRequest, "limit and offset must be positive numbers")
+		return
+	}
+	limit = min(limit, maxAuditPageSize)
+
+	counts, err := h.repo.Counts()
+	if err != nil {
+		utils.SendError(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+	list, err := h.repo.List(filter, limit, offset)
+	if err != nil {
+		utils.SendError(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+	utils.SendSuccess(c, model.QueueOverview{Counts: counts, Jobs: list})
+}
+
+// GetJob godoc
+// @Summary      작업 상세 조회
+// @Tags         Admin
+// @Produce      json
+// @Param        id   path      int  true  "작업 ID"
+// @Success      200  {object}  model.WebResponse{data=model.Job}
+// @Failure      404  {object}  model.WebResponse
+// @Router       /admin/queue/{id} [get]
+func (h *QueueHandler) GetJob(c *gin.Context) {
+	job, err := h.repo.Find(parseID(c.Param("id")))
+	if err != nil {
+		sendNotFoundOr500(c, err)
+		return
+	}
+	utils.SendSuccess(c, job)
+}
+
+// RetryJob godoc
+// @Summary      실패한 작업 재시도
+// @Description  재시도 한도를 넘겨 포기한(failed) 작업을 시도 횟수 0부터 다시 실행합니다.
+// @Tags         Admin
+// @Produce      json
+// @Param        id   path      int  true  "작업 ID"
+// @Success      200  {object}  model.WebResponse{data=model.Job}
+// @Failure      404  {object}  model.WebResponse
+// @Failure      409  {object}  model.WebResponse  "실패 상태가 아님"
+// @Router       /admin/queue/{id}/retry [post]
+func (h *QueueHandler) RetryJob(c *gin.Context) {
+	job, err := h.repo.Retry(parseID(c.Param("id")), time.Now())
+	if errors.Is(err, repository.ErrJobNotFailed) {
+		utils.SendError(c, http.StatusConflict, "Only failed jobs can be retried (status: "+job.Status+")")
+		return
+	}
+	if err != nil {
+		sendNotFoundOr500(c, err)
+		return
+	}
+	utils.SendSuccessWithMessage(c, "작업을 다시 대기열에 넣었습니다.", job)
+}
@@ -0,0 +1,63 @@
+package handler
+
+import (
+	"encoding/json"
+	"fmt"
+	"go_study/model"
+	"go_study/repository"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/glebarez/sqlite"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+)
+
+func TestQueueHandler_ListAndRetry(t *testing.T) {
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	db.AutoMigrate(&model.Job{})
+	repo := repository.NewSQLiteJobRepository(db)
+	queued, _ := repo.Enqueue(model.Job{Type: model.JobDailyReport, MaxAttempts: 1})
+	failing, _ := repo.Enqueue(model.Job{Type: model.JobStatsReport, MaxAttempts: 1})
+	claimed, _ := repo.Claim(model.JobStatsReport, "test:1", time.Now(), time.Now(), time.Minute, 1)
+	repo.Fail(claimed[0], "boom", nil, time.Now())
+
+	h := NewQueueHandler(repo)
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	r.GET("/admin/queue", h.GetQueue)
+	r.POST("/admin/queue/:id/retry", h.RetryJob)
+	do := func(method, path string) *httptest.ResponseRecorder {
+		req, _ := http.NewRequest(method, path, nil)
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+		return w
+	}
+
+	// 1. 상태별 개수 + 실패한 작업만 조회
+	w := do("GET", "/admin/queue?status=failed")
+	assert.Equal(t, http.StatusOK, w.Code)
+	var overview model.QueueOverview
+	json.Unmarshal(w.Body.Bytes(), &model.WebResponse{Data: &overview})
+	assert.Equal(t, int64(1), overview.Counts[model.JobQueued])
+	assert.Equal(t, int64(1), overview.Counts[model.JobFailed])
+	assert.Len(t, overview.Jobs, 1)
+	assert.Equal(t, failing.ID, overview.Jobs[0].ID)
+	assert.Equal(t, "boom", overview.Jobs[0].LastError)
+
+	assert.Equal(t, http.StatusBadRequest, do("GET", "/admin/queue?status=lost").Code)
+
+	// 2. 실패한 작업만 다시 시도 가능
+	assert.Equal(t, http.StatusOK, do("POST", "/admin/queue/"+fmt.Sprint(failing.ID)+"/retry").Code)
+	assert.Equal(t, http.StatusConflict, do("POST", "/admin/queue/"+fmt.Sprint(queued.ID)+"/retry").Code)
+	assert.Equal(t, http.StatusNotFound, do("POST", "/admin/queue/999/retry").Code)
+
+	retried, _ := repo.Find(failing.ID)
+	assert.Equal(t, model.JobQueued, retried.Status)
+}
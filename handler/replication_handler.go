@@ -0,0 +1,119 @@
+package handler
+
+import (
+	"go_study/global"
+	"go_study/replication"
+	"go_study/repository"
+	"go_study/utils"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// 변경분 long-poll 설정
+const (
+	maxReplicationWait  = time.Minute
+	maxReplicationBatch = 5000
+	replicationPoll     = 200 * time.Millisecond // 기다리는 동안 새 변경이 있는지 확인하는 주기
+	promoteCatchUp      = 5 * time.Second        // 승격 전에 남은 변경분을 받는 최대 시간
+)
+
+// ReplicationHandler: Active는 변경분을 내보내고, 복제 모드의 Standby는 상태 조회/승격
+type ReplicationHandler struct {
+	repo    repository.ReplicationRepository
+	replica *replication.Replica // 복제 모드가 아니면 nil
+}
+
+// 생성자
+func NewReplicationHandler(repo repository.ReplicationRepository, replica *replication.Replica) *ReplicationHandler {
+	return &ReplicationHandler{repo: repo, replica: replica}
+}
+
+// GetChanges godoc
+// @Summary      변경분 스트림 (복제용, 서버 간 통신)
+// @Description  since 이후 할 일 변경분을 변경 순번 순서대로 돌려줍니다. 새 변경이 없으면 wait만큼 기다렸다가 응답합니다. (Active만)
+// @Tags         System
+// @Produce      json
+// @Param        X-Cluster-Token  header  string  true   "cluster.token (설정하지 않은 서버는 변경분을 내보내지 않음)"
+// @Param        since  query  int     true   "복제본이 반영한 마지막 변경 순번"
+// @Param        limit  query  int     false  "최대 할 일 개수 (기본 500, 최대 5000)"
+// @Param        wait   query  string  false  "새 변경이 없을 때 기다릴 시간 (예: 10s, 최대 1m)"
+// @Success      200  {object}  model.WebResponse{data=model.ChangeBatch}
+// @Failure      400  {object}  model.WebResponse
+// @Failure      401  {object}  model.WebResponse  "토큰이 없거나 틀림 (middleware.RequirePeerToken)"
+// @Failure      503  {object}  model.WebResponse  "Standby"
+// @Router       /replication/changes [get]
+func (h *ReplicationHandler) GetChanges(c *gin.Context) {
+	since, err := strconv.ParseInt(c.Query("since"), 10, 64)
+	if err != nil || since < 0 {
+		utils.SendError(c, http.StatusBadRequest, "since must be a non-negative number")
+		return
+	}
+	limit, err := strconv.Atoi(c.DefaultQuery("limit", "500"))
+	if err != nil || limit <= 0 {
+		utils.SendError(c, http.StatusBadRequest, "limit must be a positive number")
+		return
+	}
+	limit = min(limit, maxReplicationBatch)
+	wait, err := time.ParseDuration(c.DefaultQuery("wait", "0s"))
+	if err != nil || wait < 0 {
+		utils.SendError(c, http.StatusBadRequest, "wait must be a duration like 10s")
+		return
+	}
+	wait = min(wait, maxReplicationWait)
+
+	deadline := time.NewTimer(wait)
+	defer deadline.Stop()
+	ticker := time.NewTicker(replicationPoll)
+	defer ticker.Stop()
+	for {
+		// 기다리는 도중에 Standby가 되면 더 이상 원본이 아님
+		if !global.IsActive() {
+			utils.SendError(c, http.StatusServiceUnavailable, "Server is in STANDBY mode")
+			return
+		}
+		batch, err := h.repo.ChangesSince(since, limit)
+		if err != nil {
+			utils.SendError(c, http.StatusInternalServerError, err.Error())
+			return
+		}
+		if batch.Head != since {
+			utils.SendSuccess(c, batch)
+			return
+		}
+		select {
+		case <-ticker.C:
+		case <-deadline.C:
+			utils.SendSuccess(c, batch)
+			return
+		case <-c.Request.Context().Done():
+			return
+		}
+	}
+}
+
+// GetStatus godoc
+// @Summary      복제 상태
+// @Description  이 서버(복제 모드의 Standby)가 반영한 변경 순번, Active와의 차이, 지연 시간, 마지막 에러를 보여줍니다.
+// @Tags         Admin
+// @Produce      json
+// @Success      200  {object}  model.WebResponse{data=model.ReplicationStatus}
+// @Failure      404  {object}  model.WebResponse  "복제 모드가 아님"
+// @Router       /admin/replication [get]
+func (h *ReplicationHandler) GetStatus(c *gin.Context) {
+	if h.replica == nil {
+		utils.SendError(c, http.StatusNotFound, "Replication is not enabled on this node")
+		return
+	}
+	utils.SendSuccess(c, h.replica.Status())
+}
+
+// Promote: 복제 모드의 POST /admin/promote (API 문서는 TodoHandler.PromoteToActive에)
+// 복제를 멈추고, Active에 남은 변경분을 최대 5초 동안 마저 받은 뒤 Active가 됩니다. Active가 죽었으면 지금까지 받은 데이터로 승격합니다.
+// 다 따라잡지 못했으면 일부 할 일이 빠져 있을 수 있으니 응답의 lag_seq를 확인해야 합니다.
+func (h *ReplicationHandler) Promote(c *gin.Context) {
+	status := h.replica.Promote(c.Request.Context(), promoteCatchUp)
+	utils.SendSuccessWithMessage(c, "Server is now ACTIVE", status)
+}
@@ -0,0 +1,86 @@
+package handler
+
+import (
+	"context"
+	"fmt"
+	"go_study/global"
+	"go_study/model"
+	"go_study/replication"
+	"go_study/repository"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/glebarez/sqlite"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+)
+
+func newReplicationDB(t *testing.T) *gorm.DB {
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	db.AutoMigrate(&model.Todo{}, &model.Sequence{}, &model.TodoRevision{}, &model.OutboxEvent{})
+	return db
+}
+
+// Active(원본 DB + 변경분 엔드포인트)와 따로 DB를 쓰는 Standby 복제본
+func TestReplication_TailAndPromote(t *testing.T) {
+	defer global.SetStandby()
+	global.SetActive()
+
+	sourceDB, replicaDB := newReplicationDB(t), newReplicationDB(t)
+	source := repository.NewSQLiteRepository(sourceDB)
+	h := NewReplicationHandler(repository.NewSQLiteReplicationRepository(sourceDB), nil)
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	r.GET("/replication/changes", h.GetChanges)
+	srv := httptest.NewServer(r)
+	defer srv.Close()
+
+	replica := replication.NewReplica(repository.NewSQLiteReplicationRepository(replicaDB), replication.Options{
+		Source:    func() string { return srv.URL },
+		Token:     "secret",
+		BatchSize: 2,
+	})
+
+	for i := 0; i < 3; i++ {
+		source.Save(model.Todo{Task: fmt.Sprintf("task %d", i)})
+	}
+
+	// 1. 배치 크기(2)씩 순서대로 따라잡음
+	caughtUp, err := replica.SyncOnce(context.Background(), 0)
+	assert.NoError(t, err)
+	assert.False(t, caughtUp)
+	assert.Equal(t, int64(1), replica.Status().LagSeq)
+	caughtUp, _ = replica.SyncOnce(context.Background(), 0)
+	assert.True(t, caughtUp)
+	assert.Len(t, repository.NewSQLiteRepository(replicaDB).GetAll(), 3)
+
+	// 2. long-poll: 기다리는 동안 생긴 변경을 바로 받음
+	go func() {
+		time.Sleep(50 * time.Millisecond)
+		source.Update("1")
+	}()
+	start := time.Now()
+	caughtUp, err = replica.SyncOnce(context.Background(), 5*time.Second)
+	assert.NoError(t, err)
+	assert.True(t, caughtUp)
+	assert.Less(t, time.Since(start), 5*time.Second)
+	done, _ := repository.NewSQLiteRepository(replicaDB).FindByID(1)
+	assert.True(t, done.Done)
+
+	// 3. 승격: 남은 변경분을 받고 Active가 됨, 이후로는 적용하지 않음
+	source.Delete("2")
+	status := replica.Promote(context.Background(), time.Second)
+	assert.True(t, global.IsActive())
+	assert.False(t, status.Running)
+	assert.Equal(t, int64(5), status.AppliedSeq)
+	assert.Len(t, repository.NewSQLiteRepository(replicaDB).GetAll(), 2)
+	source.Delete("3")
+	_, err = replica.SyncOnce(context.Background(), 0)
+	assert.ErrorIs(t, err, context.Canceled)
+	assert.Len(t, repository.NewSQLiteRepository(replicaDB).GetAll(), 2)
+}
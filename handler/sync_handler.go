@@ -0,0 +1,211 @@
+package handler
+
+import (
+	"go_study/model"
+	"go_study/repository"
+	"go_study/utils"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+// 충돌 처리 방식
+const (
+	SyncStrategyLWW    = "lww"    // last-writer-wins: 클라이언트 수정 시각이 더 최근이면 덮어씀
+	SyncStrategyReport = "report" // 충돌난 변경은 적용하지 않고 서버 쪽 값을 돌려줌
+)
+
+// 변경 결과 상태
+const (
+	SyncCreated  = "created"
+	SyncUpdated  = "updated"
+	SyncDeleted  = "deleted"
+	SyncConflict = "conflict"
+	SyncNotFound = "not_found" // 서버에서 이미 삭제된 항목
+	SyncInvalid  = "invalid"
+)
+
+// SyncHandler: 오프라인 우선(Offline-first) 클라이언트용 델타 동기화
+type SyncHandler struct {
+	repo   repository.TodoRepository
+	audits repository.AuditRepository
+}
+
+// 생성자
+func NewSyncHandler(r repository.TodoRepository, audits repository.AuditRepository) *SyncHandler {
+	return &SyncHandler{repo: r, audits: audits}
+}
+
+// Pull godoc
+// @Summary      변경분 가져오기
+// @Description  since(이전 응답의 token) 이후 바뀐 할 일과 삭제된 할 일을 돌려줍니다. since가 없으면 전체 목록을 돌려줍니다.
+// @Tags         Sync
+// @Produce      json
+// @Param        since  query  string  false  "이전 응답의 token"
+// @Success      200  {object}  model.WebResponse{data=model.SyncPullResult}
+// @Failure      400  {object}  model.WebResponse  "잘못된 token"
+// @Failure      410  {object}  model.WebResponse  "서버가 모르는 token (DB 복구 등) - since 없이 전체 동기화 필요"
+// @Router       /sync [get]
+func (h *SyncHandler) Pull(c *gin.Context) {
+	// 1. 토큰을 먼저 정해두고 그 순번까지만 읽음 (읽는 도중 생긴 변경은 다음 동기화에서 받음)
+	current, err := h.repo.CurrentSeq()
+	if err != nil {
+		utils.SendError(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+	result := model.SyncPullResult{
+		Token:   strconv.FormatInt(current, 10),
+		Changed: []model.Todo{},
+		Deleted: []model.SyncTombstone{},
+	}
+
+	since := strings.TrimSpace(c.Query("since"))
+	if since == "" {
+		// 2-1. 전체 동기화
+		result.Full = true
+		err = h.repo.Stream(repository.TodoFilter{}, func(t model.Todo) error {
+			if t.Seq <= current {
+				result.Changed = append(result.Changed, t)
+			}
+			return nil
+		})
+		if err != nil {
+			utils.SendError(c, http.StatusInternalServerError, err.Error())
+			return
+		}
+		utils.SendSuccess(c, result)
+		return
+	}
+
+	sinceSeq, err := strconv.ParseInt(since, 10, 64)
+	if err != nil || sinceSeq < 0 {
+		utils.SendError(c, http.StatusBadRequest, "Invalid since token")
+		return
+	}
+	if sinceSeq > current {
+		utils.SendError(c, http.StatusGone, "Unknown since token, full sync required")
+		return
+	}
+
+	// 2-2. 델타 동기화
+	changes, err := h.repo.Changes(sinceSeq, current)
+	if err != nil {
+		utils.SendError(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+	for _, t := range changes {
+		if t.DeletedAt.Valid {
+			result.Deleted = append(result.Deleted, model.SyncTombstone{ID: t.ID, UID: t.UID, Seq: t.Seq, DeletedAt: t.DeletedAt.Time})
+		} else {
+			result.Changed = append(result.Changed, t)
+		}
+	}
+	utils.SendSuccess(c, result)
+}
+
+// Push godoc
+// @Summary      오프라인 변경 올리기
+// @Description  클라이언트가 쌓아둔 변경을 한 번에 적용합니다.
+// @Description  base_seq 이후 서버에서 바뀐 항목은 충돌로 보고, strategy=lww면 updated_at이 더 최근인 쪽을, report면 서버 값을 유지하고 충돌로 알려줍니다.
+// @Tags         Sync
+// @Accept       json
+// @Produce      json
+// @Param        input  body  model.SyncPushInput  true  "변경 목록"
+// @Success      200  {object}  model.WebResponse{data=model.SyncPushResult}
+// @Failure      400  {object}  model.WebResponse
+// @Router       /sync [post]
+func (h *SyncHandler) Push(c *gin.Context) {
+	var input model.SyncPushInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		utils.SendError(c, http.StatusBadRequest, err.Error())
+		return
+	}
+	if input.Strategy == "" {
+		input.Strategy = SyncStrategyLWW
+	}
+
+	results := make([]model.SyncChangeResult, 0, len(input.Changes))
+	for _, change := range input.Changes {
+		results = append(results, h.apply(c, change, input.Strategy))
+	}
+
+	current, err := h.repo.CurrentSeq()
+	if err != nil {
+		utils.SendError(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+	utils.SendSuccess(c, model.SyncPushResult{Token: strconv.FormatInt(current, 10), Results: results})
+}
+
+// 변경 하나 적용
+func (h *SyncHandler) apply(c *gin.Context, change model.SyncChange, strategy string) model.SyncChangeResult {
+	result := model.SyncChangeResult{ClientID: change.ClientID, ID: change.ID}
+	if !change.Deleted && strings.TrimSpace(change.Task) == "" {
+		result.Status, result.Message = SyncInvalid, "task is required"
+		return result
+	}
+
+	// 1. 새 항목
+	if change.ID == 0 {
+		if change.Deleted {
+			result.Status, result.Message = SyncInvalid, "cannot delete an item without id"
+			return result
+		}
+		created, err := h.repo.Save(model.Todo{Task: change.Task, Done: change.Done, Priority: change.Priority, DueAt: change.DueAt})
+		if err != nil {
+			result.Status, result.Message = SyncInvalid, err.Error()
+			return result
+		}
+		result.ID, result.Status, result.Todo = created.ID, SyncCreated, &created
+		recordTodoAudit(h.audits, c, model.AuditTodoCreate, created.ID, nil, &created)
+		return result
+	}
+
+	// 2. 기존 항목: 클라이언트가 본 뒤로 서버에서 바뀌었는지 확인
+	current, err := h.repo.FindByID(change.ID)
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			result.Status, result.Message = SyncNotFound, "deleted on server"
+		} else {
+			result.Status, result.Message = SyncInvalid, err.Error()
+		}
+		return result
+	}
+	if current.Seq > change.BaseSeq {
+		clientWins := strategy == SyncStrategyLWW && change.UpdatedAt.After(current.UpdatedAt)
+		if !clientWins {
+			result.Status, result.Todo = SyncConflict, &current
+			result.Message = "changed on server since base_seq"
+			return result
+		}
+	}
+
+	// 3. 적용
+	if change.Deleted {
+		if err := h.repo.Delete(strconv.FormatUint(uint64(change.ID), 10)); err != nil {
+			result.Status, result.Message = SyncInvalid, err.Error()
+			return result
+		}
+		result.Status = SyncDeleted
+		recordTodoAudit(h.audits, c, model.AuditTodoDelete, current.ID, &current, nil)
+		return result
+	}
+	updated, err := h.repo.Replace(model.Todo{
+		ID:       change.ID,
+		Task:     change.Task,
+		Done:     change.Done,
+		Priority: change.Priority,
+		DueAt:    change.DueAt,
+		UID:      current.UID,
+	})
+	if err != nil {
+		result.Status, result.Message = SyncInvalid, err.Error()
+		return result
+	}
+	result.Status, result.Todo = SyncUpdated, &updated
+	recordTodoAudit(h.audits, c, model.AuditTodoUpdate, updated.ID, &current, &updated)
+	return result
+}
@@ -0,0 +1,132 @@
+package handler
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"go_study/model"
+	"go_study/repository"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/glebarez/sqlite"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+)
+
+// 동기화는 순번 발급이 핵심이라 인메모리 DB를 쓰는 실제 저장소로 테스트합니다.
+func newSyncTestRouter(t *testing.T) (*gin.Engine, *repository.SQLiteRepository) {
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	db.AutoMigrate(&model.Todo{}, &model.Sequence{}, &model.AuditEvent{}, &model.TodoRevision{}, &model.OutboxEvent{})
+	repo := repository.NewSQLiteRepository(db)
+
+	h := NewSyncHandler(repo, repository.NewSQLiteAuditRepository(db))
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	r.GET("/sync", h.Pull)
+	r.POST("/sync", h.Push)
+	return r, repo
+}
+
+func pull(t *testing.T, r *gin.Engine, since string) (int, model.SyncPullResult) {
+	req, _ := http.NewRequest("GET", "/sync?since="+since, nil)
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+	var result model.SyncPullResult
+	json.Unmarshal(w.Body.Bytes(), &model.WebResponse{Data: &result})
+	return w.Code, result
+}
+
+func push(t *testing.T, r *gin.Engine, input model.SyncPushInput) model.SyncPushResult {
+	body, _ := json.Marshal(input)
+	req, _ := http.NewRequest("POST", "/sync", bytes.NewReader(body))
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	var result model.SyncPushResult
+	json.Unmarshal(w.Body.Bytes(), &model.WebResponse{Data: &result})
+	return result
+}
+
+func TestSync_PullFullAndDelta(t *testing.T) {
+	r, repo := newSyncTestRouter(t)
+	a, _ := repo.Save(model.Todo{Task: "A"})
+	b, _ := repo.Save(model.Todo{Task: "B"})
+
+	// 1. 전체 동기화
+	code, full := pull(t, r, "")
+	assert.Equal(t, http.StatusOK, code)
+	assert.True(t, full.Full)
+	assert.Len(t, full.Changed, 2)
+	assert.Equal(t, "2", full.Token)
+
+	// 2. 변경 후 델타: 수정 1건 + 삭제 1건
+	repo.Update(fmt.Sprint(a.ID))
+	repo.Delete(fmt.Sprint(b.ID))
+	code, delta := pull(t, r, full.Token)
+	assert.Equal(t, http.StatusOK, code)
+	assert.False(t, delta.Full)
+	assert.Len(t, delta.Changed, 1)
+	assert.True(t, delta.Changed[0].Done)
+	assert.Equal(t, []uint{b.ID}, []uint{delta.Deleted[0].ID})
+
+	// 3. 더 이상 변경이 없으면 빈 결과
+	_, empty := pull(t, r, delta.Token)
+	assert.Empty(t, empty.Changed)
+	assert.Empty(t, empty.Deleted)
+
+	// 4. 잘못된 / 미래의 토큰
+	code, _ = pull(t, r, "abc")
+	assert.Equal(t, http.StatusBadRequest, code)
+	code, _ = pull(t, r, "999")
+	assert.Equal(t, http.StatusGone, code)
+}
+
+func TestSync_PushConflicts(t *testing.T) {
+	r, repo := newSyncTestRouter(t)
+	a, _ := repo.Save(model.Todo{Task: "A"})
+	base := a.Seq
+	// 클라이언트가 본 뒤에 서버에서 수정됨
+	repo.Update(fmt.Sprint(a.ID))
+
+	old := time.Now().Add(-time.Hour)
+	newer := time.Now().Add(time.Hour)
+
+	// 1. report: 충돌은 적용하지 않고 서버 값을 돌려줌, 새 항목은 생성
+	result := push(t, r, model.SyncPushInput{Strategy: SyncStrategyReport, Changes: []model.SyncChange{
+		{ClientID: "c1", ID: a.ID, BaseSeq: base, Task: "A (client)", UpdatedAt: newer},
+		{ClientID: "c2", Task: "offline new"},
+		{ClientID: "c3", Task: ""},
+	}})
+	assert.Equal(t, SyncConflict, result.Results[0].Status)
+	assert.Equal(t, "A", result.Results[0].Todo.Task)
+	assert.Equal(t, SyncCreated, result.Results[1].Status)
+	assert.NotZero(t, result.Results[1].ID)
+	assert.Equal(t, SyncInvalid, result.Results[2].Status)
+
+	// 2. lww: 클라이언트가 더 오래됐으면 충돌, 더 최근이면 덮어씀
+	result = push(t, r, model.SyncPushInput{Strategy: SyncStrategyLWW, Changes: []model.SyncChange{
+		{ClientID: "c1", ID: a.ID, BaseSeq: base, Task: "A (stale)", UpdatedAt: old},
+	}})
+	assert.Equal(t, SyncConflict, result.Results[0].Status)
+	result = push(t, r, model.SyncPushInput{Changes: []model.SyncChange{
+		{ClientID: "c1", ID: a.ID, BaseSeq: base, Task: "A (client)", UpdatedAt: newer},
+	}})
+	assert.Equal(t, SyncUpdated, result.Results[0].Status)
+	assert.Equal(t, "A (client)", result.Results[0].Todo.Task)
+
+	// 3. 최신 base_seq로 삭제 -> 삭제, 이미 삭제된 항목 수정 -> not_found
+	latest := result.Results[0].Todo.Seq
+	result = push(t, r, model.SyncPushInput{Changes: []model.SyncChange{
+		{ClientID: "c1", ID: a.ID, BaseSeq: latest, Deleted: true},
+		{ClientID: "c1", ID: a.ID, BaseSeq: latest, Task: "again"},
+	}})
+	assert.Equal(t, SyncDeleted, result.Results[0].Status)
+	assert.Equal(t, SyncNotFound, result.Results[1].Status)
+}
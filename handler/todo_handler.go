@@ -1,47 +1,72 @@
 package handler
 
 import (
-	"fmt"
+	"bytes"
+	"context"
+	"errors"
 	"go_study/global"
+	"go_study/jobs"
 	"go_study/model"
 	"go_study/repository"
 	"go_study/utils"
-	"log"
+	"io"
 	"net/http"
+	"strconv"
 	"sync"
 	"time"
 
 	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
 	"gorm.io/gorm"
 )
 
-// [추가] 사용자가 입력할 데이터만 정의한 구조체 (DTO)
-type CreateTodoInput struct {
-	Task string `json:"task" binding:"required" example:"Swagger 문서 수정하기"`
-}
+// 할 일 수정 바디 최대 크기 (1MB)
+const maxTodoBodySize = 1 << 20
 
 // TodoHandler 구조체
 // 핵심: 구체적인 *SQLiteRepository가 아니라, 추상적인 인터페이스를 가집니다.
 type TodoHandler struct {
-	repo repository.TodoRepository // 인터페이스 타입!
+	repo   repository.TodoRepository // 인터페이스 타입!
+	audits repository.AuditRepository
+	queue  jobs.Enqueuer // 리포트 같은 무거운 작업은 작업 큐로
 }
 
 // 생성자: 외부에서 리포지토리를 주입(Injection) 받습니다.
-func NewTodoHandler(r repository.TodoRepository) *TodoHandler {
-	return &TodoHandler{repo: r}
+func NewTodoHandler(r repository.TodoRepository, audits repository.AuditRepository, queue jobs.Enqueuer) *TodoHandler {
+	return &TodoHandler{repo: r, audits: audits, queue: queue}
 }
 
 // GetTodos godoc
 // @Summary     할 일 목록 조회
-// @Description 저장된 모든 할 일 목록을 반환합니다.
+// @Description 저장된 할 일 목록을 반환합니다. (done / q로 거르기 가능)
 // @Tags        Todos
 // @Accept      json
 // @Produce     json
+// @Param       done  query  bool    false  "완료 여부 필터"
+// @Param       q     query  string  false  "task 검색어"
 // @Success     200 {object} model.WebResponse{data=[]model.Todo}
+// @Failure     400 {object} model.WebResponse "잘못된 필터"
 // @Router      /todos [get]
 func (h *TodoHandler) GetTodos(c *gin.Context) {
-	todos := h.repo.GetAll()
+	filter, err := parseTodoFilter(c)
+	if err != nil {
+		utils.SendError(c, http.StatusBadRequest, err.Error())
+		return
+	}
+	if filter.Done == nil && filter.Query == "" {
+		utils.SendSuccess(c, h.repo.GetAll())
+		return
+	}
 
+	// 👇 [추가] 필터가 있으면 내보내기와 같은 조건으로 거름
+	todos := []model.Todo{}
+	if err := h.repo.Stream(filter, func(t model.Todo) error {
+		todos = append(todos, t)
+		return nil
+	}); err != nil {
+		utils.SendError(c, http.StatusInternalServerError, err.Error())
+		return
+	}
 	utils.SendSuccess(c, todos)
 }
 
@@ -51,19 +76,22 @@ func (h *TodoHandler) GetTodos(c *gin.Context) {
 // @Tags        Todos
 // @Accept      json
 // @Produce     json
-// @Param       todo body CreateTodoInput true "할 일 정보"
+// @Param       todo body model.CreateTodoInput true "할 일 정보"
+// @Param       Idempotency-Key header string false "재시도 시 중복 생성을 막기 위한 키"
 // @Success     201 {object} model.Todo
 // @Failure     400 {object} model.WebResponse{data=nil}
 // @Router      /todos [post]
 func (h *TodoHandler) AddTodo(c *gin.Context) {
-	var input CreateTodoInput
+	var input model.CreateTodoInput
 	if err := c.ShouldBindJSON(&input); err != nil {
 		utils.SendError(c, http.StatusBadRequest, err.Error())
 		return
 	}
 	newTodo := model.Todo{
-		Task: input.Task,
-		Done: false, // 기본값
+		Task:     input.Task,
+		Done:     false, // 기본값
+		Priority: input.Priority,
+		DueAt:    input.DueAt,
 	}
 	createdTodo, err := h.repo.Save(newTodo)
 	if err != nil {
@@ -71,22 +99,44 @@ func (h *TodoHandler) AddTodo(c *gin.Context) {
 		return
 	}
 	utils.SendCreated(c, createdTodo)
+	recordTodoAudit(h.audits, c, model.AuditTodoCreate, createdTodo.ID, nil, &createdTodo)
 }
 
 // ToggleTodoStatus godoc
-// @Summary      할 일 완료 여부 토글
-// @Description  특정 ID의 할 일 완료 상태(Done)를 반전시킵니다.
+// @Summary      할 일 완료 여부 토글 / 수정
+// @Description  바디가 없으면 특정 ID의 할 일 완료 상태(Done)를 반전시킵니다.
+// @Description  JSON 바디가 있으면 보낸 필드(task, done, priority, due_at, clear_due)만 바꿉니다.
 // @Tags         Todos
 // @Accept       json
 // @Produce      json
-// @Param        id   path      int  true  "수정할 할 일 ID"
+// @Param        id   path      int              true   "수정할 할 일 ID"
+// @Param        todo body      model.UpdateTodoInput  false  "바꿀 필드 (없으면 완료 여부 토글)"
 // @Success      200  {object}  model.Todo
-// @Failure      400  {object}  model.WebResponse  "잘못된 ID 형식"
+// @Failure      400  {object}  model.WebResponse  "잘못된 ID 형식 / 바디"
 // @Failure      404  {object}  model.WebResponse  "ID를 찾을 수 없음"
+// @Failure      413  {object}  model.WebResponse  "바디가 1MB를 넘음"
 // @Router       /todos/{id} [patch]
 func (h *TodoHandler) ToggleTodoStatus(c *gin.Context) {
 	id := c.Param("id")
 
+	// 👇 [추가] 바디가 있으면 필드 수정 (기존 클라이언트는 바디 없이 보내므로 토글 그대로)
+	body, err := io.ReadAll(http.MaxBytesReader(c.Writer, c.Request.Body, maxTodoBodySize))
+	if err != nil {
+		var tooLarge *http.MaxBytesError
+		if errors.As(err, &tooLarge) {
+			utils.SendError(c, http.StatusRequestEntityTooLarge, "Request body is too large")
+			return
+		}
+		utils.SendError(c, http.StatusBadRequest, err.Error())
+		return
+	}
+	if len(bytes.TrimSpace(body)) > 0 {
+		h.editTodo(c, id, body)
+		return
+	}
+
+	// 감사 로그용 변경 전 스냅샷 (없으면 아래 Update가 404 처리)
+	before, _ := h.repo.FindByID(parseID(id))
 	updatedTodo, err := h.repo.Update(id)
 
 	if err != nil {
@@ -109,6 +159,54 @@ func (h *TodoHandler) ToggleTodoStatus(c *gin.Context) {
 	}
 
 	c.JSON(http.StatusOK, updatedTodo)
+	recordTodoAudit(h.audits, c, model.AuditTodoUpdate, updatedTodo.ID, &before, &updatedTodo)
+}
+
+// editTodo: 보낸 필드만 바꿔서 저장 (PATCH /todos/{id} + JSON 바디)
+func (h *TodoHandler) editTodo(c *gin.Context, id string, body []byte) {
+	var input model.UpdateTodoInput
+	if err := binding.JSON.BindBody(body, &input); err != nil {
+		utils.SendError(c, http.StatusBadRequest, err.Error())
+		return
+	}
+	before, err := h.repo.FindByID(parseID(id))
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			utils.SendError(c, http.StatusNotFound, "Data not found")
+			return
+		}
+		utils.SendError(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	after := before
+	if input.Task != nil {
+		after.Task = *input.Task
+	}
+	if input.Done != nil {
+		after.Done = *input.Done
+	}
+	if input.Priority != nil {
+		after.Priority = *input.Priority
+	}
+	if input.DueAt != nil {
+		after.DueAt = input.DueAt
+	}
+	if input.ClearDue {
+		after.DueAt = nil
+	}
+
+	updated, err := h.repo.Replace(after)
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			utils.SendError(c, http.StatusNotFound, "Data not found")
+			return
+		}
+		utils.SendError(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+	c.JSON(http.StatusOK, updated)
+	recordTodoAudit(h.audits, c, model.AuditTodoUpdate, updated.ID, &before, &updated)
 }
 
 // DeleteTodo godoc
@@ -125,6 +223,7 @@ func (h *TodoHandler) ToggleTodoStatus(c *gin.Context) {
 // @Router       /todos/{id} [delete]
 func (h *TodoHandler) DeleteTodo(c *gin.Context) {
 	id := c.Param("id")
+	before, _ := h.repo.FindByID(parseID(id))
 	if err := h.repo.Delete(id); err != nil {
 		// 에러 종류 확인: "데이터가 없어서 에러난 거야?"
 		if err == gorm.ErrRecordNotFound {
@@ -146,113 +245,164 @@ func (h *TodoHandler) DeleteTodo(c *gin.Context) {
 
 	// ✨ 데이터가 없을 때는 Data에 nil을 넣거나 생략
 	utils.SendSuccessWithMessage(c, "삭제 성공", nil) // Data가 없으면 nil
+	recordTodoAudit(h.audits, c, model.AuditTodoDelete, before.ID, &before, nil)
+}
+
+// RestoreTodo godoc
+// @Summary      삭제된 할 일 되살리기
+// @Description  Soft Delete된 할 일을 다시 목록에 보이게 합니다.
+// @Tags         Todos
+// @Produce      json
+// @Param        id   path      int  true  "되살릴 할 일 ID"
+// @Success      200  {object}  model.WebResponse{data=model.Todo}
+// @Failure      404  {object}  model.WebResponse  "삭제된 할 일이 아님"
+// @Router       /todos/{id}/restore [post]
+func (h *TodoHandler) RestoreTodo(c *gin.Context) {
+	restored, err := h.repo.Restore(c.Param("id"))
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			utils.SendError(c, http.StatusNotFound, "Deleted todo not found")
+			return
+		}
+		utils.SendError(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+	utils.SendSuccess(c, restored)
+	recordTodoAudit(h.audits, c, model.AuditTodoRestore, restored.ID, nil, &restored)
+}
+
+// 경로의 id를 숫자로 (잘못된 값이면 0 → 조회 결과 없음)
+func parseID(id string) uint {
+	n, _ := strconv.ParseUint(id, 10, 64)
+	return uint(n)
 }
 
 // [POST] /reports - 무거운 리포트 생성 작업 (비동기)
 // GenerateDailyReport godoc
 // @Summary      일일 리포트 생성 요청
-// @Description  리포트 생성을 비동기로 요청합니다. (처리 결과는 이메일 발송 등)
+// @Description  리포트 생성 작업을 작업 큐에 넣고 바로 응답합니다. (처리 결과는 이메일 발송 등, 진행 상황은 GET /admin/queue)
 // @Tags         Reports
 // @Accept       json
 // @Produce      json
-// @Success      202  {object}  model.WebResponse  "요청 접수됨"
+// @Param        Idempotency-Key header string false "재시도 시 중복 요청을 막기 위한 키"
+// @Success      202  {object}  model.WebResponse{data=model.Job}  "요청 접수됨"
 // @Router       /reports [post]
 func (h *TodoHandler) GenerateDailyReport(c *gin.Context) {
-	// 1. 즉시 응답 (Non-blocking)
+	// 작업 큐(DB)에 넣으면 서버가 재시작해도 사라지지 않음
+	job, err := h.queue.Enqueue(model.JobDailyReport, nil)
+	if err != nil {
+		utils.SendError(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	// 즉시 응답 (Non-blocking)
 	c.JSON(http.StatusAccepted, model.WebResponse{
 		Code:    http.StatusOK,
 		Message: "리포트 생성 요청이 접수되었습니다. (백그라운드 처리 중)",
-		Data:    nil,
+		Data:    job,
 	})
-
-	// 2. 백그라운드 작업 (Goroutine)
-	go func() {
-		log.Println("📝 [Background] 리포트 데이터 수집 시작...")
-
-		// ✨ 진짜 DB 조회: 미완료된 할 일 가져오기
-		pendingTodos, err := h.repo.GetPendingTodos()
-		if err != nil {
-			log.Printf("❌ 리포트 생성 실패: %v\n", err)
-			return
-		}
-
-		// 리포트 내용 작성 (파일로 저장하거나 이메일 보내는 척)
-		reportContent := fmt.Sprintf("=== Daily Report ===\n남은 할 일: %d건\n", len(pendingTodos))
-		for _, t := range pendingTodos {
-			reportContent += fmt.Sprintf("- [ ] %s\n", t.Task)
-		}
-
-		// 시간 조금 걸리는 척 (리포트 파일 생성 시뮬레이션)
-		time.Sleep(2 * time.Second)
-
-		log.Printf("✅ [Background] 리포트 생성 완료!\n%s", reportContent)
-		// 실제로는 여기서 smtp.SendMail() 등을 호출함
-	}()
 }
 
+// 대시보드 집계 쿼리 전체에 주는 시간 제한
+const dashboardTimeout = 3 * time.Second
+
 // [GET] /dashboard - 병렬 처리 예제
 // GetDashboard godoc
 // @Summary      대시보드 데이터 조회
-// @Description  사용자 정보와 할 일 통계를 병렬로 조회하여 반환합니다.
+// @Description  전체/완료/기한 초과 개수, 완료율, 최근 N일 생성·완료 추이, 평균 완료 소요 시간, 프로젝트/컨텍스트별 개수를 병렬로 집계합니다.
 // @Tags         Dashboard
 // @Accept       json
 // @Produce      json
-// @Success 	 200 {object} model.WebResponse{data=[]string}
+// @Param        days  query  int  false  "최근 며칠 (기본 7, 최대 90)"
+// @Success      200  {object}  model.WebResponse{data=model.DashboardData}
+// @Failure      400  {object}  model.WebResponse  "잘못된 days"
+// @Failure      504  {object}  model.WebResponse  "집계 시간 초과"
 // @Router       /dashboard [get]
 func (h *TodoHandler) GetDashboard(c *gin.Context) {
-	// 결과를 모을 채널 생성 (문자열이 지나다니는 파이프)
-	// 버퍼(2)를 주어서 송신자가 블로킹되지 않게 함
-	results := make(chan string, 2)
-
-	// WaitGroup 생성 (스레드 조인용 카운터)
-	var wg sync.WaitGroup
-
-	// "나 2개 기다릴 거야" 설정
-	wg.Add(2)
+	days, err := strconv.Atoi(c.DefaultQuery("days", "7"))
+	if err != nil || days < 1 || days > 90 {
+		utils.SendError(c, http.StatusBadRequest, "days must be between 1 and 90")
+		return
+	}
 
-	// --- [작업 1] 사용자 프로필 조회 ---
+	// 요청이 끊기거나 시간 제한을 넘으면 남은 쿼리도 함께 취소됨
+	ctx, cancel := context.WithTimeout(c.Request.Context(), dashboardTimeout)
+	defer cancel()
+
+	now := time.Now().UTC()
+	today := now.Truncate(24 * time.Hour)
+	since := today.AddDate(0, 0, -(days - 1))
+
+	// 결과는 작업마다 자기 변수에만 쓰므로 잠금이 필요 없음
+	var (
+		wg                 sync.WaitGroup
+		summary            model.TodoSummary
+		daily              []model.DailyCount
+		projects, contexts []model.TagCount
+		errs               [3]error
+	)
+	wg.Add(3)
+
+	// --- [작업 1] 전체 집계 ---
 	go func() {
 		defer wg.Done() // 함수 끝나면 무조건 카운트 -1
-
-		time.Sleep(1 * time.Second) // 1초 걸리는 척
-		log.Println("👤 프로필 조회 완료")
-		results <- "User Profile: Gyong97 (Level 99)" // 채널에 데이터 쏘기
+		summary, errs[0] = h.repo.Summary(ctx, now)
 	}()
 
-	// --- [작업 2] 통계 집계 ---
+	// --- [작업 2] 날짜별 추이 ---
 	go func() {
-		defer wg.Done() // 함수 끝나면 무조건 카운트 -1
-		// ✨ 진짜 DB 조회!
-		total, done, err := h.repo.GetStats()
-		if err != nil {
-			results <- fmt.Sprintf("Stats Error: %v", err)
-			return
-		}
-		// 통계 문자열 생성
-		statsMsg := fmt.Sprintf("Stats: Total %d / Done %d (Rate: %.0f%%)",
-			total, done, float64(done)/float64(total)*100)
-		results <- statsMsg
+		defer wg.Done()
+		daily, errs[1] = h.repo.DailyCounts(ctx, since)
 	}()
 
-	// --- [중요 패턴] 기다리기 & 채널 닫기 ---
-	// 메인 고루틴이 멈추면 안 되니까, "기다리는 역할"도 별도 고루틴에게 시킴
+	// --- [작업 3] 프로젝트 / 컨텍스트별 ---
 	go func() {
-		wg.Wait()      // 작업 2개가 다 끝날 때까지 대기
-		close(results) // 다 끝났으면 파이프 입구를 막음 (그래야 받는 쪽 반복문이 끝남)
+		defer wg.Done()
+		projects, contexts, errs[2] = h.repo.TagCounts(ctx)
 	}()
 
-	// --- [결과 수집] ---
-	var responseData []string
+	wg.Wait() // 세 작업이 다 끝날 때까지 대기
 
-	// 채널이 닫힐 때까지 데이터를 계속 꺼냄 (Range Loop)
-	for msg := range results {
-		responseData = append(responseData, msg)
+	if ctx.Err() == context.DeadlineExceeded {
+		utils.SendError(c, http.StatusGatewayTimeout, "Dashboard query timed out")
+		return
 	}
-	c.JSON(http.StatusOK, model.WebResponse{
-		Code:    http.StatusOK,
-		Message: "대시보드 데이터 조회 완료",
-		Data:    responseData,
-	})
+	for _, err := range errs {
+		if err != nil {
+			utils.SendError(c, http.StatusInternalServerError, err.Error())
+			return
+		}
+	}
+
+	data := model.DashboardData{
+		TodoSummary: summary,
+		Days:        days,
+		Daily:       fillDays(daily, since, days),
+		Projects:    projects,
+		Contexts:    contexts,
+	}
+	// 할 일이 0개면 0으로 나누지 않음 (NaN 방지)
+	if summary.Total > 0 {
+		data.CompletionRate = float64(summary.Done) / float64(summary.Total) * 100
+	}
+	utils.SendSuccessWithMessage(c, "대시보드 데이터 조회 완료", data)
+}
+
+// 집계 결과에 없는 날(아무 일도 없던 날)을 0으로 채워서 since부터 days일치를 만듦
+func fillDays(counts []model.DailyCount, since time.Time, days int) []model.DailyCount {
+	byDate := map[string]model.DailyCount{}
+	for _, d := range counts {
+		byDate[d.Date] = d
+	}
+	filled := make([]model.DailyCount, days)
+	for i := range filled {
+		date := since.AddDate(0, 0, i).Format(time.DateOnly)
+		filled[i] = model.DailyCount{Date: date}
+		if d, ok := byDate[date]; ok {
+			filled[i] = d
+		}
+	}
+	return filled
 }
 
 // HealthCheck godoc
@@ -292,6 +442,7 @@ func (h *TodoHandler) HealthCheck(c *gin.Context) {
 // PromoteToActive godoc
 // @Summary      서버 승격 (Standby -> Active)
 // @Description  관리자 명령으로 서버를 Active 상태로 전환합니다.
+// @Description  복제 모드(replication.enabled)면 복제를 멈추고 Active에 남은 변경분을 최대 5초 동안 받은 뒤 승격하고, data에 복제 상태(model.ReplicationStatus)를 돌려줍니다. 다 따라잡지 못했으면 lag_seq를 확인하세요.
 // @Tags         System
 // @Success      200  {object}  model.WebResponse
 // @Router       /admin/promote [post]
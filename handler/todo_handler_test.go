@@ -2,15 +2,20 @@ package handler
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
+	"go_study/jobs"
 	"go_study/model"
+	"go_study/repository"
 	"net/http"
 	"net/http/httptest"
 	"testing"
+	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
+	"gorm.io/gorm"
 )
 
 // 1. Mock 객체 정의
@@ -45,6 +50,11 @@ func (m *MockTodoRepository) Delete(id string) error {
 	return args.Error(0)
 }
 
+func (m *MockTodoRepository) Restore(id string) (model.Todo, error) {
+	args := m.Called(id)
+	return args.Get(0).(model.Todo), args.Error(1)
+}
+
 // [추가] 통계 조회 Mock
 func (m *MockTodoRepository) GetStats() (int64, int64, error) {
 	args := m.Called()
@@ -59,6 +69,131 @@ func (m *MockTodoRepository) GetPendingTodos() ([]model.Todo, error) {
 	return args.Get(0).([]model.Todo), args.Error(1)
 }
 
+// [추가] 대시보드 집계 Mock
+func (m *MockTodoRepository) Summary(ctx context.Context, now time.Time) (model.TodoSummary, error) {
+	args := m.Called(ctx, now)
+	return args.Get(0).(model.TodoSummary), args.Error(1)
+}
+
+func (m *MockTodoRepository) DailyCounts(ctx context.Context, since time.Time) ([]model.DailyCount, error) {
+	args := m.Called(ctx, since)
+	return args.Get(0).([]model.DailyCount), args.Error(1)
+}
+
+func (m *MockTodoRepository) TagCounts(ctx context.Context) ([]model.TagCount, []model.TagCount, error) {
+	args := m.Called(ctx)
+	return args.Get(0).([]model.TagCount), args.Get(1).([]model.TagCount), args.Error(2)
+}
+
+func (m *MockTodoRepository) Timings(ctx context.Context, since time.Time) ([]model.Todo, error) {
+	args := m.Called(ctx, since)
+	return args.Get(0).([]model.Todo), args.Error(1)
+}
+
+// [추가] 스트리밍 조회 Mock: 설정된 목록을 fn에 하나씩 넘겨줌
+func (m *MockTodoRepository) Stream(filter repository.TodoFilter, fn func(model.Todo) error) error {
+	args := m.Called(filter)
+	for _, t := range args.Get(0).([]model.Todo) {
+		if err := fn(t); err != nil {
+			return err
+		}
+	}
+	return args.Error(1)
+}
+
+// [추가] 일괄 저장 Mock
+func (m *MockTodoRepository) SaveAll(todos []model.Todo) ([]model.Todo, error) {
+	args := m.Called(todos)
+	return args.Get(0).([]model.Todo), args.Error(1)
+}
+
+// [추가] 단건 조회 / 덮어쓰기 Mock
+func (m *MockTodoRepository) FindByID(id uint) (model.Todo, error) {
+	args := m.Called(id)
+	return args.Get(0).(model.Todo), args.Error(1)
+}
+
+func (m *MockTodoRepository) FindByUID(uid string) (model.Todo, error) {
+	args := m.Called(uid)
+	return args.Get(0).(model.Todo), args.Error(1)
+}
+
+func (m *MockTodoRepository) Replace(t model.Todo) (model.Todo, error) {
+	args := m.Called(t)
+	return args.Get(0).(model.Todo), args.Error(1)
+}
+
+// [추가] 동기화용 Mock
+func (m *MockTodoRepository) CurrentSeq() (int64, error) {
+	args := m.Called()
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func (m *MockTodoRepository) Changes(since, until int64) ([]model.Todo, error) {
+	args := m.Called(since, until)
+	return args.Get(0).([]model.Todo), args.Error(1)
+}
+
+// [추가] 변경 이력 조회 / 되돌리기 Mock
+func (m *MockTodoRepository) Revisions(todoID uint) ([]model.TodoRevision, error) {
+	args := m.Called(todoID)
+	return args.Get(0).([]model.TodoRevision), args.Error(1)
+}
+
+func (m *MockTodoRepository) Revert(id uint, rev int) (model.Todo, error) {
+	args := m.Called(id, rev)
+	return args.Get(0).(model.Todo), args.Error(1)
+}
+
+// [추가] DB 접근자 Mock (Health Check용)
+func (m *MockTodoRepository) GetDB() *gorm.DB {
+	args := m.Called()
+	db, _ := args.Get(0).(*gorm.DB)
+	return db
+}
+
+// 감사 로그 저장소 Mock
+type MockAuditRepository struct {
+	mock.Mock
+}
+
+func (m *MockAuditRepository) Append(e model.AuditEvent) error {
+	args := m.Called(e)
+	return args.Error(0)
+}
+
+func (m *MockAuditRepository) Find(filter repository.AuditFilter, limit, offset int) ([]model.AuditEvent, error) {
+	args := m.Called(filter, limit, offset)
+	return args.Get(0).([]model.AuditEvent), args.Error(1)
+}
+
+func (m *MockAuditRepository) Stream(filter repository.AuditFilter, fn func(model.AuditEvent) error) error {
+	args := m.Called(filter)
+	for _, e := range args.Get(0).([]model.AuditEvent) {
+		if err := fn(e); err != nil {
+			return err
+		}
+	}
+	return args.Error(1)
+}
+
+// 감사 로그는 저장만 되면 되는 테스트용 (호출 여부는 따로 검사하지 않음)
+func newNopAuditRepository() *MockAuditRepository {
+	audits := new(MockAuditRepository)
+	audits.On("Append", mock.Anything).Return(nil).Maybe()
+	return audits
+}
+
+// 작업 큐 Mock (jobs.Enqueuer)
+type MockEnqueuer struct {
+	mock.Mock
+}
+
+func (m *MockEnqueuer) Enqueue(jobType string, payload interface{}, opts ...jobs.EnqueueOption) (model.Job, error) {
+	args := m.Called(jobType, payload)
+	return args.Get(0).(model.Job), args.Error(1)
+}
+
 // ----------------------------------------------------------------
 // 실제 테스트 함수
 // ----------------------------------------------------------------
@@ -76,8 +211,14 @@ func TestAddTodo_Success(t *testing.T) {
 	// "Save 함수에 inputTodo가 들어오면 -> expectedTodo와 nil(에러없음)을 리턴해라!"
 	mockRepo.On("Save", inputTodo).Return(expectedTodo, nil)
 
+	// 감사 로그에 "todo.create"가 남아야 함
+	mockAudit := new(MockAuditRepository)
+	mockAudit.On("Append", mock.MatchedBy(func(e model.AuditEvent) bool {
+		return e.Action == model.AuditTodoCreate && e.EntityID == "1" && e.Before == nil && e.After != nil
+	})).Return(nil)
+
 	// 핸들러에 가짜 저장소를 주입 (Dependency Injection)
-	h := NewTodoHandler(mockRepo)
+	h := NewTodoHandler(mockRepo, mockAudit, nil)
 
 	// 2. 실행 (Act)
 	gin.SetMode(gin.TestMode)
@@ -96,26 +237,82 @@ func TestAddTodo_Success(t *testing.T) {
 	assert.Equal(t, http.StatusCreated, w.Code)
 
 	// 응답 본문에 ID가 1로 박혀서 나왔는가?
+	// 응답은 WebResponse로 감싸져 있으므로 data 필드를 꺼내서 확인
 	var responseTodo model.Todo
-	json.Unmarshal(w.Body.Bytes(), &responseTodo)
+	json.Unmarshal(w.Body.Bytes(), &model.WebResponse{Data: &responseTodo})
 	assert.Equal(t, uint(1), responseTodo.ID)
 	assert.Equal(t, "Mock Test", responseTodo.Task)
 
 	// ⭐️ Mock 검증: "정말로 Save 함수가 호출되었는가?"
 	mockRepo.AssertExpectations(t)
+	mockAudit.AssertExpectations(t)
+}
+
+func TestToggleTodoStatus_EditFields(t *testing.T) {
+	due := time.Date(2025, 12, 31, 18, 0, 0, 0, time.UTC)
+	stored := model.Todo{ID: 1, Task: "old", Priority: 3, DueAt: &due}
+	mockRepo := new(MockTodoRepository)
+	mockRepo.On("FindByID", uint(1)).Return(stored, nil)
+	mockRepo.On("FindByID", uint(2)).Return(model.Todo{}, gorm.ErrRecordNotFound)
+	// 보낸 필드만 바뀌고 나머지는 그대로
+	mockRepo.On("Replace", model.Todo{ID: 1, Task: "new", Done: true, Priority: 3, DueAt: &due}).
+		Return(model.Todo{ID: 1, Task: "new", Done: true, Priority: 3, DueAt: &due}, nil).Once()
+	// clear_due면 마감 시각만 지움
+	mockRepo.On("Replace", model.Todo{ID: 1, Task: "old", Priority: 3}).
+		Return(model.Todo{ID: 1, Task: "old", Priority: 3}, nil).Once()
+
+	h := NewTodoHandler(mockRepo, newNopAuditRepository(), nil)
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	r.PATCH("/todos/:id", h.ToggleTodoStatus)
+	patch := func(id string, body []byte) *httptest.ResponseRecorder {
+		req, _ := http.NewRequest("PATCH", "/todos/"+id, bytes.NewReader(body))
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+		return w
+	}
+
+	// 1. 일부 필드 수정
+	w := patch("1", []byte(`{"task":"new","done":true}`))
+	assert.Equal(t, http.StatusOK, w.Code)
+	var got model.Todo
+	json.Unmarshal(w.Body.Bytes(), &got)
+	assert.Equal(t, "new", got.Task)
+	assert.True(t, got.Done)
+	assert.Equal(t, 3, got.Priority)
+
+	// 2. 마감 시각 지우기
+	w = patch("1", []byte(`{"clear_due":true}`))
+	assert.Equal(t, http.StatusOK, w.Code)
+	got = model.Todo{}
+	json.Unmarshal(w.Body.Bytes(), &got)
+	assert.Nil(t, got.DueAt)
+
+	// 3. 없는 할 일
+	assert.Equal(t, http.StatusNotFound, patch("2", []byte(`{"task":"new"}`)).Code)
+
+	// 4. 잘못된 바디 (JSON이 아님 / 검증 실패)
+	assert.Equal(t, http.StatusBadRequest, patch("1", []byte(`{"task":`)).Code)
+	assert.Equal(t, http.StatusBadRequest, patch("1", []byte(`{"priority":10}`)).Code)
+	assert.Equal(t, http.StatusBadRequest, patch("1", []byte(`{"task":""}`)).Code)
+
+	// 5. 너무 큰 바디는 읽다가 멈추고 413
+	huge := append([]byte(`{"task":"`), bytes.Repeat([]byte("a"), maxTodoBodySize)...)
+	huge = append(huge, `"}`...)
+	assert.Equal(t, http.StatusRequestEntityTooLarge, patch("1", huge).Code)
+
+	mockRepo.AssertExpectations(t)
+	mockRepo.AssertNotCalled(t, "Update", mock.Anything)
 }
 
 func TestGenerateDailyReport_Accepted(t *testing.T) {
 	// 1. Arrange
+	// 리포트는 요청 안에서 만들지 않고 작업 큐에 넣기만 함
 	mockRepo := new(MockTodoRepository)
-	// 비동기 고루틴 안에서 GetPendingTodos가 호출될 수도, 안 될 수도 있음 (Timing 이슈)
-	// 따라서 "호출된다면 빈 배열 리턴해라" 정도로 유연하게 설정하거나,
-	// 단순히 핸들러의 응답 코드만 체크할 거면 Mock 설정 없이도 202는 반환됨.
-
-	// 안전하게: 혹시 고루틴이 엄청 빨리 돌아서 호출할까봐 넣어둠
-	mockRepo.On("GetPendingTodos").Return([]model.Todo{}, nil).Maybe()
+	mockQueue := new(MockEnqueuer)
+	mockQueue.On("Enqueue", model.JobDailyReport, nil).Return(model.Job{ID: 7, Type: model.JobDailyReport, Status: model.JobQueued}, nil)
 
-	h := NewTodoHandler(mockRepo)
+	h := NewTodoHandler(mockRepo, newNopAuditRepository(), mockQueue)
 
 	// 2. Act
 	gin.SetMode(gin.TestMode)
@@ -127,6 +324,114 @@ func TestGenerateDailyReport_Accepted(t *testing.T) {
 	r.ServeHTTP(w, req)
 
 	// 3. Assert
-	// 즉시 응답이 202 Accepted 인가?
+	// 즉시 응답이 202 Accepted 이고 작업 ID를 돌려주는가?
 	assert.Equal(t, http.StatusAccepted, w.Code)
+	var job model.Job
+	json.Unmarshal(w.Body.Bytes(), &model.WebResponse{Data: &job})
+	assert.Equal(t, uint(7), job.ID)
+	mockQueue.AssertExpectations(t)
+	mockRepo.AssertNotCalled(t, "GetPendingTodos")
+}
+
+func TestImportTodos_ReportsErrorsAndDuplicates(t *testing.T) {
+	mockRepo := new(MockTodoRepository)
+	// 이미 "우유 사기"가 저장되어 있다고 가정
+	mockRepo.On("Stream", repository.TodoFilter{}).Return([]model.Todo{{ID: 1, Task: "우유 사기"}}, nil)
+	mockRepo.On("SaveAll", []model.Todo{{Task: "운동하기"}}).Return([]model.Todo{{ID: 2, Task: "운동하기"}}, nil)
+
+	h := NewTodoHandler(mockRepo, newNopAuditRepository(), nil)
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	r.POST("/todos/import", h.ImportTodos)
+
+	body := "task,done\n우유 사기,false\n운동하기,false\n,false\n운동하기,true\n"
+	req, _ := http.NewRequest("POST", "/todos/import?format=csv", bytes.NewBufferString(body))
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	var result model.ImportResult
+	json.Unmarshal(w.Body.Bytes(), &model.WebResponse{Data: &result})
+	assert.Equal(t, 4, result.Total)
+	assert.Equal(t, 1, result.Imported)
+	assert.Equal(t, []model.ImportLineError{{Line: 4, Message: "task is required"}}, result.Errors)
+	assert.Len(t, result.Duplicates, 2) // 기존 데이터와 중복 1건 + 파일 안에서 중복 1건
+	mockRepo.AssertExpectations(t)
+}
+
+func TestImportTodos_DryRunDoesNotSave(t *testing.T) {
+	mockRepo := new(MockTodoRepository)
+	mockRepo.On("Stream", repository.TodoFilter{}).Return([]model.Todo{}, nil)
+
+	h := NewTodoHandler(mockRepo, newNopAuditRepository(), nil)
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	r.POST("/todos/import", h.ImportTodos)
+
+	req, _ := http.NewRequest("POST", "/todos/import?format=todotxt&dry_run=true", bytes.NewBufferString("2025-01-01 책 읽기\n"))
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	var result model.ImportResult
+	json.Unmarshal(w.Body.Bytes(), &model.WebResponse{Data: &result})
+	assert.True(t, result.DryRun)
+	assert.Equal(t, 1, result.Imported)
+	mockRepo.AssertNotCalled(t, "SaveAll", mock.Anything)
+}
+
+func TestGetDashboard_AggregatesInParallel(t *testing.T) {
+	mockRepo := new(MockTodoRepository)
+	mockRepo.On("Summary", mock.Anything, mock.Anything).Return(model.TodoSummary{Total: 4, Done: 1, Overdue: 2}, nil)
+	today := time.Now().UTC().Format(time.DateOnly)
+	mockRepo.On("DailyCounts", mock.Anything, mock.Anything).Return([]model.DailyCount{{Date: today, Created: 3, Completed: 1}}, nil)
+	mockRepo.On("TagCounts", mock.Anything).Return([]model.TagCount{{Name: "+work", Total: 2}}, []model.TagCount{}, nil)
+
+	h := NewTodoHandler(mockRepo, newNopAuditRepository(), nil)
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	r.GET("/dashboard", h.GetDashboard)
+
+	req, _ := http.NewRequest("GET", "/dashboard?days=3", nil)
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	var data model.DashboardData
+	json.Unmarshal(w.Body.Bytes(), &model.WebResponse{Data: &data})
+	assert.Equal(t, int64(2), data.Overdue)
+	assert.Equal(t, 25.0, data.CompletionRate)
+	// 빈 날은 0으로 채워져서 항상 3일치, 마지막이 오늘
+	assert.Len(t, data.Daily, 3)
+	assert.Equal(t, model.DailyCount{Date: today, Created: 3, Completed: 1}, data.Daily[2])
+	assert.Equal(t, "+work", data.Projects[0].Name)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestGetDashboard_EmptyHasNoNaN(t *testing.T) {
+	mockRepo := new(MockTodoRepository)
+	mockRepo.On("Summary", mock.Anything, mock.Anything).Return(model.TodoSummary{}, nil)
+	mockRepo.On("DailyCounts", mock.Anything, mock.Anything).Return([]model.DailyCount{}, nil)
+	mockRepo.On("TagCounts", mock.Anything).Return([]model.TagCount{}, []model.TagCount{}, nil)
+
+	h := NewTodoHandler(mockRepo, newNopAuditRepository(), nil)
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	r.GET("/dashboard", h.GetDashboard)
+
+	req, _ := http.NewRequest("GET", "/dashboard", nil)
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+
+	// NaN이면 JSON 인코딩 자체가 실패하므로 200 + 0 이어야 함
+	assert.Equal(t, http.StatusOK, w.Code)
+	var data model.DashboardData
+	json.Unmarshal(w.Body.Bytes(), &model.WebResponse{Data: &data})
+	assert.Equal(t, 0.0, data.CompletionRate)
+	assert.Len(t, data.Daily, 7)
+
+	req, _ = http.NewRequest("GET", "/dashboard?days=0", nil)
+	w = httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
 }
@@ -0,0 +1,163 @@
+package handler
+
+import (
+	"errors"
+	"go_study/model"
+	"go_study/repository"
+	"go_study/todoio"
+	"go_study/utils"
+	"log"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// 가져오기 파일 최대 크기 (10MB)
+const maxImportSize = 10 << 20
+
+// 쿼리 파라미터(done, q)를 조회 조건으로 변환
+func parseTodoFilter(c *gin.Context) (repository.TodoFilter, error) {
+	filter := repository.TodoFilter{Query: strings.TrimSpace(c.Query("q"))}
+	if v := c.Query("done"); v != "" {
+		done, err := strconv.ParseBool(v)
+		if err != nil {
+			return filter, errors.New("done must be true or false")
+		}
+		filter.Done = &done
+	}
+	return filter, nil
+}
+
+// 중복 판단용 정규화 (앞뒤 공백, 대소문자 무시)
+func normalizeTask(task string) string {
+	return strings.ToLower(strings.Join(strings.Fields(task), " "))
+}
+
+// ExportTodos godoc
+// @Summary      할 일 내보내기
+// @Description  할 일 목록을 JSON / CSV / todo.txt 형식으로 내려받습니다. (스트리밍)
+// @Tags         Todos
+// @Produce      json
+// @Produce      plain
+// @Param        format  query  string  false  "json | csv | todotxt (기본 json)"
+// @Param        done    query  bool    false  "완료 여부 필터"
+// @Param        q       query  string  false  "task 검색어"
+// @Success      200  {file}    file
+// @Failure      400  {object}  model.WebResponse  "잘못된 형식/필터"
+// @Router       /todos/export [get]
+func (h *TodoHandler) ExportTodos(c *gin.Context) {
+	format, err := todoio.ParseFormat(c.Query("format"))
+	if err != nil {
+		utils.SendError(c, http.StatusBadRequest, err.Error())
+		return
+	}
+	filter, err := parseTodoFilter(c)
+	if err != nil {
+		utils.SendError(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	c.Header("Content-Type", format.ContentType())
+	c.Header("Content-Disposition", `attachment; filename="`+format.FileName()+`"`)
+	c.Status(http.StatusOK)
+
+	// 한 건씩 바로 응답에 씀 (헤더가 이미 나갔으므로 중간 에러는 로그로만 남김)
+	enc := todoio.NewEncoder(format, c.Writer)
+	if err := h.repo.Stream(filter, enc.Encode); err != nil {
+		log.Printf("❌ 내보내기 실패: %v\n", err)
+		return
+	}
+	if err := enc.Close(); err != nil {
+		log.Printf("❌ 내보내기 마무리 실패: %v\n", err)
+	}
+}
+
+// ImportTodos godoc
+// @Summary      할 일 가져오기
+// @Description  JSON 배열 / CSV(task, done, created_at 헤더) / todo.txt 파일을 읽어서 할 일을 한 번에 추가합니다.
+// @Description  검증에 실패한 줄과 중복(기존 데이터 또는 파일 내부)은 건너뛰고 결과 리포트에 줄 번호와 함께 담깁니다.
+// @Tags         Todos
+// @Accept       json
+// @Accept       plain
+// @Produce      json
+// @Param        format   query  string  false  "json | csv | todotxt (기본 json)"
+// @Param        dry_run  query  bool    false  "true면 저장하지 않고 결과만 미리보기"
+// @Success      200  {object}  model.WebResponse{data=model.ImportResult}
+// @Failure      400  {object}  model.WebResponse  "파일을 읽을 수 없음"
+// @Failure      500  {object}  model.WebResponse  "서버 내부 에러"
+// @Router       /todos/import [post]
+func (h *TodoHandler) ImportTodos(c *gin.Context) {
+	format, err := todoio.ParseFormat(c.Query("format"))
+	if err != nil {
+		utils.SendError(c, http.StatusBadRequest, err.Error())
+		return
+	}
+	dryRun := false
+	if v := c.Query("dry_run"); v != "" {
+		if dryRun, err = strconv.ParseBool(v); err != nil {
+			utils.SendError(c, http.StatusBadRequest, "dry_run must be true or false")
+			return
+		}
+	}
+
+	// 1. 파일 파싱 (줄 단위 검증 포함)
+	body := http.MaxBytesReader(c.Writer, c.Request.Body, maxImportSize)
+	records, err := todoio.Decode(format, body)
+	if err != nil {
+		utils.SendError(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	// 2. 기존 데이터의 task 목록 (중복 검사용)
+	seen := map[string]bool{}
+	err = h.repo.Stream(repository.TodoFilter{}, func(t model.Todo) error {
+		seen[normalizeTask(t.Task)] = true
+		return nil
+	})
+	if err != nil {
+		utils.SendError(c, http.StatusInternalServerError, "Fail to load existing todos")
+		return
+	}
+
+	// 3. 검증 실패 / 중복 분류
+	result := model.ImportResult{
+		DryRun:     dryRun,
+		Total:      len(records),
+		Duplicates: []model.ImportLineError{},
+		Errors:     []model.ImportLineError{},
+	}
+	var toSave []model.Todo
+	for _, rec := range records {
+		if rec.Err != nil {
+			result.Errors = append(result.Errors, model.ImportLineError{Line: rec.Line, Message: rec.Err.Error()})
+			continue
+		}
+		key := normalizeTask(rec.Todo.Task)
+		if seen[key] {
+			result.Duplicates = append(result.Duplicates, model.ImportLineError{Line: rec.Line, Message: "duplicate task: " + rec.Todo.Task})
+			continue
+		}
+		seen[key] = true
+		toSave = append(toSave, rec.Todo)
+	}
+
+	// 4. 저장 (dry-run이면 건너뜀)
+	if !dryRun {
+		if toSave, err = h.repo.SaveAll(toSave); err != nil {
+			utils.SendError(c, http.StatusInternalServerError, "Fail to Import")
+			return
+		}
+		for i := range toSave {
+			recordTodoAudit(h.audits, c, model.AuditTodoCreate, toSave[i].ID, nil, &toSave[i])
+		}
+	}
+	result.Imported = len(toSave)
+	result.Todos = toSave
+	if result.Todos == nil {
+		result.Todos = []model.Todo{}
+	}
+
+	utils.SendSuccessWithMessage(c, "가져오기 완료", result)
+}
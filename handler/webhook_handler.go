@@ -0,0 +1,279 @@
+package handler
+
+import (
+	"fmt"
+	"go_study/model"
+	"go_study/repository"
+	"go_study/utils"
+	"net/http"
+	"net/url"
+	"slices"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+// WebhookHandler: 웹훅 구독 관리와 전송 기록 조회
+type WebhookHandler struct {
+	repo repository.WebhookRepository
+}
+
+// 생성자
+func NewWebhookHandler(repo repository.WebhookRepository) *WebhookHandler {
+	return &WebhookHandler{repo: repo}
+}
+
+// CreateWebhook godoc
+// @Summary      웹훅 구독 추가
+// @Description  할 일이 바뀌면 url로 POST 합니다. 본문은 X-Signature 헤더(sha256=HMAC-SHA256(secret, body))로 서명됩니다.
+// @Tags         Webhooks
+// @Accept       json
+// @Produce      json
+// @Param        input  body  model.CreateWebhookInput  true  "구독 정보"
+// @Success      201  {object}  model.WebResponse{data=model.WebhookWithSecret}
+// @Failure      400  {object}  model.WebResponse
+// @Router       /webhooks [post]
+func (h *WebhookHandler) CreateWebhook(c *gin.Context) {
+	var input model.CreateWebhookInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		utils.SendError(c, http.StatusBadRequest, err.Error())
+		return
+	}
+	hook := model.Webhook{URL: input.URL, Events: input.Events, Secret: input.Secret, Active: true}
+	if input.Active != nil {
+		hook.Active = *input.Active
+	}
+	if hook.Secret == "" {
+		secret, err := utils.RandomToken(32)
+		if err != nil {
+			utils.SendError(c, http.StatusInternalServerError, "Fail to generate secret")
+			return
+		}
+		hook.Secret = secret
+	}
+	if err := validateWebhook(hook); err != nil {
+		utils.SendError(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	created, err := h.repo.Create(hook)
+	if err != nil {
+		utils.SendError(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+	utils.SendCreated(c, model.WebhookWithSecret{Webhook: created, Secret: created.Secret})
+}
+
+// ListWebhooks godoc
+// @Summary      웹훅 구독 목록
+// @Tags         Webhooks
+// @Produce      json
+// @Success      200  {object}  model.WebResponse{data=[]model.Webhook}
+// @Router       /webhooks [get]
+func (h *WebhookHandler) ListWebhooks(c *gin.Context) {
+	hooks, err := h.repo.List()
+	if err != nil {
+		utils.SendError(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+	utils.SendSuccess(c, hooks)
+}
+
+// GetWebhook godoc
+// @Summary      웹훅 구독 조회
+// @Tags         Webhooks
+// @Produce      json
+// @Param        id   path      int  true  "웹훅 ID"
+// @Success      200  {object}  model.WebResponse{data=model.Webhook}
+// @Failure      404  {object}  model.WebResponse
+// @Router       /webhooks/{id} [get]
+func (h *WebhookHandler) GetWebhook(c *gin.Context) {
+	hook, ok := h.findWebhook(c)
+	if !ok {
+		return
+	}
+	utils.SendSuccess(c, hook)
+}
+
+// UpdateWebhook godoc
+// @Summary      웹훅 구독 수정
+// @Description  보낸 필드만 바꿉니다. active=false면 전송을 멈추고 대기 중인 전송은 failed가 됩니다.
+// @Tags         Webhooks
+// @Accept       json
+// @Produce      json
+// @Param        id     path  int                 true  "웹훅 ID"
+// @Param        input  body  model.UpdateWebhookInput  true  "바꿀 값"
+// @Success      200  {object}  model.WebResponse{data=model.Webhook}
+// @Failure      400  {object}  model.WebResponse
+// @Failure      404  {object}  model.WebResponse
+// @Router       /webhooks/{id} [patch]
+func (h *WebhookHandler) UpdateWebhook(c *gin.Context) {
+	var input model.UpdateWebhookInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		utils.SendError(c, http.StatusBadRequest, err.Error())
+		return
+	}
+	hook, ok := h.findWebhook(c)
+	if !ok {
+		return
+	}
+	if input.URL != nil {
+		hook.URL = *input.URL
+	}
+	if input.Events != nil {
+		hook.Events = input.Events
+	}
+	if input.Secret != nil {
+		hook.Secret = *input.Secret
+	}
+	if input.Active != nil {
+		hook.Active = *input.Active
+	}
+	if err := validateWebhook(hook); err != nil {
+		utils.SendError(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	updated, err := h.repo.Update(hook)
+	if err != nil {
+		utils.SendError(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+	utils.SendSuccess(c, updated)
+}
+
+// DeleteWebhook godoc
+// @Summary      웹훅 구독 삭제
+// @Description  구독과 전송 기록을 함께 삭제합니다.
+// @Tags         Webhooks
+// @Param        id   path      int  true  "웹훅 ID"
+// @Success      200  {object}  model.WebResponse
+// @Failure      404  {object}  model.WebResponse
+// @Router       /webhooks/{id} [delete]
+func (h *WebhookHandler) DeleteWebhook(c *gin.Context) {
+	if err := h.repo.Delete(parseID(c.Param("id"))); err != nil {
+		if err == gorm.ErrRecordNotFound {
+			utils.SendError(c, http.StatusNotFound, "Data not found")
+			return
+		}
+		utils.SendError(c, http.StatusInternalServerError, "Fail to Delete")
+		return
+	}
+	utils.SendSuccessWithMessage(c, "삭제 성공", nil)
+}
+
+// ListDeliveries godoc
+// @Summary      웹훅 전송 기록
+// @Description  구독자 하나에게 보냈거나 보낼 전송 목록을 최신순으로 돌려줍니다.
+// @Tags         Webhooks
+// @Produce      json
+// @Param        id      path   int  true   "웹훅 ID"
+// @Param        limit   query  int  false  "개수 (기본 50, 최대 500)"
+// @Param        offset  query  int  false  "건너뛸 개수"
+// @Success      200  {object}  model.WebResponse{data=[]model.WebhookDelivery}
+// @Failure      404  {object}  model.WebResponse
+// @Router       /webhooks/{id}/deliveries [get]
+func (h *WebhookHandler) ListDeliveries(c *gin.Context) {
+	hook, ok := h.findWebhook(c)
+	if !ok {
+		return
+	}
+	limit, errLimit := strconv.Atoi(c.DefaultQuery("limit", "50"))
+	offset, errOffset := strconv.Atoi(c.DefaultQuery("offset", "0"))
+	if errLimit != nil || errOffset != nil || limit <= 0 || offset < 0 {
+		utils.SendError(c, http.StatusBadRequest, "limit and offset must be positive numbers")
+		return
+	}
+	deliveries, err := h.repo.Deliveries(hook.ID, min(limit, 500), offset)
+	if err != nil {
+		utils.SendError(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+	utils.SendSuccess(c, deliveries)
+}
+
+// GetDelivery godoc
+// @Summary      웹훅 전송 상세
+// @Description  전송 한 건과 모든 시도 기록(응답 코드, 에러, 소요 시간)을 돌려줍니다.
+// @Tags         Webhooks
+// @Produce      json
+// @Param        id        path  int  true  "웹훅 ID"
+// @Param        delivery  path  int  true  "전송 ID"
+// @Success      200  {object}  model.WebResponse{data=model.DeliveryDetail}
+// @Failure      404  {object}  model.WebResponse
+// @Router       /webhooks/{id}/deliveries/{delivery} [get]
+func (h *WebhookHandler) GetDelivery(c *gin.Context) {
+	d, err := h.repo.FindDelivery(parseID(c.Param("id")), parseID(c.Param("delivery")))
+	if err != nil {
+		sendNotFoundOr500(c, err)
+		return
+	}
+	attempts, err := h.repo.Attempts(d.ID)
+	if err != nil {
+		utils.SendError(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+	utils.SendSuccess(c, model.DeliveryDetail{WebhookDelivery: d, Attempts: attempts})
+}
+
+// Redeliver godoc
+// @Summary      웹훅 재전송
+// @Description  전송을 대기열에 다시 넣습니다. (재시도 횟수는 0부터 다시 셈)
+// @Tags         Webhooks
+// @Produce      json
+// @Param        id        path  int  true  "웹훅 ID"
+// @Param        delivery  path  int  true  "전송 ID"
+// @Success      202  {object}  model.WebResponse{data=model.WebhookDelivery}
+// @Failure      404  {object}  model.WebResponse
+// @Router       /webhooks/{id}/deliveries/{delivery}/redeliver [post]
+func (h *WebhookHandler) Redeliver(c *gin.Context) {
+	d, err := h.repo.Redeliver(parseID(c.Param("id")), parseID(c.Param("delivery")))
+	if err != nil {
+		sendNotFoundOr500(c, err)
+		return
+	}
+	c.JSON(http.StatusAccepted, model.WebResponse{
+		Code:    http.StatusAccepted,
+		Message: "재전송 대기열에 넣었습니다.",
+		Data:    d,
+	})
+}
+
+// 경로의 id로 구독 조회 (없으면 404 응답 후 false)
+func (h *WebhookHandler) findWebhook(c *gin.Context) (model.Webhook, bool) {
+	hook, err := h.repo.Find(parseID(c.Param("id")))
+	if err != nil {
+		sendNotFoundOr500(c, err)
+		return hook, false
+	}
+	return hook, true
+}
+
+func sendNotFoundOr500(c *gin.Context, err error) {
+	if err == gorm.ErrRecordNotFound {
+		utils.SendError(c, http.StatusNotFound, "Data not found")
+		return
+	}
+	utils.SendError(c, http.StatusInternalServerError, err.Error())
+}
+
+// URL은 http(s) 절대 주소, 이벤트는 아는 종류 또는 "*"
+func validateWebhook(hook model.Webhook) error {
+	u, err := url.Parse(hook.URL)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return fmt.Errorf("url must be an absolute http(s) URL")
+	}
+	if len(hook.Events) == 0 {
+		return fmt.Errorf("events must not be empty")
+	}
+	for _, e := range hook.Events {
+		if e != "*" && !slices.Contains(model.EventTypes, e) {
+			return fmt.Errorf("unknown event %q (allowed: * %v)", e, model.EventTypes)
+		}
+	}
+	if hook.Secret == "" {
+		return fmt.Errorf("secret must not be empty")
+	}
+	return nil
+}
@@ -0,0 +1,134 @@
+package handler
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"go_study/model"
+	"go_study/repository"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/glebarez/sqlite"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+func newWebhookRouter(t *testing.T) (*gin.Engine, *repository.SQLiteWebhookRepository) {
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	db.AutoMigrate(&model.Webhook{}, &model.WebhookDelivery{}, &model.WebhookAttempt{})
+	repo := repository.NewSQLiteWebhookRepository(db)
+
+	h := NewWebhookHandler(repo)
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	r.GET("/webhooks", h.ListWebhooks)
+	r.POST("/webhooks", h.CreateWebhook)
+	r.GET("/webhooks/:id", h.GetWebhook)
+	r.PATCH("/webhooks/:id", h.UpdateWebhook)
+	r.DELETE("/webhooks/:id", h.DeleteWebhook)
+	r.GET("/webhooks/:id/deliveries", h.ListDeliveries)
+	r.GET("/webhooks/:id/deliveries/:delivery", h.GetDelivery)
+	r.POST("/webhooks/:id/deliveries/:delivery/redeliver", h.Redeliver)
+	return r, repo
+}
+
+func doWebhookRequest(r *gin.Engine, method, path, body string) *httptest.ResponseRecorder {
+	req, _ := http.NewRequest(method, path, bytes.NewBufferString(body))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+	return w
+}
+
+func TestWebhookHandler_CRUD(t *testing.T) {
+	r, _ := newWebhookRouter(t)
+
+	// 1. 생성: 서명 키를 안 보내면 서버가 만들고, 생성 응답에서만 보여줌
+	w := doWebhookRequest(r, "POST", "/webhooks", `{"url":"https://hooks.example.com/todos","events":["todo.created"]}`)
+	require.Equal(t, http.StatusCreated, w.Code)
+	var created model.WebhookWithSecret
+	json.Unmarshal(w.Body.Bytes(), &model.WebResponse{Data: &created})
+	assert.NotZero(t, created.ID)
+	assert.True(t, created.Active)
+	assert.NotEmpty(t, created.Secret)
+	path := fmt.Sprintf("/webhooks/%d", created.ID)
+
+	// 2. 조회/목록에는 서명 키가 없음
+	w = doWebhookRequest(r, "GET", path, "")
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.NotContains(t, w.Body.String(), created.Secret)
+	var list []model.Webhook
+	w = doWebhookRequest(r, "GET", "/webhooks", "")
+	json.Unmarshal(w.Body.Bytes(), &model.WebResponse{Data: &list})
+	assert.Len(t, list, 1)
+
+	// 3. 수정: 보낸 필드만 바뀜
+	w = doWebhookRequest(r, "PATCH", path, `{"events":["*"],"active":false}`)
+	require.Equal(t, http.StatusOK, w.Code)
+	var updated model.Webhook
+	json.Unmarshal(w.Body.Bytes(), &model.WebResponse{Data: &updated})
+	assert.Equal(t, []string{"*"}, updated.Events)
+	assert.False(t, updated.Active)
+	assert.Equal(t, "https://hooks.example.com/todos", updated.URL)
+
+	// 4. 삭제 후에는 404
+	assert.Equal(t, http.StatusOK, doWebhookRequest(r, "DELETE", path, "").Code)
+	assert.Equal(t, http.StatusNotFound, doWebhookRequest(r, "GET", path, "").Code)
+	assert.Equal(t, http.StatusNotFound, doWebhookRequest(r, "DELETE", path, "").Code)
+	assert.Equal(t, http.StatusNotFound, doWebhookRequest(r, "PATCH", path, `{"active":true}`).Code)
+}
+
+func TestWebhookHandler_RejectsInvalidInput(t *testing.T) {
+	r, repo := newWebhookRouter(t)
+
+	for _, body := range []string{
+		`{"url":"https://hooks.example.com","events":[]}`,             // 이벤트 없음
+		`{"url":"ftp://hooks.example.com","events":["todo.created"]}`, // http(s)가 아님
+		`{"url":"/relative","events":["todo.created"]}`,               // 절대 주소가 아님
+		`{"url":"https://hooks.example.com","events":["todo.eaten"]}`, // 모르는 이벤트
+		`{"events":["todo.created"]}`,                                 // url 없음
+	} {
+		assert.Equal(t, http.StatusBadRequest, doWebhookRequest(r, "POST", "/webhooks", body).Code, body)
+	}
+
+	hook, _ := repo.Create(model.Webhook{URL: "https://hooks.example.com", Events: []string{"*"}, Secret: "s", Active: true})
+	path := fmt.Sprintf("/webhooks/%d", hook.ID)
+	assert.Equal(t, http.StatusBadRequest, doWebhookRequest(r, "PATCH", path, `{"secret":""}`).Code)
+	assert.Equal(t, http.StatusBadRequest, doWebhookRequest(r, "PATCH", path, `{"url":"nope"}`).Code)
+	assert.Equal(t, http.StatusBadRequest, doWebhookRequest(r, "GET", path+"/deliveries?limit=0", "").Code)
+}
+
+func TestWebhookHandler_DeliveriesAndRedeliver(t *testing.T) {
+	r, repo := newWebhookRouter(t)
+	hook, _ := repo.Create(model.Webhook{URL: "https://hooks.example.com", Events: []string{"*"}, Secret: "s", Active: true})
+	repo.Enqueue(model.EventTodoCreated, []byte(`{"id":1}`))
+
+	// 1. 전송 목록
+	var deliveries []model.WebhookDelivery
+	w := doWebhookRequest(r, "GET", fmt.Sprintf("/webhooks/%d/deliveries", hook.ID), "")
+	require.Equal(t, http.StatusOK, w.Code)
+	json.Unmarshal(w.Body.Bytes(), &model.WebResponse{Data: &deliveries})
+	require.Len(t, deliveries, 1)
+	assert.Equal(t, model.EventTodoCreated, deliveries[0].Event)
+
+	// 2. 상세 (시도 기록 포함)
+	path := fmt.Sprintf("/webhooks/%d/deliveries/%d", hook.ID, deliveries[0].ID)
+	var detail model.DeliveryDetail
+	w = doWebhookRequest(r, "GET", path, "")
+	require.Equal(t, http.StatusOK, w.Code)
+	json.Unmarshal(w.Body.Bytes(), &model.WebResponse{Data: &detail})
+	assert.Equal(t, deliveries[0].ID, detail.ID)
+	assert.Empty(t, detail.Attempts)
+
+	// 3. 재전송은 202, 다른 구독자의 전송 ID로는 404
+	assert.Equal(t, http.StatusAccepted, doWebhookRequest(r, "POST", path+"/redeliver", "").Code)
+	assert.Equal(t, http.StatusNotFound, doWebhookRequest(r, "GET", fmt.Sprintf("/webhooks/%d/deliveries/%d", hook.ID+1, deliveries[0].ID), "").Code)
+	assert.Equal(t, http.StatusNotFound, doWebhookRequest(r, "POST", fmt.Sprintf("/webhooks/%d/deliveries/999/redeliver", hook.ID), "").Code)
+}
@@ -0,0 +1,61 @@
+package healthcheck
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"go_study/global"
+
+	"gorm.io/gorm"
+)
+
+// Role: Active 서버만 트래픽을 받을 준비가 된 것으로 봄
+func Role() CheckFunc {
+	return func(ctx context.Context) error {
+		if !global.IsActive() {
+			return errors.New("server is in STANDBY mode")
+		}
+		return nil
+	}
+}
+
+// Database: DB 연결 확인 (Ping)
+func Database(db *gorm.DB) CheckFunc {
+	return func(ctx context.Context) error {
+		sqlDB, err := db.DB()
+		if err != nil {
+			return fmt.Errorf("database instance error: %w", err)
+		}
+		return sqlDB.PingContext(ctx)
+	}
+}
+
+// DiskSpace: dir이 있는 볼륨에 minFree 바이트 이상 남았는지 확인
+func DiskSpace(dir string, minFree uint64) CheckFunc {
+	return func(ctx context.Context) error {
+		free, err := freeBytes(dir)
+		if err != nil {
+			return fmt.Errorf("statfs %s: %w", dir, err)
+		}
+		if free < minFree {
+			return fmt.Errorf("only %d MiB free on %s (need %d MiB)", free>>20, dir, minFree>>20)
+		}
+		return nil
+	}
+}
+
+// Writable: dir에 파일을 만들 수 있는지 확인 (임시 파일을 만들었다가 지움)
+func Writable(dir string) CheckFunc {
+	return func(ctx context.Context) error {
+		f, err := os.CreateTemp(dir, ".healthcheck-*")
+		if err != nil {
+			return err
+		}
+		name := f.Name()
+		f.Close()
+		return os.Remove(filepath.Clean(name))
+	}
+}
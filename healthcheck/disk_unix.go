@@ -0,0 +1,14 @@
+//go:build unix
+
+package healthcheck
+
+import "syscall"
+
+// 일반 사용자가 쓸 수 있는 남은 용량 (바이트)
+func freeBytes(dir string) (uint64, error) {
+	var st syscall.Statfs_t
+	if err := syscall.Statfs(dir, &st); err != nil {
+		return 0, err
+	}
+	return uint64(st.Bavail) * uint64(st.Bsize), nil
+}
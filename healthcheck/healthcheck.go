@@ -0,0 +1,130 @@
+// Package healthcheck: readiness 판단에 쓰는 의존성 점검 (역할, DB, 디스크, 로그 디렉터리 ...)
+// 점검은 동시에 돌고 각각 제한 시간을 가지며, 점검별 마지막 에러를 기억해서 /health/details로 보여줍니다.
+package healthcheck
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"go_study/model"
+)
+
+// 점검 결과 상태
+const (
+	StatusOK   = "ok"
+	StatusFail = "fail"
+)
+
+// CheckFunc: 정상이면 nil
+type CheckFunc func(ctx context.Context) error
+
+// DetailFunc: 결과와 함께 보여줄 상세 정보(예: 복제 지연)가 있는 점검
+type DetailFunc func(ctx context.Context) (interface{}, error)
+
+type check struct {
+	name string
+	fn   DetailFunc
+}
+
+// 점검별로 기억하는 마지막 상태
+type history struct {
+	lastError     string
+	lastErrorAt   *time.Time
+	lastSuccessAt *time.Time
+}
+
+// Checker: 등록된 점검을 한꺼번에 실행
+type Checker struct {
+	timeout time.Duration
+
+	mu      sync.Mutex
+	checks  []check
+	history map[string]*history
+}
+
+// 생성자 (timeout: 점검 하나의 제한 시간)
+func New(timeout time.Duration) *Checker {
+	if timeout <= 0 {
+		timeout = 2 * time.Second
+	}
+	return &Checker{timeout: timeout, history: make(map[string]*history)}
+}
+
+// Add: 점검 등록 (등록한 순서대로 결과에 나옴)
+func (c *Checker) Add(name string, fn CheckFunc) {
+	c.AddDetail(name, func(ctx context.Context) (interface{}, error) { return nil, fn(ctx) })
+}
+
+// AddDetail: 상세 정보가 있는 점검 등록
+func (c *Checker) AddDetail(name string, fn DetailFunc) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.checks = append(c.checks, check{name: name, fn: fn})
+	c.history[name] = &history{}
+}
+
+// Run: 모든 점검을 동시에 실행 (하나라도 실패하면 Ready=false)
+func (c *Checker) Run(ctx context.Context) model.HealthReport {
+	c.mu.Lock()
+	checks := append([]check(nil), c.checks...)
+	c.mu.Unlock()
+
+	results := make([]model.HealthResult, len(checks))
+	var wg sync.WaitGroup
+	for i, ch := range checks {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			results[i] = c.run(ctx, ch)
+		}()
+	}
+	wg.Wait()
+
+	report := model.HealthReport{Ready: true, CheckedAt: time.Now(), Checks: results}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for i := range results {
+		r := &results[i]
+		h := c.history[r.Name]
+		if r.Status == StatusOK {
+			h.lastSuccessAt = &report.CheckedAt
+		} else {
+			report.Ready = false
+			h.lastError, h.lastErrorAt = r.Error, &report.CheckedAt
+		}
+		r.LastError, r.LastErrorAt, r.LastSuccessAt = h.lastError, h.lastErrorAt, h.lastSuccessAt
+	}
+	return report
+}
+
+// 점검 하나 실행 (제한 시간을 넘기면 실패, 늦게 끝난 점검 결과는 버림)
+func (c *Checker) run(ctx context.Context, ch check) model.HealthResult {
+	ctx, cancel := context.WithTimeout(ctx, c.timeout)
+	defer cancel()
+
+	type outcome struct {
+		detail interface{}
+		err    error
+	}
+	start := time.Now()
+	done := make(chan outcome, 1)
+	go func() {
+		detail, err := ch.fn(ctx)
+		done <- outcome{detail, err}
+	}()
+
+	var out outcome
+	select {
+	case out = <-done:
+	case <-ctx.Done():
+		out.err = ctx.Err()
+	}
+
+	err := out.err
+	r := model.HealthResult{Name: ch.name, Status: StatusOK, LatencyMs: float64(time.Since(start).Microseconds()) / 1000, Detail: out.detail}
+	if err != nil {
+		r.Status, r.Error = StatusFail, err.Error()
+	}
+	return r
+}
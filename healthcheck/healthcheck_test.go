@@ -0,0 +1,60 @@
+package healthcheck
+
+import (
+	"context"
+	"errors"
+	"math"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestChecker_ReportAndLastError(t *testing.T) {
+	c := New(50 * time.Millisecond)
+	failing := true
+	c.Add("ok", func(ctx context.Context) error { return nil })
+	c.Add("flaky", func(ctx context.Context) error {
+		if failing {
+			return errors.New("down")
+		}
+		return nil
+	})
+	c.Add("slow", func(ctx context.Context) error {
+		<-ctx.Done()
+		return ctx.Err()
+	})
+
+	// 1. 실패한 점검이 있으면 not ready, 제한 시간을 넘긴 점검도 실패
+	report := c.Run(context.Background())
+	assert.False(t, report.Ready)
+	assert.Equal(t, []string{"ok", "flaky", "slow"}, []string{report.Checks[0].Name, report.Checks[1].Name, report.Checks[2].Name})
+	assert.Equal(t, StatusOK, report.Checks[0].Status)
+	assert.Equal(t, StatusFail, report.Checks[1].Status)
+	assert.Equal(t, "down", report.Checks[1].Error)
+	assert.Contains(t, report.Checks[2].Error, "deadline")
+
+	// 2. 회복돼도 마지막 에러는 남음
+	failing = false
+	report = c.Run(context.Background())
+	flaky := report.Checks[1]
+	assert.Equal(t, StatusOK, flaky.Status)
+	assert.Empty(t, flaky.Error)
+	assert.Equal(t, "down", flaky.LastError)
+	assert.NotNil(t, flaky.LastErrorAt)
+	assert.NotNil(t, flaky.LastSuccessAt)
+}
+
+func TestWritableAndDiskSpace(t *testing.T) {
+	dir := t.TempDir()
+	assert.NoError(t, Writable(dir)(context.Background()))
+	// 점검용 임시 파일은 남지 않음
+	entries, _ := os.ReadDir(dir)
+	assert.Empty(t, entries)
+	assert.Error(t, Writable(filepath.Join(dir, "missing"))(context.Background()))
+
+	assert.NoError(t, DiskSpace(dir, 1)(context.Background()))
+	assert.Error(t, DiskSpace(dir, math.MaxUint64)(context.Background()))
+}
@@ -0,0 +1,91 @@
+package ical
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"go_study/model"
+)
+
+func TestEscapeText(t *testing.T) {
+	assert.Equal(t, `a\, b\; c\\d\ne`, EscapeText("a, b; c\\d\r\ne"))
+}
+
+func TestFold(t *testing.T) {
+	// 짧은 줄은 CRLF만 붙음
+	assert.Equal(t, "SUMMARY:hi\r\n", Fold("SUMMARY:hi"))
+
+	// 긴 줄(한글 3바이트)은 75옥텟 이하로 접히고, 글자가 중간에서 잘리지 않아야 함
+	line := "SUMMARY:" + strings.Repeat("가", 60)
+	folded := Fold(line)
+	parts := strings.Split(strings.TrimSuffix(folded, "\r\n"), "\r\n")
+	assert.Greater(t, len(parts), 1)
+	for i, p := range parts {
+		assert.LessOrEqual(t, len(p), 75)
+		if i > 0 {
+			assert.True(t, strings.HasPrefix(p, " "))
+		}
+	}
+
+	// 접힌 줄을 펴면 원래 줄이 나와야 함
+	assert.Equal(t, line, strings.ReplaceAll(strings.TrimSuffix(folded, "\r\n"), "\r\n ", ""))
+}
+
+func TestWriteTodo(t *testing.T) {
+	due := time.Date(2025, 3, 1, 9, 0, 0, 0, time.FixedZone("KST", 9*3600))
+	todo := model.Todo{
+		ID:        7,
+		Task:      "보고서 제출, 최종본",
+		Done:      true,
+		Priority:  1,
+		DueAt:     &due,
+		CreatedAt: time.Date(2025, 2, 1, 0, 0, 0, 0, time.UTC),
+		UpdatedAt: time.Date(2025, 2, 20, 0, 0, 0, 0, time.UTC),
+	}
+
+	var buf bytes.Buffer
+	w := NewWriter(&buf)
+	opts := Options{Name: "Go Todo", Events: true}
+	BeginCalendar(w, opts)
+	WriteTodo(w, todo, opts)
+	EndCalendar(w)
+	assert.NoError(t, w.Flush())
+
+	out := buf.String()
+	assert.True(t, strings.HasPrefix(out, "BEGIN:VCALENDAR\r\nVERSION:2.0\r\n"))
+	assert.True(t, strings.HasSuffix(out, "END:VCALENDAR\r\n"))
+	assert.Contains(t, out, "UID:todo-7@go-todo-api\r\n")
+	assert.Contains(t, out, "SUMMARY:보고서 제출\\, 최종본\r\n")
+	assert.Contains(t, out, "STATUS:COMPLETED\r\n")
+	assert.Contains(t, out, "PRIORITY:1\r\n")
+	assert.Contains(t, out, "DUE:20250301T000000Z\r\n") // KST 09:00 -> UTC 00:00
+	assert.Contains(t, out, "COMPLETED:20250220T000000Z\r\n")
+	assert.Contains(t, out, "BEGIN:VEVENT\r\n")
+	assert.Contains(t, out, "DTSTART:20250301T000000Z\r\nDURATION:PT0S\r\n")
+	assert.NotContains(t, out, "DTEND")
+}
+
+func TestParseTodo(t *testing.T) {
+	// 접힌 줄, 이스케이프, DATE 값, VTIMEZONE 같은 다른 컴포넌트가 섞여 있어도 VTODO만 읽음
+	input := "BEGIN:VCALENDAR\r\nVERSION:2.0\r\n" +
+		"BEGIN:VTIMEZONE\r\nTZID:Asia/Seoul\r\nEND:VTIMEZONE\r\n" +
+		"BEGIN:VTODO\r\nUID:x-1\r\nSUMMARY:긴 제목\\, 세미콜론\\; 그리고\r\n  접힌 줄\r\n" +
+		"DUE;VALUE=DATE:20250310\r\nCOMPLETED:20250309T120000Z\r\nEND:VTODO\r\nEND:VCALENDAR\r\n"
+
+	todo, err := ParseTodo(strings.NewReader(input))
+	assert.NoError(t, err)
+	assert.Equal(t, "x-1", todo.UID)
+	assert.Equal(t, "긴 제목, 세미콜론; 그리고 접힌 줄", todo.Task)
+	assert.True(t, todo.Done)
+	assert.Equal(t, time.Date(2025, 3, 10, 0, 0, 0, 0, time.UTC), *todo.DueAt)
+
+	// VTODO가 없으면 ErrNoTodo, 구조가 깨지면 에러
+	_, err = ParseTodo(strings.NewReader("BEGIN:VCALENDAR\r\nBEGIN:VEVENT\r\nUID:e\r\nEND:VEVENT\r\nEND:VCALENDAR\r\n"))
+	assert.Equal(t, ErrNoTodo, err)
+	_, err = ParseTodo(strings.NewReader("BEGIN:VCALENDAR\r\nBEGIN:VTODO\r\nUID:x\r\nEND:VCALENDAR\r\n"))
+	assert.Error(t, err)
+}
@@ -0,0 +1,232 @@
+package ical
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"go_study/model"
+)
+
+// ErrNoTodo: 달력 데이터에 VTODO 컴포넌트가 없음 (예: VEVENT만 있는 경우)
+var ErrNoTodo = errors.New("no VTODO component")
+
+// Prop: 파싱된 속성 한 줄 (예: DUE;TZID=Asia/Seoul:20250301T090000)
+type Prop struct {
+	Name   string
+	Params map[string]string
+	Value  string
+}
+
+// Component: BEGIN ~ END 사이의 속성과 하위 컴포넌트
+type Component struct {
+	Name       string
+	Props      []Prop
+	Components []*Component
+}
+
+// Get: 이름이 같은 첫 번째 속성 (없으면 nil)
+func (c *Component) Get(name string) *Prop {
+	for i := range c.Props {
+		if c.Props[i].Name == name {
+			return &c.Props[i]
+		}
+	}
+	return nil
+}
+
+// Parse: iCalendar 문서를 컴포넌트 트리로 읽음 (최상위는 보통 VCALENDAR 하나)
+func Parse(r io.Reader) (*Component, error) {
+	lines, err := unfold(r)
+	if err != nil {
+		return nil, err
+	}
+
+	var stack []*Component
+	var root *Component
+	for _, line := range lines {
+		prop, err := parseLine(line)
+		if err != nil {
+			return nil, err
+		}
+		switch prop.Name {
+		case "BEGIN":
+			comp := &Component{Name: strings.ToUpper(prop.Value)}
+			if len(stack) > 0 {
+				parent := stack[len(stack)-1]
+				parent.Components = append(parent.Components, comp)
+			} else if root == nil {
+				root = comp
+			} else {
+				return nil, errors.New("multiple top-level components")
+			}
+			stack = append(stack, comp)
+		case "END":
+			if len(stack) == 0 || stack[len(stack)-1].Name != strings.ToUpper(prop.Value) {
+				return nil, fmt.Errorf("unexpected END:%s", prop.Value)
+			}
+			stack = stack[:len(stack)-1]
+		default:
+			if len(stack) == 0 {
+				return nil, fmt.Errorf("property %s outside of a component", prop.Name)
+			}
+			cur := stack[len(stack)-1]
+			cur.Props = append(cur.Props, prop)
+		}
+	}
+	if root == nil {
+		return nil, errors.New("empty calendar")
+	}
+	if len(stack) > 0 {
+		return nil, fmt.Errorf("missing END:%s", stack[len(stack)-1].Name)
+	}
+	return root, nil
+}
+
+// ParseTodo: 달력 데이터의 첫 번째 VTODO를 model.Todo로 변환
+// UID는 Todo.UID에 담기고, ID/CreatedAt 같은 서버 값은 비어 있습니다.
+func ParseTodo(r io.Reader) (model.Todo, error) {
+	var t model.Todo
+	cal, err := Parse(r)
+	if err != nil {
+		return t, err
+	}
+	var vtodo *Component
+	for _, c := range cal.Components {
+		if c.Name == "VTODO" {
+			vtodo = c
+			break
+		}
+	}
+	if vtodo == nil {
+		return t, ErrNoTodo
+	}
+
+	if p := vtodo.Get("UID"); p != nil {
+		t.UID = UnescapeText(p.Value)
+	}
+	if t.UID == "" {
+		return t, errors.New("VTODO has no UID")
+	}
+	if p := vtodo.Get("SUMMARY"); p != nil {
+		t.Task = UnescapeText(p.Value)
+	}
+	if p := vtodo.Get("PRIORITY"); p != nil {
+		prio, err := strconv.Atoi(p.Value)
+		if err != nil || prio < 0 || prio > 9 {
+			return t, fmt.Errorf("invalid PRIORITY %q", p.Value)
+		}
+		t.Priority = prio
+	}
+	if p := vtodo.Get("DUE"); p != nil {
+		due, err := parseTime(*p)
+		if err != nil {
+			return t, err
+		}
+		t.DueAt = &due
+	}
+	// 완료 여부: STATUS:COMPLETED 또는 COMPLETED 속성이 있으면 완료
+	if p := vtodo.Get("STATUS"); p != nil && strings.EqualFold(p.Value, "COMPLETED") {
+		t.Done = true
+	}
+	if p := vtodo.Get("COMPLETED"); p != nil {
+		t.Done = true
+		if completed, err := parseTime(*p); err == nil {
+			t.CompletedAt = &completed
+		}
+	}
+	return t, nil
+}
+
+// UnescapeText: EscapeText의 반대
+func UnescapeText(s string) string {
+	var b strings.Builder
+	for i := 0; i < len(s); i++ {
+		if s[i] == '\\' && i+1 < len(s) {
+			i++
+			switch s[i] {
+			case 'n', 'N':
+				b.WriteByte('\n')
+			default:
+				b.WriteByte(s[i]) // \\ \; \,
+			}
+			continue
+		}
+		b.WriteByte(s[i])
+	}
+	return b.String()
+}
+
+// 접힌 줄 펴기: CRLF(또는 LF) 다음에 공백/탭이 오면 앞 줄에 이어 붙임
+func unfold(r io.Reader) ([]string, error) {
+	var lines []string
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 64*1024), 1<<20)
+	for scanner.Scan() {
+		line := strings.TrimRight(scanner.Text(), "\r")
+		if len(line) > 0 && (line[0] == ' ' || line[0] == '\t') && len(lines) > 0 {
+			lines[len(lines)-1] += line[1:]
+			continue
+		}
+		if line == "" {
+			continue
+		}
+		lines = append(lines, line)
+	}
+	return lines, scanner.Err()
+}
+
+// "NAME;PARAM=VALUE;PARAM2=\"a:b\":value" 형식의 한 줄 파싱
+func parseLine(line string) (Prop, error) {
+	prop := Prop{Params: map[string]string{}}
+
+	// 따옴표 밖의 첫 번째 ':'가 이름/파라미터와 값의 경계
+	inQuote := false
+	colon := -1
+	for i := 0; i < len(line); i++ {
+		if line[i] == '"' {
+			inQuote = !inQuote
+		} else if line[i] == ':' && !inQuote {
+			colon = i
+			break
+		}
+	}
+	if colon < 0 {
+		return prop, fmt.Errorf("invalid content line %q", line)
+	}
+	prop.Value = line[colon+1:]
+
+	parts := strings.Split(line[:colon], ";")
+	prop.Name = strings.ToUpper(parts[0])
+	for _, param := range parts[1:] {
+		k, v, _ := strings.Cut(param, "=")
+		prop.Params[strings.ToUpper(k)] = strings.Trim(v, `"`)
+	}
+	return prop, nil
+}
+
+// DATE(20250301) / UTC(…Z) / TZID 지정 / 떠있는(floating) 시각을 time.Time으로 변환
+// 떠있는 시각과 알 수 없는 TZID는 UTC로 취급합니다.
+func parseTime(p Prop) (time.Time, error) {
+	if p.Params["VALUE"] == "DATE" || len(p.Value) == 8 {
+		return time.Parse("20060102", p.Value)
+	}
+	if strings.HasSuffix(p.Value, "Z") {
+		return time.Parse(utcDateTime, p.Value)
+	}
+	loc := time.UTC
+	if tzid := p.Params["TZID"]; tzid != "" {
+		if l, err := time.LoadLocation(tzid); err == nil {
+			loc = l
+		}
+	}
+	t, err := time.ParseInLocation("20060102T150405", p.Value, loc)
+	if err != nil {
+		return t, fmt.Errorf("invalid %s value %q", p.Name, p.Value)
+	}
+	return t, nil
+}
@@ -0,0 +1,109 @@
+package ical
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+
+	"go_study/model"
+)
+
+// ProdID: 이 서비스가 만든 달력임을 표시
+const ProdID = "-//Gyong97//Go Todo API//KO"
+
+// Options: 달력 출력 옵션
+type Options struct {
+	Name   string // 달력 앱에 표시될 이름 (X-WR-CALNAME)
+	Events bool   // true면 마감일이 있는 할 일을 VEVENT로도 출력 (할 일 목록을 지원하지 않는 달력 앱용)
+}
+
+// TodoUID: 할 일마다 고정된 UID (달력 앱이 같은 항목으로 인식하도록)
+// CalDAV 클라이언트가 만든 할 일은 클라이언트가 정한 UID를 그대로 씁니다.
+func TodoUID(t model.Todo) string {
+	if t.UID != "" {
+		return t.UID
+	}
+	return DefaultUID(t.ID)
+}
+
+// DefaultUID: 서버(API)에서 만든 할 일의 UID
+func DefaultUID(id uint) string {
+	return fmt.Sprintf("todo-%d@go-todo-api", id)
+}
+
+// ParseDefaultUID: DefaultUID의 반대 (형식이 다르면 false)
+func ParseDefaultUID(uid string) (uint, bool) {
+	var id uint
+	if n, err := fmt.Sscanf(uid, "todo-%d@go-todo-api", &id); err != nil || n != 1 || DefaultUID(id) != uid {
+		return 0, false
+	}
+	return id, true
+}
+
+// WriteSingle: 할 일 하나만 담은 완전한 달력 문서 (CalDAV 리소스 한 개 = .ics 파일 한 개)
+func WriteSingle(w *Writer, t model.Todo) {
+	BeginCalendar(w, Options{})
+	WriteTodo(w, t, Options{})
+	EndCalendar(w)
+}
+
+// BeginCalendar: VCALENDAR 머리말
+func BeginCalendar(w *Writer, opts Options) {
+	w.Begin("VCALENDAR")
+	w.Property("VERSION", "2.0")
+	w.Property("PRODID", ProdID)
+	w.Property("CALSCALE", "GREGORIAN")
+	if opts.Name != "" {
+		w.Text("X-WR-CALNAME", opts.Name)
+	}
+}
+
+// EndCalendar: VCALENDAR 맺음말
+func EndCalendar(w *Writer) {
+	w.End("VCALENDAR")
+}
+
+// WriteTodo: 할 일 하나를 VTODO로 (옵션에 따라 VEVENT도) 출력
+func WriteTodo(w *Writer, t model.Todo, opts Options) {
+	stamp := lastModified(t)
+
+	w.Begin("VTODO")
+	w.Text("UID", TodoUID(t))
+	w.Time("DTSTAMP", stamp)
+	w.Time("CREATED", t.CreatedAt)
+	w.Time("LAST-MODIFIED", stamp)
+	w.Text("SUMMARY", t.Task)
+	if t.Priority > 0 {
+		w.Property("PRIORITY", strconv.Itoa(t.Priority))
+	}
+	if t.DueAt != nil {
+		w.Time("DUE", *t.DueAt)
+	}
+	if t.Done {
+		w.Property("STATUS", "COMPLETED")
+		w.Property("PERCENT-COMPLETE", "100")
+		w.Time("COMPLETED", t.CompletionTime())
+	} else {
+		w.Property("STATUS", "NEEDS-ACTION")
+	}
+	w.End("VTODO")
+
+	if opts.Events && t.DueAt != nil {
+		w.Begin("VEVENT")
+		w.Text("UID", TodoUID(t)+"-due")
+		w.Time("DTSTAMP", stamp)
+		w.Time("DTSTART", *t.DueAt)
+		w.Property("DURATION", "PT0S") // 길이 없는 시점 (RFC 5545: DTEND는 DTSTART보다 뒤여야 하므로 쓰지 않음)
+		w.Text("SUMMARY", t.Task)
+		w.Property("TRANSP", "TRANSPARENT") // 마감 알림일 뿐 일정이 잡힌 시간은 아님
+		w.End("VEVENT")
+	}
+}
+
+// 수정 시각이 비어 있으면 생성 시각으로 대체
+func lastModified(t model.Todo) time.Time {
+	if t.UpdatedAt.IsZero() {
+		return t.CreatedAt
+	}
+	return t.UpdatedAt
+}
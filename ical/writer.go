@@ -0,0 +1,99 @@
+// Package ical: RFC 5545 (iCalendar) 형식으로 달력 데이터를 쓰는 도구
+package ical
+
+import (
+	"bufio"
+	"io"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// 한 줄 최대 길이 (RFC 5545 3.1: 줄바꿈 문자를 제외하고 75 옥텟)
+const maxLineOctets = 75
+
+// UTC 날짜-시각 형식 (예: 20250102T090000Z)
+const utcDateTime = "20060102T150405Z"
+
+// Writer: 속성(Property)을 한 줄씩 쓰면서 접기(Folding)와 CRLF 줄바꿈을 처리
+// 첫 에러 이후의 쓰기는 무시되고, Flush()에서 그 에러를 돌려줍니다.
+type Writer struct {
+	w   *bufio.Writer
+	err error
+}
+
+// NewWriter: 생성자
+func NewWriter(w io.Writer) *Writer {
+	return &Writer{w: bufio.NewWriter(w)}
+}
+
+// Begin / End: 컴포넌트 시작과 끝 (VCALENDAR, VTODO, VEVENT ...)
+func (w *Writer) Begin(component string) { w.Line("BEGIN:" + component) }
+func (w *Writer) End(component string)   { w.Line("END:" + component) }
+
+// Property: 값을 그대로 쓰는 속성 (예: STATUS:COMPLETED)
+func (w *Writer) Property(name, value string) {
+	w.Line(name + ":" + value)
+}
+
+// Text: TEXT 타입 속성 (쉼표, 세미콜론, 역슬래시, 줄바꿈을 이스케이프)
+func (w *Writer) Text(name, value string) {
+	w.Property(name, EscapeText(value))
+}
+
+// Time: 날짜-시각 속성을 UTC로 씀 (예: DTSTAMP:20250102T090000Z)
+func (w *Writer) Time(name string, t time.Time) {
+	w.Property(name, FormatTime(t))
+}
+
+// Line: 한 줄을 75옥텟 단위로 접어서 CRLF로 끝맺어 씀
+func (w *Writer) Line(line string) {
+	if w.err != nil {
+		return
+	}
+	_, w.err = w.w.WriteString(Fold(line))
+}
+
+// Flush: 버퍼를 비우고 지금까지의 에러를 반환
+func (w *Writer) Flush() error {
+	if w.err != nil {
+		return w.err
+	}
+	return w.w.Flush()
+}
+
+// FormatTime: UTC 날짜-시각 문자열
+func FormatTime(t time.Time) string {
+	return t.UTC().Format(utcDateTime)
+}
+
+// EscapeText: TEXT 값 이스케이프 (RFC 5545 3.3.11)
+func EscapeText(s string) string {
+	s = strings.ReplaceAll(s, "\r\n", "\n")
+	return strings.NewReplacer(
+		`\`, `\\`,
+		";", `\;`,
+		",", `\,`,
+		"\n", `\n`,
+		"\r", `\n`,
+	).Replace(s)
+}
+
+// Fold: 긴 줄을 "CRLF + 공백"으로 접음 (UTF-8 글자가 중간에서 잘리지 않도록 글자 경계에서 자름)
+func Fold(line string) string {
+	var b strings.Builder
+	limit := maxLineOctets
+	for len(line) > limit {
+		cut := limit
+		for cut > 0 && !utf8.RuneStart(line[cut]) {
+			cut--
+		}
+		b.WriteString(line[:cut])
+		b.WriteString("\r\n ")
+		line = line[cut:]
+		limit = maxLineOctets - 1 // 이어지는 줄은 맨 앞 공백 1옥텟을 포함해서 75
+	}
+	b.WriteString(line)
+	b.WriteString("\r\n")
+	return b.String()
+}
@@ -0,0 +1,290 @@
+// Package jobs: DB(jobs 테이블)에 저장되는 백그라운드 작업 큐
+// 작업은 종류별 핸들러가 처리하고, 종류별/전체 동시 실행 수를 제한합니다.
+// 실패하면 지수 백오프로 다시 시도하고, 한도를 넘기면 failed로 남겨서 GET /admin/queue에서 다시 시도할 수 있습니다.
+// 실행 중인 작업은 visibility timeout 동안만 잡고 있으므로, 서버가 죽으면 승격된 Standby가 이어서 가져갑니다.
+// DB를 공유하는 Standby(StandbyTakeover)는 승격을 기다리지 않고 주인이 없는 작업(잠금이 풀렸거나 visibility timeout 넘게 밀린 작업)을 가져갑니다.
+package jobs
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"os"
+	"sync"
+	"time"
+
+	"go_study/global"
+	"go_study/model"
+	"go_study/repository"
+)
+
+// Options: 큐 동작 설정 (0이면 기본값)
+type Options struct {
+	Workers           int           // 전체 동시 실행 수
+	PollInterval      time.Duration // 작업 확인 주기
+	VisibilityTimeout time.Duration // 작업 하나를 잡고 있는 시간 (핸들러 제한 시간이기도 함)
+	MaxAttempts       int           // 기본 재시도 한도
+	BaseBackoff       time.Duration // 첫 재시도 대기, 이후 2배씩
+	MaxBackoff        time.Duration // 재시도 대기 상한
+	StandbyTakeover   bool          // Standby도 주인이 없는 작업을 가져감 (Active와 DB를 공유할 때만, 복제 모드에서는 false)
+}
+
+func (o Options) withDefaults() Options {
+	if o.Workers <= 0 {
+		o.Workers = 4
+	}
+	if o.PollInterval <= 0 {
+		o.PollInterval = time.Second
+	}
+	if o.VisibilityTimeout <= 0 {
+		o.VisibilityTimeout = 5 * time.Minute
+	}
+	if o.MaxAttempts <= 0 {
+		o.MaxAttempts = 5
+	}
+	if o.BaseBackoff <= 0 {
+		o.BaseBackoff = 10 * time.Second
+	}
+	if o.MaxBackoff <= 0 {
+		o.MaxBackoff = time.Hour
+	}
+	return o
+}
+
+// HandlerFunc: 작업 하나를 처리하는 함수 (에러를 돌려주면 재시도)
+// ctx는 visibility timeout이 지나면 취소됩니다. 그 뒤에는 다른 서버가 같은 작업을 가져갈 수 있습니다.
+type HandlerFunc func(ctx context.Context, job model.Job) error
+
+// Enqueuer: 작업을 넣기만 하는 쪽 (핸들러 등)
+type Enqueuer interface {
+	Enqueue(jobType string, payload interface{}, opts ...EnqueueOption) (model.Job, error)
+}
+
+// 종류별 핸들러와 동시 실행 슬롯
+type registration struct {
+	fn    HandlerFunc
+	slots chan struct{}
+}
+
+// Queue: 작업 큐 (Active 서버가 작업을 가져가서 실행, StandbyTakeover면 Standby는 밀린 작업만)
+type Queue struct {
+	repo   repository.JobRepository
+	opts   Options
+	worker string
+	slots  chan struct{} // 전체 동시 실행 슬롯
+
+	mu        sync.RWMutex
+	handlers  map[string]*registration
+	schedules map[string]chan struct{} // 종류별 주기 작업 (닫으면 멈춤)
+	wg        sync.WaitGroup
+}
+
+// 생성자
+func NewQueue(repo repository.JobRepository, opts Options) *Queue {
+	opts = opts.withDefaults()
+	host, _ := os.Hostname()
+	return &Queue{
+		repo:      repo,
+		opts:      opts,
+		worker:    fmt.Sprintf("%s:%d", host, os.Getpid()),
+		slots:     make(chan struct{}, opts.Workers),
+		handlers:  make(map[string]*registration),
+		schedules: make(map[string]chan struct{}),
+	}
+}
+
+// Register: 작업 종류별 핸들러 등록 (concurrency: 이 종류의 동시 실행 수)
+func (q *Queue) Register(jobType string, concurrency int, fn HandlerFunc) {
+	if concurrency <= 0 {
+		concurrency = 1
+	}
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	q.handlers[jobType] = &registration{fn: fn, slots: make(chan struct{}, concurrency)}
+}
+
+// Handle: payload를 T로 풀어서 넘겨주는 Register
+func Handle[T any](q *Queue, jobType string, concurrency int, fn func(ctx context.Context, payload T) error) {
+	q.Register(jobType, concurrency, func(ctx context.Context, job model.Job) error {
+		var payload T
+		if job.Payload != "" {
+			if err := json.Unmarshal([]byte(job.Payload), &payload); err != nil {
+				return fmt.Errorf("invalid payload: %w", err)
+			}
+		}
+		return fn(ctx, payload)
+	})
+}
+
+// EnqueueOption: 작업 추가 옵션
+type EnqueueOption func(*model.Job)
+
+// WithKey: 같은 키의 작업이 이미 있으면 새로 만들지 않음
+func WithKey(key string) EnqueueOption {
+	return func(j *model.Job) { j.Key = &key }
+}
+
+// RunAt: 이 시각 이후에 실행
+func RunAt(t time.Time) EnqueueOption {
+	return func(j *model.Job) { j.RunAt = t }
+}
+
+// MaxAttempts: 이 작업의 재시도 한도
+func MaxAttempts(n int) EnqueueOption {
+	return func(j *model.Job) { j.MaxAttempts = n }
+}
+
+// Enqueue: 작업 추가 (payload는 JSON으로 저장)
+func (q *Queue) Enqueue(jobType string, payload interface{}, opts ...EnqueueOption) (model.Job, error) {
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return model.Job{}, err
+	}
+	j := model.Job{Type: jobType, Payload: string(body), MaxAttempts: q.opts.MaxAttempts, RunAt: time.Now()}
+	for _, opt := range opts {
+		opt(&j)
+	}
+	return q.repo.Enqueue(j)
+}
+
+// Start: 백그라운드 실행 시작
+func (q *Queue) Start() {
+	go func() {
+		ticker := time.NewTicker(q.opts.PollInterval)
+		defer ticker.Stop()
+		for range ticker.C {
+			if global.IsActive() {
+				q.poll(false)
+				continue
+			}
+			// Standby는 DB를 공유할 때만 주인이 없는 작업을 가져감 (복제 모드에서는 승격된 뒤 locked_until이 지난 작업부터 이어서 처리)
+			if q.opts.StandbyTakeover {
+				if n := q.poll(true); n > 0 {
+					log.Printf("🛟 [Jobs] Standby가 밀린 작업 %d개를 이어서 실행\n", n)
+				}
+			}
+		}
+	}()
+}
+
+// RunOnce: 지금 실행할 수 있는 작업을 가져가서 끝날 때까지 기다림 (시작한 작업 수 반환, 테스트용)
+func (q *Queue) RunOnce() int {
+	n := q.poll(false)
+	q.wg.Wait()
+	return n
+}
+
+// poll: 종류별로 빈 슬롯만큼 작업을 가져가서 고루틴으로 실행
+// standby면 잠금이 풀린 작업과 visibility timeout보다 오래 밀린 대기 작업만 (Active가 살아 있으면 그 전에 가져감)
+func (q *Queue) poll(standby bool) int {
+	q.mu.RLock()
+	defer q.mu.RUnlock()
+
+	started := 0
+	for jobType, reg := range q.handlers {
+		n := reserve(q.slots, reg.slots)
+		if n == 0 {
+			continue
+		}
+		now := time.Now()
+		dueBy := now
+		if standby {
+			dueBy = now.Add(-q.opts.VisibilityTimeout)
+		}
+		claimed, err := q.repo.Claim(jobType, q.worker, now, dueBy, q.opts.VisibilityTimeout, n)
+		if err != nil {
+			log.Printf("❌ [Jobs] %s 작업 조회 실패: %v\n", jobType, err)
+		}
+		// 못 쓴 슬롯은 돌려줌
+		for i := len(claimed); i < n; i++ {
+			release(q.slots, reg.slots)
+		}
+		for _, j := range claimed {
+			q.wg.Add(1)
+			go q.run(reg, j)
+		}
+		started += len(claimed)
+	}
+	return started
+}
+
+// 전체/종류별 슬롯을 둘 다 얻을 수 있는 만큼 예약
+func reserve(all, own chan struct{}) int {
+	n := 0
+	for {
+		select {
+		case all <- struct{}{}:
+		default:
+			return n
+		}
+		select {
+		case own <- struct{}{}:
+			n++
+		default:
+			<-all
+			return n
+		}
+	}
+}
+
+func release(all, own chan struct{}) {
+	<-own
+	<-all
+}
+
+// run: 작업 하나 실행 후 결과 기록
+func (q *Queue) run(reg *registration, j model.Job) {
+	defer q.wg.Done()
+	defer release(q.slots, reg.slots)
+
+	// 이전 실행이 마지막 시도였는데 끝내지 못하고 죽었음 (visibility timeout으로 다시 나온 작업)
+	if j.Attempts > j.MaxAttempts {
+		q.fail(j, "visibility timeout exceeded on last attempt", nil)
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), q.opts.VisibilityTimeout)
+	defer cancel()
+	if err := call(ctx, reg.fn, j); err != nil {
+		var retryAt *time.Time
+		if j.Attempts < j.MaxAttempts {
+			t := time.Now().Add(Backoff(q.opts.BaseBackoff, q.opts.MaxBackoff, j.Attempts))
+			retryAt = &t
+		}
+		log.Printf("⚠️ [Jobs] %s #%d 실패 (%d/%d회): %v\n", j.Type, j.ID, j.Attempts, j.MaxAttempts, err)
+		q.fail(j, err.Error(), retryAt)
+		return
+	}
+	if err := q.repo.Complete(j, time.Now()); err != nil {
+		log.Printf("❌ [Jobs] %s #%d 완료 기록 실패: %v\n", j.Type, j.ID, err)
+	}
+}
+
+func (q *Queue) fail(j model.Job, reason string, retryAt *time.Time) {
+	if err := q.repo.Fail(j, reason, retryAt, time.Now()); err != nil {
+		log.Printf("❌ [Jobs] %s #%d 실패 기록 실패: %v\n", j.Type, j.ID, err)
+	}
+}
+
+// 핸들러의 panic이 큐 고루틴을 죽이지 않도록
+func call(ctx context.Context, fn HandlerFunc, j model.Job) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("panic: %v", r)
+		}
+	}()
+	return fn(ctx, j)
+}
+
+// Backoff: attempts번 실패한 뒤 다음 시도까지 기다릴 시간 (base, 2*base, 4*base ... 최대 limit)
+func Backoff(base, limit time.Duration, attempts int) time.Duration {
+	d := base
+	for i := 1; i < attempts; i++ {
+		d *= 2
+		if d >= limit {
+			return limit
+		}
+	}
+	return min(d, limit)
+}
@@ -0,0 +1,196 @@
+package jobs
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"go_study/model"
+	"go_study/repository"
+
+	"github.com/glebarez/sqlite"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+)
+
+// 파일 DB: :memory:는 연결마다 다른 DB라서 작업 고루틴이 새 연결을 열면 테이블이 보이지 않음
+func newTestRepo(t *testing.T) *repository.SQLiteJobRepository {
+	db, err := gorm.Open(sqlite.Open(filepath.Join(t.TempDir(), "jobs.db")), &gorm.Config{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	db.AutoMigrate(&model.Job{})
+	return repository.NewSQLiteJobRepository(db)
+}
+
+type greeting struct {
+	Name string `json:"name"`
+}
+
+func TestQueue_TypedHandlerAndKey(t *testing.T) {
+	repo := newTestRepo(t)
+	q := NewQueue(repo, Options{})
+
+	var got []string
+	Handle(q, "greet", 1, func(ctx context.Context, p greeting) error {
+		got = append(got, p.Name)
+		return nil
+	})
+
+	j, err := q.Enqueue("greet", greeting{Name: "kim"}, WithKey("greet@1"))
+	assert.NoError(t, err)
+	// 같은 키는 새로 만들지 않음
+	dup, _ := q.Enqueue("greet", greeting{Name: "lee"}, WithKey("greet@1"))
+	assert.Equal(t, j.ID, dup.ID)
+	// 아직 실행 시각이 안 된 작업은 가져가지 않음
+	q.Enqueue("greet", greeting{Name: "later"}, RunAt(time.Now().Add(time.Hour)))
+
+	assert.Equal(t, 1, q.RunOnce())
+	assert.Equal(t, []string{"kim"}, got)
+
+	done, _ := repo.Find(j.ID)
+	assert.Equal(t, model.JobSucceeded, done.Status)
+	assert.NotNil(t, done.FinishedAt)
+	assert.Equal(t, 0, q.RunOnce())
+}
+
+func TestQueue_RetriesThenFails(t *testing.T) {
+	repo := newTestRepo(t)
+	q := NewQueue(repo, Options{MaxAttempts: 2, BaseBackoff: time.Millisecond, MaxBackoff: time.Millisecond})
+	q.Register("flaky", 1, func(ctx context.Context, job model.Job) error {
+		return errors.New("boom")
+	})
+	j, _ := q.Enqueue("flaky", nil)
+
+	// 1회째 실패 → 백오프 후 다시 대기
+	q.RunOnce()
+	j, _ = repo.Find(j.ID)
+	assert.Equal(t, model.JobQueued, j.Status)
+	assert.Equal(t, 1, j.Attempts)
+	assert.Equal(t, "boom", j.LastError)
+
+	// 2회째 실패 → 한도 초과로 포기
+	time.Sleep(5 * time.Millisecond)
+	q.RunOnce()
+	j, _ = repo.Find(j.ID)
+	assert.Equal(t, model.JobFailed, j.Status)
+
+	// 관리자가 다시 시도하면 처음부터
+	_, err := repo.Retry(j.ID, time.Now())
+	assert.NoError(t, err)
+	j, _ = repo.Find(j.ID)
+	assert.Equal(t, model.JobQueued, j.Status)
+	assert.Equal(t, 0, j.Attempts)
+	_, err = repo.Retry(j.ID, time.Now())
+	assert.ErrorIs(t, err, repository.ErrJobNotFailed)
+
+	counts, _ := repo.Counts()
+	assert.Equal(t, int64(1), counts[model.JobQueued])
+	assert.Equal(t, int64(0), counts[model.JobFailed])
+}
+
+func TestQueue_VisibilityTimeout(t *testing.T) {
+	repo := newTestRepo(t)
+	q := NewQueue(repo, Options{})
+	var runs int32
+	q.Register("work", 1, func(ctx context.Context, job model.Job) error {
+		atomic.AddInt32(&runs, 1)
+		return nil
+	})
+	j, _ := q.Enqueue("work", nil)
+
+	// 다른 서버가 가져간 뒤 죽음
+	claimed, err := repo.Claim("work", "dead:1", time.Now(), time.Now(), time.Millisecond, 10)
+	assert.NoError(t, err)
+	assert.Len(t, claimed, 1)
+	// locked_until 전에는 아무도 못 가져감
+	before := time.Now().Add(-time.Second)
+	again, _ := repo.Claim("work", "other:2", before, before, time.Minute, 10)
+	assert.Empty(t, again)
+	time.Sleep(5 * time.Millisecond)
+
+	// 이 서버가 이어서 가져가서 실행
+	assert.Equal(t, 1, q.RunOnce())
+	assert.Equal(t, int32(1), atomic.LoadInt32(&runs))
+	j, _ = repo.Find(j.ID)
+	assert.Equal(t, model.JobSucceeded, j.Status)
+	assert.Equal(t, 2, j.Attempts)
+
+	// 죽었던 서버가 늦게 결과를 써도 무시됨
+	assert.NoError(t, repo.Fail(claimed[0], "late", nil, time.Now()))
+	j, _ = repo.Find(j.ID)
+	assert.Equal(t, model.JobSucceeded, j.Status)
+}
+
+func TestQueue_StandbyTakesOverOnlyOrphanedJobs(t *testing.T) {
+	repo := newTestRepo(t)
+	q := NewQueue(repo, Options{VisibilityTimeout: time.Minute, StandbyTakeover: true})
+	var mu sync.Mutex
+	var ran []string
+	q.Register("work", 4, func(ctx context.Context, job model.Job) error {
+		mu.Lock()
+		defer mu.Unlock()
+		ran = append(ran, job.Payload)
+		return nil
+	})
+	now := time.Now()
+
+	// 살아 있는 Active가 잡고 있는 작업
+	held, _ := q.Enqueue("work", "held", RunAt(now.Add(-3*time.Minute)))
+	_, err := repo.Claim("work", "active:1", now, now, time.Minute, 1)
+	assert.NoError(t, err)
+	// 죽은 Active가 잡고 있던 작업 (locked_until이 이미 지남)
+	q.Enqueue("work", "orphaned", RunAt(now.Add(-3*time.Minute)))
+	_, err = repo.Claim("work", "dead:1", now.Add(-2*time.Minute), now.Add(-2*time.Minute), time.Minute, 1)
+	assert.NoError(t, err)
+	// visibility timeout보다 오래 밀린 작업, 방금 실행 시각이 된 작업
+	q.Enqueue("work", "overdue", RunAt(now.Add(-90*time.Second)))
+	fresh, _ := q.Enqueue("work", "fresh")
+
+	// Standby는 주인이 없는 작업만 가져감 (잠긴 작업, 막 들어온 작업은 Active 몫)
+	assert.Equal(t, 2, q.poll(true))
+	q.wg.Wait()
+	assert.ElementsMatch(t, []string{`"orphaned"`, `"overdue"`}, ran)
+	j, _ := repo.Find(held.ID)
+	assert.Equal(t, model.JobRunning, j.Status)
+	assert.Equal(t, "active:1", j.LockedBy)
+	j, _ = repo.Find(fresh.ID)
+	assert.Equal(t, model.JobQueued, j.Status)
+}
+
+func TestQueue_ConcurrencyLimit(t *testing.T) {
+	repo := newTestRepo(t)
+	q := NewQueue(repo, Options{Workers: 3})
+	release := make(chan struct{})
+	var running int32
+	q.Register("slow", 2, func(ctx context.Context, job model.Job) error {
+		atomic.AddInt32(&running, 1)
+		<-release
+		return nil
+	})
+	for i := 0; i < 5; i++ {
+		q.Enqueue("slow", nil)
+	}
+
+	// 종류별 한도(2)만큼만 가져감, 슬롯이 찬 동안은 더 가져가지 않음
+	assert.Equal(t, 2, q.poll(false))
+	assert.Eventually(t, func() bool { return atomic.LoadInt32(&running) == 2 }, time.Second, time.Millisecond)
+	assert.Equal(t, 0, q.poll(false))
+	close(release)
+	q.wg.Wait()
+
+	assert.Equal(t, 2, q.RunOnce())
+	assert.Equal(t, 1, q.RunOnce())
+	counts, _ := repo.Counts()
+	assert.Equal(t, int64(5), counts[model.JobSucceeded])
+}
+
+func TestBackoff(t *testing.T) {
+	assert.Equal(t, 10*time.Second, Backoff(10*time.Second, time.Hour, 1))
+	assert.Equal(t, 40*time.Second, Backoff(10*time.Second, time.Hour, 3))
+	assert.Equal(t, time.Hour, Backoff(10*time.Second, time.Hour, 20))
+}
@@ -0,0 +1,49 @@
+package jobs
+
+import (
+	"fmt"
+	"log"
+	"time"
+
+	"go_study/global"
+)
+
+// Every: interval마다 jobType 작업을 추가 (Active 서버에서만)
+// 주기마다 같은 키(jobType@주기 시작 시각)를 쓰므로, 두 서버가 동시에 Active여도 한 주기에 한 번만 실행됩니다.
+// 같은 jobType으로 다시 부르면 이전 주기를 멈추고 새 주기로 바꾸고, interval이 0 이하면 멈추기만 합니다. (설정 다시 읽기)
+func (q *Queue) Every(jobType string, interval time.Duration, payload interface{}) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	if stop, ok := q.schedules[jobType]; ok {
+		close(stop)
+		delete(q.schedules, jobType)
+		log.Printf("⏰ [Jobs] 주기 작업 해제: %s\n", jobType)
+	}
+	if interval <= 0 {
+		return
+	}
+	stop := make(chan struct{})
+	q.schedules[jobType] = stop
+
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		log.Printf("⏰ [Jobs] 주기 작업 등록: %s (%s 간격)\n", jobType, interval)
+		for {
+			var now time.Time
+			select {
+			case <-stop:
+				return
+			case now = <-ticker.C:
+			}
+			if !global.IsActive() {
+				continue
+			}
+			key := fmt.Sprintf("%s@%d", jobType, now.Truncate(interval).Unix())
+			if _, err := q.Enqueue(jobType, payload, WithKey(key)); err != nil {
+				log.Printf("❌ [Jobs] 주기 작업 추가 실패 (%s): %v\n", jobType, err)
+			}
+		}
+	}()
+}
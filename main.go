@@ -1,27 +1,36 @@
 package main
 
 import (
+	"context"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"strings"
+
 	"go_study/config"
-	"go_study/handler"
 	"go_study/middleware"
-	"go_study/model"
-	"go_study/repository"
-	"log"
+	"go_study/migrate"
 
-	"github.com/gin-gonic/gin"
 	"github.com/glebarez/sqlite"
 	"gorm.io/gorm"
+)
 
-	_ "go_study/docs"
+const usage = `usage: main <command> [flags]
 
-	swaggerFiles "github.com/swaggo/files"
-	ginSwagger "github.com/swaggo/gin-swagger"
+commands:
+  serve         [--role active|standby] [--port :8080] [--db file]   서버 실행 (기본 명령)
+  migrate       up | down [n] | status                               스키마 마이그레이션
+  seed          [--count 1000] [--allow-webhooks]                    데모 데이터 생성 (활성 웹훅이 있으면 거절)
+  backup        [--dir dir] [--keep n]                               온라인 백업 (서버 실행 중에도 가능)
+  restore       <backup file or name>                                오프라인 복원 (서버를 멈춘 상태에서)
+  promote       [--url URL] [--ca f --cert f --key f]                실행 중인 서버를 Active로
+  demote        [--url URL] [--ca f --cert f --key f]                실행 중인 서버를 Standby로
+  check-config                                                       설정 파일과 DB 상태 점검
 
-	"go_study/cron"
-	"go_study/global"
-	"os"
-	"strings"
-)
+모든 명령은 --config <file> (기본 ./config.yaml)과 --db <file>을 받고,
+플래그 값은 config.yaml과 환경 변수보다 우선합니다. (main <command> -h 로 명령별 플래그 확인)
+`
 
 // @title           Go Todo API
 // @version         1.0
@@ -31,77 +40,98 @@ import (
 // @host            localhost:8080
 // @BasePath        /
 func main() {
-	// 설정 로드
-	config.LoadConfig()
-	// 로거 초기화
-	middleware.InitLogger()
-	// 프로그램 종료 시 버퍼 비우기
-	defer middleware.Log.Sync()
-
-	// 🚀 [수정] 환경변수로 초기 상태 결정
-	// Docker Compose에서 넣어준 값을 읽어옵니다.
-	initialRole := strings.ToLower(os.Getenv("INITIAL_ROLE"))
-
-	if initialRole == "active" {
-		global.SetActive()
-		middleware.Log.Info("🚀 서버가 ACTIVE 모드로 시작됩니다.")
-	} else {
-		global.SetStandby()
-		middleware.Log.Info("💤 서버가 STANDBY 모드로 시작됩니다.")
+	cmd, args := splitCommand(os.Args[1:])
+	switch cmd {
+	case "serve":
+		runServe(args)
+	case "migrate":
+		runMigrate(args)
+	case "seed":
+		runSeed(args)
+	case "backup":
+		runBackup(args)
+	case "restore":
+		runRestore(args)
+	case "promote", "demote":
+		runRoleChange(cmd, args)
+	case "check-config":
+		runCheckConfig(args)
+	case "help":
+		fmt.Print(usage)
+	default:
+		fmt.Fprint(os.Stderr, usage)
+		log.Fatalf("unknown command %q", cmd)
 	}
+}
 
-	// 1. DB 연결 (Infrastructure Layer)
-	db, err := gorm.Open(sqlite.Open(config.AppConfig.Database.File), &gorm.Config{})
-	if err != nil {
-		log.Fatal(err)
+// splitCommand: 하위 명령과 나머지 인자 (첫 인자가 플래그거나 없으면 serve: ./main, ./main --role=active)
+func splitCommand(args []string) (string, []string) {
+	if len(args) > 0 && !strings.HasPrefix(args[0], "-") {
+		return args[0], args[1:]
 	}
-	db.AutoMigrate(&model.Todo{})
-
-	// 2. Repository 생성 (인터페이스 구현체)
-	// SQLiteRepository 인스턴스를 만듭니다.
-	todoRepo := repository.NewSQLiteRepository(db)
-
-	// 3. Handler 생성 (의존성 주입) ⭐
-	// Handler에게 "너는 이 리포지토리를 써"라고 주입해줍니다.
-	todoHandler := handler.NewTodoHandler(todoRepo)
+	return "serve", args
+}
 
-	cron.StartStatsJob(todoRepo)
-	// 4. Gin 라우팅 설정
-	// Default()는 기본 로거를 포함하므로, 우리가 만든 걸 쓰려면 New()로 빈 깡통을 만듦
-	r := gin.New()
+// commandFlags: 하위 명령의 플래그 (--config, --db는 공통, 나머지는 명령마다)
+type commandFlags struct {
+	*flag.FlagSet
+	configPath string
+	overrides  map[string]interface{} // 지정된 플래그만: 설정 키 → 값
+}
 
-	// 🚀 [추가] 정적 파일(HTML/CSS) 서빙 설정
-	// "./static" 폴더를 "/view"라는 주소로 연결하거나, 파일 하나를 특정 주소에 연결
-	r.Static("/static", "./static")          // static 폴더 공개
-	r.StaticFile("/", "./static/index.html") // 루트(/) 접속 시 index.html 보여주기
+func newCommandFlags(name, args string) *commandFlags {
+	f := &commandFlags{FlagSet: flag.NewFlagSet(name, flag.ExitOnError), overrides: map[string]interface{}{}}
+	f.Usage = func() {
+		fmt.Fprintf(f.Output(), "usage: main %s [flags] %s\n", name, args)
+		f.PrintDefaults()
+	}
+	f.StringVar(&f.configPath, "config", "", "설정 파일 경로 (기본: ./config.yaml)")
+	f.override("db", "database.file", "DB 파일 (database.file)")
+	return f
+}
 
-	// 미들웨어 부착
-	r.Use(gin.Recovery())
-	r.Use(middleware.ZapLogger())
+// override: 설정 키 하나를 덮어쓰는 플래그 (명령줄에서 지정했을 때만 적용)
+func (f *commandFlags) override(name, key, usage string) {
+	f.Func(name, usage, func(v string) error {
+		f.overrides[key] = v
+		return nil
+	})
+}
 
-	// 이제 핸들러가 메소드이므로 인스턴스(todoHandler)를 통해 호출합니다.
-	api := r.Group("/todos")
-	api.Use(middleware.CheckActive)
-	{
-		api.GET("", todoHandler.GetTodos)
-		api.POST("", todoHandler.AddTodo)
-		api.PATCH("/:id", todoHandler.ToggleTodoStatus)
-		api.DELETE("/:id", todoHandler.DeleteTodo)
+// load: 플래그를 읽고 설정 로드 → 로거 초기화
+func (f *commandFlags) load(args []string) {
+	f.Parse(args)
+	if err := config.Load(f.configPath, f.overrides); err != nil {
+		log.Fatal(err)
 	}
+	middleware.InitLogger()
+}
 
-	r.POST("/reports", todoHandler.GenerateDailyReport)
-	r.GET("/dashboard", todoHandler.GetDashboard)
-
-	// healthcheck, active-stanby 구조
-	r.GET("/health", todoHandler.HealthCheck)
-	// 🚀 [추가] 관리자용 승격 API (Admin 그룹으로 묶는 게 좋음)
-	admin := r.Group("/admin")
-	{
-		admin.POST("/promote", todoHandler.PromoteToActive)
-		admin.POST("/demote", todoHandler.DemoteToStandby)
+// openDB: database.file 열기
+func openDB() *gorm.DB {
+	db, err := gorm.Open(sqlite.Open(config.AppConfig.Database.File), &gorm.Config{})
+	if err != nil {
+		log.Fatal(err)
 	}
-	r.GET("/swagger/*any", ginSwagger.WrapHandler(swaggerFiles.Handler))
+	return db
+}
 
-	middleware.Log.Info("Starting Server with Dependency Injection...")
-	r.Run(config.AppConfig.Server.Port)
+// openMigratedDB: DB를 열고 (auto_migrate면) 남은 마이그레이션 적용, 스키마가 바이너리와 맞지 않으면 종료
+// 잠금으로 한 서버만 적용하고, DB가 바이너리보다 새 버전이면 시작을 거부합니다.
+func openMigratedDB() *gorm.DB {
+	db := openDB()
+	migrator := migrate.New(db, migrate.Options{})
+	if config.AppConfig.Database.AutoMigrate {
+		applied, err := migrator.Up(context.Background())
+		if err != nil {
+			log.Fatalf("❌ 마이그레이션 실패: %v", err)
+		}
+		for _, m := range applied {
+			middleware.Log.Info(fmt.Sprintf("🗂️ 마이그레이션 적용: %04d_%s", m.Version, m.Name))
+		}
+	}
+	if err := migrator.Check(); err != nil {
+		log.Fatalf("❌ %v (./main migrate status 로 확인)", err)
+	}
+	return db
 }
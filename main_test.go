@@ -0,0 +1,175 @@
+package main
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"go_study/config"
+	"go_study/migrate"
+	"go_study/model"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// writeTestConfig: 로그와 DB를 임시 디렉터리에 두는 설정 파일
+func writeTestConfig(t *testing.T) (string, string) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "config.yaml")
+	body := "server:\n  port: \":9090\"\nlog:\n  path: " + filepath.Join(dir, "server.log") + "\ndatabase:\n  file: " + filepath.Join(dir, "from-file.db") + "\n"
+	require.NoError(t, os.WriteFile(path, []byte(body), 0o644))
+	return path, dir
+}
+
+func TestSplitCommand(t *testing.T) {
+	tests := []struct {
+		args     []string
+		wantCmd  string
+		wantArgs []string
+	}{
+		{nil, "serve", nil},
+		{[]string{"--role=active"}, "serve", []string{"--role=active"}},
+		{[]string{"migrate", "up"}, "migrate", []string{"up"}},
+		{[]string{"restore", "--db", "x.db", "todos-1.db"}, "restore", []string{"--db", "x.db", "todos-1.db"}},
+		{[]string{"check-config"}, "check-config", []string{}},
+	}
+	for _, tt := range tests {
+		cmd, args := splitCommand(tt.args)
+		assert.Equal(t, tt.wantCmd, cmd, tt.args)
+		assert.Equal(t, tt.wantArgs, args, tt.args)
+	}
+}
+
+func TestStartRole(t *testing.T) {
+	tests := []struct {
+		flag, env string
+		want      string
+		wantErr   bool
+	}{
+		{"", "", "standby", false},
+		{"", "ACTIVE", "active", false},
+		{"standby", "active", "standby", false}, // --role > INITIAL_ROLE
+		{"Active", "", "active", false},
+		{"leader", "", "", true},
+		{"", "primary", "", true},
+	}
+	for _, tt := range tests {
+		got, err := startRole(tt.flag, tt.env)
+		if tt.wantErr {
+			assert.Error(t, err, "%q/%q", tt.flag, tt.env)
+			continue
+		}
+		assert.NoError(t, err)
+		assert.Equal(t, tt.want, got, "%q/%q", tt.flag, tt.env)
+	}
+}
+
+// 플래그 > 환경 변수 > 설정 파일 > 기본값
+func TestCommandFlags_Precedence(t *testing.T) {
+	tests := []struct {
+		name     string
+		env      map[string]string
+		args     []string
+		wantPort string
+		wantDB   string
+	}{
+		{"file", nil, nil, ":9090", "from-file.db"},
+		{"env over file", map[string]string{"TODO_SERVER_PORT": ":7070", "TODO_DATABASE_FILE": "from-env.db"}, nil, ":7070", "from-env.db"},
+		{"flag over env", map[string]string{"TODO_SERVER_PORT": ":7070", "TODO_DATABASE_FILE": "from-env.db"}, []string{"--port", ":6060", "--db", "from-flag.db"}, ":6060", "from-flag.db"},
+		{"flag only for given keys", map[string]string{"TODO_SERVER_PORT": ":7070"}, []string{"--db", "from-flag.db"}, ":7070", "from-flag.db"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path, _ := writeTestConfig(t)
+			for k, v := range tt.env {
+				t.Setenv(k, v)
+			}
+			f := newCommandFlags("serve", "")
+			f.override("port", "server.port", "")
+			f.load(append([]string{"--config", path}, tt.args...))
+
+			assert.Equal(t, tt.wantPort, config.AppConfig.Server.Port)
+			assert.Equal(t, tt.wantDB, filepath.Base(config.AppConfig.Database.File))
+		})
+	}
+}
+
+func TestMigrateSeedAndCheckConfig(t *testing.T) {
+	path, dir := writeTestConfig(t)
+	dbFile := filepath.Join(dir, "todos.db")
+	common := []string{"--config", path, "--db", dbFile}
+
+	// 1. migrate up: 빈 DB에 모든 마이그레이션 적용
+	runMigrate(append(common, "up"))
+	assert.NoError(t, migrate.New(openDB(), migrate.Options{}).Check())
+
+	// 2. seed: 마이그레이션된 DB에 데모 할 일
+	runSeed(append(common, "--count", "3"))
+	var count int64
+	openDB().Model(&model.Todo{}).Count(&count)
+	assert.Equal(t, int64(3), count)
+
+	// 3. check-config: 문제가 없으면 종료하지 않고 끝남
+	runCheckConfig(common)
+	assert.Equal(t, dbFile, config.AppConfig.Database.File)
+}
+
+// check-config는 문제가 있으면 종료 코드 1로 끝나므로 테스트 바이너리를 하위 프로세스로 다시 실행해서 확인
+func TestCheckConfig_FailsWithoutDatabase(t *testing.T) {
+	if args := os.Getenv("CHECK_CONFIG_ARGS"); args != "" {
+		runCheckConfig(strings.Split(args, "\n"))
+		return
+	}
+	path, dir := writeTestConfig(t)
+	cmd := exec.Command(os.Args[0], "-test.run=^TestCheckConfig_FailsWithoutDatabase$")
+	cmd.Env = append(os.Environ(),
+		"CHECK_CONFIG_ARGS=--config\n"+path+"\n--db\n"+filepath.Join(dir, "missing.db"),
+		"TODO_DATABASE_AUTO_MIGRATE=false")
+	out, err := cmd.CombinedOutput()
+
+	var exitErr *exec.ExitError
+	require.ErrorAs(t, err, &exitErr, string(out))
+	assert.Equal(t, 1, exitErr.ExitCode())
+	assert.Contains(t, string(out), "./main migrate up 필요")
+}
+
+// 활성 웹훅이 있으면 seed는 종료 코드 1로 거절하고, --allow-webhooks면 생성 (하위 프로세스로 확인)
+func TestSeed_RefusesWithActiveWebhooks(t *testing.T) {
+	if args := os.Getenv("SEED_ARGS"); args != "" {
+		runSeed(strings.Split(args, "\n"))
+		return
+	}
+	path, dir := writeTestConfig(t)
+	dbFile := filepath.Join(dir, "todos.db")
+	common := []string{"--config", path, "--db", dbFile}
+	runMigrate(append(common, "up"))
+	require.NoError(t, openDB().Create(&model.Webhook{URL: "https://hooks.example.com/todos", Events: []string{"*"}, Secret: "s", Active: true}).Error)
+
+	seed := func(extra ...string) (string, error) {
+		cmd := exec.Command(os.Args[0], "-test.run=^TestSeed_RefusesWithActiveWebhooks$")
+		cmd.Env = append(os.Environ(), "SEED_ARGS="+strings.Join(append(append(common, "--count", "3"), extra...), "\n"))
+		out, err := cmd.CombinedOutput()
+		return string(out), err
+	}
+	count := func() int64 {
+		var n int64
+		openDB().Model(&model.Todo{}).Count(&n)
+		return n
+	}
+
+	// 1. 웹훅이 있으면 아무것도 만들지 않고 거절
+	out, err := seed()
+	var exitErr *exec.ExitError
+	require.ErrorAs(t, err, &exitErr, out)
+	assert.Equal(t, 1, exitErr.ExitCode())
+	assert.Contains(t, out, "--allow-webhooks")
+	assert.Zero(t, count())
+
+	// 2. 명시적으로 허용하면 생성
+	out, err = seed("--allow-webhooks")
+	require.NoError(t, err, out)
+	assert.Equal(t, int64(3), count())
+}
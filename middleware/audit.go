@@ -0,0 +1,43 @@
+package middleware
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+
+	"go_study/global"
+	"go_study/model"
+	"go_study/repository"
+	"go_study/utils"
+)
+
+// AuditAdmin : 관리자 API 호출(GET 제외)을 감사 로그에 남기는 미들웨어
+// 승격/강등처럼 서버 역할이 바뀌는 호출이 많으므로, 호출 전후의 역할을 스냅샷으로 남깁니다.
+func AuditAdmin(store repository.AuditRepository) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if c.Request.Method == http.MethodGet {
+			c.Next()
+			return
+		}
+		before := roleSnapshot()
+		c.Next()
+
+		ev := utils.NewAuditEvent(c, model.AuditAdmin, "admin", c.FullPath())
+		ev.Before, ev.After = before, roleSnapshot()
+		if err := store.Append(ev); err != nil {
+			Log.Error("감사 로그 저장 실패", zap.String("path", ev.Path), zap.Error(err))
+		}
+	}
+}
+
+// 현재 서버 역할 ({"role":"active"} / {"role":"standby"})
+func roleSnapshot() json.RawMessage {
+	role := "standby"
+	if global.IsActive() {
+		role = "active"
+	}
+	b, _ := json.Marshal(map[string]string{"role": role})
+	return b
+}
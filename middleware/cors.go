@@ -0,0 +1,86 @@
+package middleware
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+
+	"go_study/config"
+
+	"github.com/gin-gonic/gin"
+)
+
+// CORS: 다른 출처(origin)의 프런트엔드가 API를 부를 수 있도록 cors.* 설정대로 헤더를 붙임
+// 설정은 요청마다 current()에서 읽으므로 설정 파일을 다시 읽으면 바로 적용됩니다.
+// preflight(OPTIONS + Access-Control-Request-Method)는 여기서 204로 끝내고, CalDAV 같은 일반 OPTIONS는 그대로 통과시킵니다.
+func CORS(current func() *config.Config) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		origin := c.GetHeader("Origin")
+		if origin == "" {
+			c.Next()
+			return
+		}
+		cfg := current().CORS
+		preflight := c.Request.Method == http.MethodOptions && c.GetHeader("Access-Control-Request-Method") != ""
+
+		h := c.Writer.Header()
+		h.Add("Vary", "Origin")
+		allowAll, ok := matchOrigin(cfg.AllowedOrigins, origin)
+		if !ok {
+			// 허용하지 않은 출처: 헤더를 붙이지 않으면 브라우저가 막음 (같은 출처 요청이나 curl은 영향 없음)
+			if preflight {
+				c.AbortWithStatus(http.StatusNoContent)
+				return
+			}
+			c.Next()
+			return
+		}
+
+		if allowAll && !cfg.AllowCredentials {
+			h.Set("Access-Control-Allow-Origin", "*")
+		} else {
+			h.Set("Access-Control-Allow-Origin", origin)
+		}
+		if cfg.AllowCredentials {
+			h.Set("Access-Control-Allow-Credentials", "true")
+		}
+
+		if !preflight {
+			if len(cfg.ExposedHeaders) > 0 {
+				h.Set("Access-Control-Expose-Headers", strings.Join(cfg.ExposedHeaders, ", "))
+			}
+			c.Next()
+			return
+		}
+
+		h.Add("Vary", "Access-Control-Request-Method")
+		h.Add("Vary", "Access-Control-Request-Headers")
+		h.Set("Access-Control-Allow-Methods", strings.Join(cfg.AllowedMethods, ", "))
+		if len(cfg.AllowedHeaders) > 0 {
+			h.Set("Access-Control-Allow-Headers", strings.Join(cfg.AllowedHeaders, ", "))
+		}
+		if cfg.MaxAge > 0 {
+			h.Set("Access-Control-Max-Age", strconv.Itoa(int(cfg.MaxAge.Seconds())))
+		}
+		c.AbortWithStatus(http.StatusNoContent)
+	}
+}
+
+// matchOrigin: origin이 허용 목록에 있는지 ("*"는 모두, "https://*.example.com"은 하위 도메인)
+func matchOrigin(allowed []string, origin string) (allowAll, ok bool) {
+	for _, a := range allowed {
+		switch {
+		case a == "*":
+			return true, true
+		case strings.EqualFold(a, origin):
+			return false, true
+		case strings.Contains(a, "://*."):
+			scheme, suffix, _ := strings.Cut(a, "://*")
+			if strings.HasPrefix(origin, scheme+"://") && strings.HasSuffix(strings.ToLower(origin), strings.ToLower(suffix)) &&
+				len(origin) > len(scheme)+3+len(suffix) {
+				return false, true
+			}
+		}
+	}
+	return false, false
+}
@@ -0,0 +1,151 @@
+package middleware
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+
+	"go_study/model"
+	"go_study/repository"
+	"go_study/utils"
+)
+
+// IdempotencyHeader: 클라이언트가 재시도 시 같은 값을 보내는 헤더
+const IdempotencyHeader = "Idempotency-Key"
+
+// 설정이 없을 때 사용할 기본 보관 기간
+const defaultIdempotencyTTL = 24 * time.Hour
+
+// 해시를 계산하려고 메모리에 읽어두는 바디의 최대 크기 (넘으면 413)
+const maxIdempotentBodySize = 1 << 20
+
+// 응답 바디를 가로채서 복사해두는 ResponseWriter 래퍼
+type bodyRecorder struct {
+	gin.ResponseWriter
+	body bytes.Buffer
+}
+
+func (w *bodyRecorder) Write(b []byte) (int, error) {
+	w.body.Write(b)
+	return w.ResponseWriter.Write(b)
+}
+
+func (w *bodyRecorder) WriteString(s string) (int, error) {
+	w.body.WriteString(s)
+	return w.ResponseWriter.WriteString(s)
+}
+
+// Idempotency : Idempotency-Key 헤더가 붙은 요청의 최초 응답을 DB에 저장해두고, 재시도 시 그대로 돌려주는 미들웨어
+// - 같은 키 + 같은 바디: 저장된 응답 재생 (핸들러는 다시 실행되지 않음)
+// - 같은 키 + 다른 바디: 422 Unprocessable Entity
+// - 첫 요청이 아직 처리 중: 409 Conflict
+// 키의 범위는 "요청자(X-Actor) + 메소드 + 라우트 경로" 단위라서, 다른 사용자가 우연히 같은 키를 써도 서로의 응답을 받지 않습니다.
+func Idempotency(store repository.IdempotencyRepository, ttl time.Duration) gin.HandlerFunc {
+	if ttl <= 0 {
+		ttl = defaultIdempotencyTTL
+	}
+
+	return func(c *gin.Context) {
+		key := c.GetHeader(IdempotencyHeader)
+		if key == "" {
+			// 헤더가 없으면 평소처럼 처리
+			c.Next()
+			return
+		}
+		if len(key) > 255 {
+			utils.SendError(c, http.StatusBadRequest, "Idempotency-Key is too long (max 255)")
+			c.Abort()
+			return
+		}
+
+		// 1. 바디를 읽어서 해시 계산 (핸들러가 다시 읽을 수 있게 되돌려 놓기)
+		var body []byte
+		if c.Request.Body != nil {
+			var err error
+			body, err = io.ReadAll(http.MaxBytesReader(c.Writer, c.Request.Body, maxIdempotentBodySize))
+			var tooLarge *http.MaxBytesError
+			if errors.As(err, &tooLarge) {
+				utils.SendError(c, http.StatusRequestEntityTooLarge, "Request body is too large")
+				c.Abort()
+				return
+			}
+			if err != nil {
+				utils.SendError(c, http.StatusBadRequest, "Failed to read request body")
+				c.Abort()
+				return
+			}
+		}
+		c.Request.Body = io.NopCloser(bytes.NewReader(body))
+		sum := sha256.Sum256(body)
+		hash := hex.EncodeToString(sum[:])
+
+		// 2. 키 선점
+		rec, created, err := store.Reserve(model.IdempotencyRecord{
+			Key:         key,
+			Scope:       utils.ActorOf(c) + " " + c.Request.Method + " " + c.FullPath(),
+			RequestHash: hash,
+			ExpiresAt:   time.Now().Add(ttl),
+		})
+		if err != nil {
+			utils.SendError(c, http.StatusInternalServerError, "Idempotency store error")
+			c.Abort()
+			return
+		}
+
+		// 3. 이미 본 키라면 -> 저장된 결과로 응답
+		if !created {
+			if rec.RequestHash != hash {
+				utils.SendError(c, http.StatusUnprocessableEntity, "Idempotency-Key was already used with a different request body")
+				c.Abort()
+				return
+			}
+			if rec.StatusCode == 0 {
+				utils.SendError(c, http.StatusConflict, "A request with this Idempotency-Key is still being processed")
+				c.Abort()
+				return
+			}
+			c.Header("Idempotent-Replayed", "true")
+			c.Data(rec.StatusCode, rec.ContentType, rec.Body)
+			c.Abort()
+			return
+		}
+
+		// 4. 처음 보는 키 -> 핸들러 실행하면서 응답 복사
+		// 핸들러가 panic이면 키를 풀어주고 다시 panic (풀지 않으면 TTL 동안 재시도가 전부 409)
+		defer func() {
+			if p := recover(); p != nil {
+				releaseKey(store, rec)
+				panic(p)
+			}
+		}()
+		recorder := &bodyRecorder{ResponseWriter: c.Writer}
+		c.Writer = recorder
+		c.Next()
+
+		// 5. 서버 에러는 저장하지 않음 (클라이언트가 같은 키로 다시 시도할 수 있게)
+		status := recorder.Status()
+		if status >= http.StatusInternalServerError {
+			releaseKey(store, rec)
+			return
+		}
+		if err := store.Complete(rec.ID, status, recorder.Header().Get("Content-Type"), recorder.body.Bytes()); err != nil {
+			// 응답은 이미 나갔으므로 실패로 바꿀 수 없음: 키를 풀어서 재시도가 409에 막히지 않게
+			Log.Error("idempotency 응답 저장 실패", zap.String("key", rec.Key), zap.String("scope", rec.Scope), zap.Error(err))
+			releaseKey(store, rec)
+		}
+	}
+}
+
+// releaseKey: 선점한 키를 풀어줌 (같은 키로 다시 시도하면 핸들러가 다시 실행됨)
+func releaseKey(store repository.IdempotencyRepository, rec model.IdempotencyRecord) {
+	if err := store.Release(rec.ID); err != nil {
+		Log.Error("idempotency 키 해제 실패 (TTL이 지날 때까지 같은 키는 409)", zap.String("key", rec.Key), zap.String("scope", rec.Scope), zap.Error(err))
+	}
+}
@@ -0,0 +1,139 @@
+package middleware
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/glebarez/sqlite"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+
+	"go_study/model"
+	"go_study/repository"
+	"go_study/utils"
+)
+
+func newIdempotencyStore() *repository.SQLiteIdempotencyRepository {
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
+	if err != nil {
+		panic("failed to connect database")
+	}
+	db.AutoMigrate(&model.IdempotencyRecord{})
+	return repository.NewSQLiteIdempotencyRepository(db)
+}
+
+// 호출 횟수를 세는 테스트용 라우터
+func newIdempotencyTestRouter(calls *int) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	r.POST("/todos", Idempotency(newIdempotencyStore(), time.Hour), func(c *gin.Context) {
+		*calls++
+		c.JSON(http.StatusCreated, gin.H{"call": *calls})
+	})
+	return r
+}
+
+func doIdempotentPost(r *gin.Engine, key, body string) *httptest.ResponseRecorder {
+	return doIdempotentPostAs(r, "", key, body)
+}
+
+func doIdempotentPostAs(r *gin.Engine, actor, key, body string) *httptest.ResponseRecorder {
+	req, _ := http.NewRequest("POST", "/todos", bytes.NewBufferString(body))
+	if key != "" {
+		req.Header.Set(IdempotencyHeader, key)
+	}
+	if actor != "" {
+		req.Header.Set(utils.ActorHeader, actor)
+	}
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+	return w
+}
+
+func TestIdempotency_ReplaysFirstResponse(t *testing.T) {
+	calls := 0
+	r := newIdempotencyTestRouter(&calls)
+
+	first := doIdempotentPost(r, "key-1", `{"task":"a"}`)
+	second := doIdempotentPost(r, "key-1", `{"task":"a"}`)
+
+	assert.Equal(t, 1, calls, "핸들러는 한 번만 실행되어야 함")
+	assert.Equal(t, http.StatusCreated, second.Code)
+	assert.Equal(t, first.Body.String(), second.Body.String())
+	assert.Equal(t, "true", second.Header().Get("Idempotent-Replayed"))
+}
+
+func TestIdempotency_RejectsDifferentBody(t *testing.T) {
+	calls := 0
+	r := newIdempotencyTestRouter(&calls)
+
+	doIdempotentPost(r, "key-1", `{"task":"a"}`)
+	w := doIdempotentPost(r, "key-1", `{"task":"b"}`)
+
+	assert.Equal(t, http.StatusUnprocessableEntity, w.Code)
+	assert.Equal(t, 1, calls)
+}
+
+func TestIdempotency_WithoutHeader(t *testing.T) {
+	calls := 0
+	r := newIdempotencyTestRouter(&calls)
+
+	doIdempotentPost(r, "", `{"task":"a"}`)
+	doIdempotentPost(r, "", `{"task":"a"}`)
+
+	assert.Equal(t, 2, calls, "헤더가 없으면 매번 실행")
+}
+
+func TestIdempotency_ScopedPerActor(t *testing.T) {
+	calls := 0
+	r := newIdempotencyTestRouter(&calls)
+
+	// 두 사용자가 같은 키를 써도 각자 처리되고, 각자의 재시도는 자기 응답을 받음
+	alice := doIdempotentPostAs(r, "alice", "key-1", `{"task":"a"}`)
+	bob := doIdempotentPostAs(r, "bob", "key-1", `{"task":"b"}`)
+	assert.Equal(t, http.StatusCreated, alice.Code)
+	assert.Equal(t, http.StatusCreated, bob.Code)
+	assert.Equal(t, 2, calls)
+
+	retry := doIdempotentPostAs(r, "bob", "key-1", `{"task":"b"}`)
+	assert.Equal(t, bob.Body.String(), retry.Body.String())
+	assert.Equal(t, "true", retry.Header().Get("Idempotent-Replayed"))
+	assert.Equal(t, 2, calls)
+}
+
+func TestIdempotency_ReleasesKeyOnPanic(t *testing.T) {
+	calls := 0
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	r.Use(gin.Recovery())
+	r.POST("/todos", Idempotency(newIdempotencyStore(), time.Hour), func(c *gin.Context) {
+		calls++
+		if calls == 1 {
+			panic("boom")
+		}
+		c.JSON(http.StatusCreated, gin.H{"call": calls})
+	})
+
+	assert.Equal(t, http.StatusInternalServerError, doIdempotentPost(r, "key-1", `{"task":"a"}`).Code)
+	// 선점이 풀렸으므로 재시도는 409가 아니라 다시 처리됨
+	w := doIdempotentPost(r, "key-1", `{"task":"a"}`)
+	assert.Equal(t, http.StatusCreated, w.Code)
+	assert.Equal(t, 2, calls)
+}
+
+func TestIdempotency_RejectsTooLargeBody(t *testing.T) {
+	calls := 0
+	r := newIdempotencyTestRouter(&calls)
+
+	w := doIdempotentPost(r, "key-1", `{"task":"`+strings.Repeat("a", maxIdempotentBodySize)+`"}`)
+	assert.Equal(t, http.StatusRequestEntityTooLarge, w.Code)
+	assert.Zero(t, calls)
+
+	// 키를 차지하지 않았으므로 정상 크기로 다시 보내면 처리됨
+	assert.Equal(t, http.StatusCreated, doIdempotentPost(r, "key-1", `{"task":"a"}`).Code)
+}
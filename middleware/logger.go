@@ -10,11 +10,20 @@ import (
 	"gopkg.in/natefinch/lumberjack.v2"
 
 	"go_study/config"
+	"go_study/utils"
 )
 
 // 전역 로거 변수 (편의상)
 var Log *zap.Logger
 
+// 로그 레벨 (설정을 다시 읽으면 SetLogLevel로 재시작 없이 바뀜)
+var logLevel = zap.NewAtomicLevel()
+
+// SetLogLevel: 로그 레벨 변경 (debug | info | warn | error)
+func SetLogLevel(level string) error {
+	return logLevel.UnmarshalText([]byte(level))
+}
+
 // 로거 초기화 (파일 저장 + 터미널 출력)
 func InitLogger() {
 	cfg := config.AppConfig.Log
@@ -27,7 +36,11 @@ func InitLogger() {
 	// 3. Core 생성 (터미널 + 파일 동시에 출력하려면 MultiWriteSyncer 사용)
 	// zapcore.AddSync(os.Stdout): 터미널에도 출력
 	// writeSyncer: 파일에도 출력
-	core := zapcore.NewCore(encoder, zapcore.NewMultiWriteSyncer(writeSyncer, zapcore.AddSync(os.Stdout)), zapcore.InfoLevel)
+	// log.level (debug | info | warn | error, config.Validate에서 검증됨)
+	if err := SetLogLevel(cfg.Level); err != nil {
+		logLevel.SetLevel(zapcore.InfoLevel)
+	}
+	core := zapcore.NewCore(encoder, zapcore.NewMultiWriteSyncer(writeSyncer, zapcore.AddSync(os.Stdout)), logLevel)
 
 	// 4. 로거 생성
 	// AddCaller: 로그 찍은 파일명과 라인 수 표시 (logger.go:45)
@@ -81,6 +94,7 @@ func ZapLogger() gin.HandlerFunc {
 			zap.String("ip", c.ClientIP()),                  // 요청자 IP
 			zap.String("user-agent", c.Request.UserAgent()), // 브라우저 정보
 			zap.Duration("latency", latency),                // 소요 시간 (중요!)
+			zap.String("request_id", utils.RequestIDOf(c)),  // 요청 ID (감사 로그와 연결)
 		)
 	}
 }
@@ -0,0 +1,21 @@
+package middleware
+
+import (
+	"github.com/gin-gonic/gin"
+
+	"go_study/utils"
+)
+
+// RequestID : 요청마다 ID를 붙이는 미들웨어
+// 앞단(nginx 등)이 X-Request-ID를 보내면 그대로 쓰고, 없으면 새로 만들어서 응답 헤더에도 돌려줍니다.
+func RequestID() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		id := c.GetHeader(utils.RequestIDHeader)
+		if id == "" || len(id) > 128 {
+			id, _ = utils.RandomToken(16)
+		}
+		c.Set(utils.RequestIDKey, id)
+		c.Header(utils.RequestIDHeader, id)
+		c.Next()
+	}
+}
@@ -0,0 +1,143 @@
+package middleware
+
+import (
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/hex"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+
+	"go_study/cluster"
+	"go_study/config"
+	"go_study/model"
+
+	"github.com/gin-gonic/gin"
+)
+
+// isHTTPS: 브라우저가 HTTPS로 보낸 요청인지 (직접 TLS이거나 앞단 nginx가 X-Forwarded-Proto: https로 알려준 경우)
+func isHTTPS(c *gin.Context) bool {
+	return c.Request.TLS != nil || strings.EqualFold(c.GetHeader("X-Forwarded-Proto"), "https")
+}
+
+// SecurityHeaders: 모든 응답에 기본 보안 헤더, 정적 페이지(/, /static)에는 CSP, HTTPS 요청에는 HSTS
+// 설정(security.*)은 요청마다 current()에서 읽습니다.
+func SecurityHeaders(current func() *config.Config) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		cfg := current().Security
+		h := c.Writer.Header()
+		h.Set("X-Content-Type-Options", "nosniff")
+		if cfg.FrameOptions != "" {
+			h.Set("X-Frame-Options", cfg.FrameOptions)
+		}
+		if cfg.ReferrerPolicy != "" {
+			h.Set("Referrer-Policy", cfg.ReferrerPolicy)
+		}
+		if cfg.HSTSMaxAge > 0 && isHTTPS(c) {
+			h.Set("Strict-Transport-Security", "max-age="+strconv.Itoa(int(cfg.HSTSMaxAge.Seconds()))+"; includeSubDomains")
+		}
+		path := c.Request.URL.Path
+		if cfg.ContentSecurityPolicy != "" && (path == "/" || strings.HasPrefix(path, "/static/")) {
+			h.Set("Content-Security-Policy", cfg.ContentSecurityPolicy)
+		}
+		c.Next()
+	}
+}
+
+// CSRF: 쿠키 기반 세션을 쓰는 요청의 CSRF 방어 (double-submit 쿠키)
+// 토큰 쿠키(security.csrf.cookie_name)를 내려주고, 세션 쿠키(security.csrf.session_cookies)가 실린 쓰기 요청은
+// 같은 토큰을 헤더(security.csrf.header_name)로도 보내야 통과합니다. 다른 사이트는 쿠키를 읽을 수 없으므로 헤더를 만들 수 없습니다.
+// 세션 쿠키가 없는 요청(CLI, 서버 간 호출, CalDAV 앱)은 브라우저가 자동으로 자격 증명을 싣는 경우가 아니므로 검사하지 않습니다.
+func CSRF(current func() *config.Config) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		cfg := current().Security.CSRF
+		if !cfg.Enabled {
+			c.Next()
+			return
+		}
+
+		token, err := c.Cookie(cfg.CookieName)
+		if err != nil || token == "" {
+			token = newCSRFToken()
+			http.SetCookie(c.Writer, &http.Cookie{
+				Name:     cfg.CookieName,
+				Value:    token,
+				Path:     "/",
+				Secure:   isHTTPS(c),
+				HttpOnly: false, // 페이지의 JS가 읽어서 헤더로 보내야 함
+				SameSite: http.SameSiteStrictMode,
+			})
+			token = "" // 방금 만든 토큰은 이 요청의 검사에 쓰지 않음
+		}
+
+		if isReadMethod(c.Request.Method) || !hasSessionCookie(c, cfg.SessionCookies) {
+			c.Next()
+			return
+		}
+		sent := c.GetHeader(cfg.HeaderName)
+		if token == "" || sent == "" || subtle.ConstantTimeCompare([]byte(sent), []byte(token)) != 1 {
+			c.AbortWithStatusJSON(http.StatusForbidden, model.WebResponse{
+				Code:    http.StatusForbidden,
+				Message: "CSRF token missing or invalid (send the " + cfg.CookieName + " cookie value in the " + cfg.HeaderName + " header)",
+			})
+			return
+		}
+		c.Next()
+	}
+}
+
+func hasSessionCookie(c *gin.Context, names []string) bool {
+	for _, name := range names {
+		if v, err := c.Cookie(name); err == nil && v != "" {
+			return true
+		}
+	}
+	return false
+}
+
+func newCSRFToken() string {
+	b := make([]byte, 32)
+	rand.Read(b)
+	return hex.EncodeToString(b)
+}
+
+// RequireClientCert: server.tls.client_ca_file이 서명한 클라이언트 인증서가 있어야 통과 (mutual TLS, /admin용)
+// server.tls.admin_client_cns가 있으면 인증서 CN도 그 목록에 있어야 합니다. (목록은 요청마다 current()에서 읽음)
+// 인증서 확인 자체는 TLS handshake에서 끝났으므로 여기서는 확인된 체인이 있는지만 봅니다.
+func RequireClientCert(current func() *config.Config) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		tls := c.Request.TLS
+		if tls == nil || len(tls.VerifiedChains) == 0 || len(tls.VerifiedChains[0]) == 0 {
+			c.AbortWithStatusJSON(http.StatusForbidden, model.WebResponse{
+				Code:    http.StatusForbidden,
+				Message: "client certificate required",
+			})
+			return
+		}
+		cn := tls.VerifiedChains[0][0].Subject.CommonName
+		if allowed := current().Server.TLS.AdminClientCNs; len(allowed) > 0 && !slices.Contains(allowed, cn) {
+			c.AbortWithStatusJSON(http.StatusForbidden, model.WebResponse{
+				Code:    http.StatusForbidden,
+				Message: "client certificate " + strconv.Quote(cn) + " is not allowed",
+			})
+			return
+		}
+		c.Next()
+	}
+}
+
+// RequirePeerToken: 서버 간 경로(heartbeat, 복제 변경분)는 X-Cluster-Token이 cluster.token과 같아야 통과
+// peer는 클라이언트 인증서 없이 토큰으로 인증하므로 /admin의 mutual TLS와 따로 둡니다. 토큰을 설정하지 않은 서버는 전부 거절합니다.
+func RequirePeerToken(token string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if !cluster.ValidToken(token, c.GetHeader(cluster.TokenHeader)) {
+			c.AbortWithStatusJSON(http.StatusUnauthorized, model.WebResponse{
+				Code:    http.StatusUnauthorized,
+				Message: "Missing or invalid cluster token",
+			})
+			return
+		}
+		c.Next()
+	}
+}
@@ -0,0 +1,181 @@
+package middleware
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"go_study/config"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newSecurityRouter(cfg *config.Config) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	current := func() *config.Config { return cfg }
+	r := gin.New()
+	r.Use(SecurityHeaders(current), CORS(current), CSRF(current))
+	ok := func(c *gin.Context) { c.Status(http.StatusOK) }
+	r.GET("/todos", ok)
+	r.POST("/todos", ok)
+	r.GET("/static/index.html", ok)
+	r.Handle("OPTIONS", "/caldav/", ok)
+	return r
+}
+
+func request(r *gin.Engine, method, path string, header http.Header, cookies ...*http.Cookie) *httptest.ResponseRecorder {
+	req, _ := http.NewRequest(method, path, nil)
+	for k, v := range header {
+		req.Header[k] = v
+	}
+	for _, ck := range cookies {
+		req.AddCookie(ck)
+	}
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+	return w
+}
+
+func TestCORS(t *testing.T) {
+	cfg := config.Default()
+	cfg.CORS.AllowedOrigins = []string{"https://app.example.com", "https://*.partner.io"}
+	cfg.CORS.AllowCredentials = true
+	r := newSecurityRouter(cfg)
+
+	// 1. preflight: 허용한 출처면 메소드/헤더/캐시 시간을 알려주고 204
+	w := request(r, "OPTIONS", "/todos", http.Header{"Origin": {"https://app.example.com"}, "Access-Control-Request-Method": {"POST"}})
+	assert.Equal(t, http.StatusNoContent, w.Code)
+	assert.Equal(t, "https://app.example.com", w.Header().Get("Access-Control-Allow-Origin"))
+	assert.Equal(t, "true", w.Header().Get("Access-Control-Allow-Credentials"))
+	assert.Contains(t, w.Header().Get("Access-Control-Allow-Methods"), "PATCH")
+	assert.Contains(t, w.Header().Get("Access-Control-Allow-Headers"), "Idempotency-Key")
+	assert.Equal(t, "600", w.Header().Get("Access-Control-Max-Age"))
+
+	// 2. 하위 도메인 와일드카드, 실제 요청에는 노출 헤더
+	w = request(r, "GET", "/todos", http.Header{"Origin": {"https://a.partner.io"}})
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "https://a.partner.io", w.Header().Get("Access-Control-Allow-Origin"))
+	assert.Contains(t, w.Header().Get("Access-Control-Expose-Headers"), "X-Request-ID")
+
+	// 3. 허용하지 않은 출처: 헤더 없음 (브라우저가 막음)
+	w = request(r, "OPTIONS", "/todos", http.Header{"Origin": {"https://evil.example"}, "Access-Control-Request-Method": {"POST"}})
+	assert.Equal(t, http.StatusNoContent, w.Code)
+	assert.Empty(t, w.Header().Get("Access-Control-Allow-Origin"))
+	assert.Empty(t, request(r, "GET", "/todos", http.Header{"Origin": {"https://partner.io"}}).Header().Get("Access-Control-Allow-Origin"))
+
+	// 4. preflight가 아닌 OPTIONS(CalDAV)는 핸들러까지 감
+	assert.Equal(t, http.StatusOK, request(r, "OPTIONS", "/caldav/", http.Header{"Origin": {"https://app.example.com"}}).Code)
+
+	// 5. "*" (자격 증명 없이)
+	cfg.CORS.AllowedOrigins = []string{"*"}
+	cfg.CORS.AllowCredentials = false
+	w = request(r, "GET", "/todos", http.Header{"Origin": {"https://any.site"}})
+	assert.Equal(t, "*", w.Header().Get("Access-Control-Allow-Origin"))
+	assert.Empty(t, w.Header().Get("Access-Control-Allow-Credentials"))
+}
+
+func TestSecurityHeaders(t *testing.T) {
+	cfg := config.Default()
+	r := newSecurityRouter(cfg)
+
+	w := request(r, "GET", "/todos", nil)
+	assert.Equal(t, "nosniff", w.Header().Get("X-Content-Type-Options"))
+	assert.Equal(t, "DENY", w.Header().Get("X-Frame-Options"))
+	assert.Empty(t, w.Header().Get("Content-Security-Policy"), "CSP only on static pages")
+	assert.Empty(t, w.Header().Get("Strict-Transport-Security"), "no HSTS over plain HTTP")
+
+	w = request(r, "GET", "/static/index.html", http.Header{"X-Forwarded-Proto": {"https"}})
+	assert.Equal(t, cfg.Security.ContentSecurityPolicy, w.Header().Get("Content-Security-Policy"))
+	assert.Equal(t, "max-age=31536000; includeSubDomains", w.Header().Get("Strict-Transport-Security"))
+
+	cfg.Security.HSTSMaxAge = 0
+	assert.Empty(t, request(r, "GET", "/todos", http.Header{"X-Forwarded-Proto": {"https"}}).Header().Get("Strict-Transport-Security"))
+}
+
+func TestCSRF(t *testing.T) {
+	cfg := config.Default()
+	cfg.Security.CSRF.Enabled = true
+	cfg.Security.CSRF.SessionCookies = []string{"session"}
+	r := newSecurityRouter(cfg)
+	session := &http.Cookie{Name: "session", Value: "abc"}
+
+	// 1. 읽기 요청에 토큰 쿠키를 내려줌
+	w := request(r, "GET", "/todos", nil, session)
+	require.Equal(t, http.StatusOK, w.Code)
+	var token *http.Cookie
+	for _, ck := range w.Result().Cookies() {
+		if ck.Name == "csrf_token" {
+			token = ck
+		}
+	}
+	require.NotNil(t, token)
+	assert.Equal(t, http.SameSiteStrictMode, token.SameSite)
+
+	// 2. 세션 쿠키가 있는 쓰기 요청은 같은 토큰을 헤더로 보내야 함
+	assert.Equal(t, http.StatusForbidden, request(r, "POST", "/todos", nil, session, token).Code)
+	assert.Equal(t, http.StatusForbidden, request(r, "POST", "/todos", http.Header{"X-Csrf-Token": {"wrong"}}, session, token).Code)
+	assert.Equal(t, http.StatusForbidden, request(r, "POST", "/todos", http.Header{"X-Csrf-Token": {token.Value}}, session).Code, "token without its cookie")
+	assert.Equal(t, http.StatusOK, request(r, "POST", "/todos", http.Header{"X-Csrf-Token": {token.Value}}, session, token).Code)
+
+	// 3. 세션 쿠키가 없는 API 클라이언트는 검사하지 않음
+	assert.Equal(t, http.StatusOK, request(r, "POST", "/todos", nil).Code)
+
+	// 4. 끄면 검사 안 함
+	cfg.Security.CSRF.Enabled = false
+	assert.Equal(t, http.StatusOK, request(r, "POST", "/todos", nil, session).Code)
+}
+
+func TestRequireClientCert(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	cfg := config.Default()
+	r := gin.New()
+	r.GET("/admin/cluster", RequireClientCert(func() *config.Config { return cfg }), func(c *gin.Context) { c.Status(http.StatusOK) })
+
+	send := func(cn string) int {
+		req, _ := http.NewRequest("GET", "/admin/cluster", nil)
+		if cn != "" {
+			leaf := &x509.Certificate{Subject: pkix.Name{CommonName: cn}}
+			req.TLS = &tls.ConnectionState{VerifiedChains: [][]*x509.Certificate{{leaf}}}
+		}
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+		return w.Code
+	}
+
+	// 1. 확인된 클라이언트 인증서가 없으면 403
+	assert.Equal(t, http.StatusForbidden, send(""))
+
+	// 2. CN 목록이 비어 있으면 CA가 서명한 인증서 모두 허용
+	assert.Equal(t, http.StatusOK, send("anyone"))
+
+	// 3. CN 목록이 있으면 목록에 있는 것만
+	cfg.Server.TLS.AdminClientCNs = []string{"ops-admin"}
+	assert.Equal(t, http.StatusOK, send("ops-admin"))
+	assert.Equal(t, http.StatusForbidden, send("anyone"))
+}
+
+func TestRequirePeerToken(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	send := func(token, header string) int {
+		r := gin.New()
+		r.POST("/cluster/heartbeat", RequirePeerToken(token), func(c *gin.Context) { c.Status(http.StatusOK) })
+		req, _ := http.NewRequest("POST", "/cluster/heartbeat", nil)
+		if header != "" {
+			req.Header.Set("X-Cluster-Token", header)
+		}
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+		return w.Code
+	}
+
+	assert.Equal(t, http.StatusOK, send("s3cret", "s3cret"))
+	assert.Equal(t, http.StatusUnauthorized, send("s3cret", ""))
+	assert.Equal(t, http.StatusUnauthorized, send("s3cret", "guess"))
+	// 토큰을 설정하지 않은 서버는 빈 토큰도 거절
+	assert.Equal(t, http.StatusUnauthorized, send("", ""))
+}
@@ -1,12 +1,20 @@
 package middleware
 
 import (
+	"go_study/config"
 	"go_study/global" // global 패키지 경로 확인
+	"go_study/model"
 	"net/http"
 
 	"github.com/gin-gonic/gin"
 )
 
+// Standby 서버 동작 방식 (config: standby.mode, 값은 config 패키지에서 검증)
+const (
+	StandbyReject   = config.StandbyReject   // 모든 요청 503 (nginx가 Active로 넘기도록)
+	StandbyReadOnly = config.StandbyReadOnly // 읽기 요청은 공유 DB에서 처리, 쓰기 요청만 503 + Active 주소 안내
+)
+
 // CheckActive : 현재 서버가 Active 상태인지 확인하는 미들웨어
 func CheckActive(c *gin.Context) {
 	// global 패키지의 상태 확인
@@ -21,3 +29,39 @@ func CheckActive(c *gin.Context) {
 	// Active 상태라면 통과
 	c.Next()
 }
+
+// StandbyGuard: mode에 맞는 Standby 처리 미들웨어 (알 수 없는 값이면 reject)
+// activeURL은 지금 Active인 서버 주소를 돌려줌 (모르면 빈 값)
+func StandbyGuard(mode string, activeURL func() string) gin.HandlerFunc {
+	if mode != StandbyReadOnly {
+		return CheckActive
+	}
+	return func(c *gin.Context) {
+		if global.IsActive() || isReadMethod(c.Request.Method) {
+			c.Next()
+			return
+		}
+
+		// 쓰기 요청: Active 주소를 알면 Location 헤더와 본문으로 안내
+		var data gin.H
+		if url := activeURL(); url != "" {
+			location := url + c.Request.URL.RequestURI()
+			c.Header("Location", location)
+			data = gin.H{"active": url, "location": location}
+		}
+		c.AbortWithStatusJSON(http.StatusServiceUnavailable, model.WebResponse{
+			Code:    http.StatusServiceUnavailable,
+			Message: "⛔ Server is in read-only STANDBY mode; send writes to the active node",
+			Data:    data,
+		})
+	}
+}
+
+// 데이터를 바꾸지 않는 메서드 (CalDAV 조회 포함)
+func isReadMethod(method string) bool {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions, "PROPFIND", "REPORT":
+		return true
+	}
+	return false
+}
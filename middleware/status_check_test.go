@@ -0,0 +1,57 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"go_study/global"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+func newStandbyRouter(mode string, activeURL string) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	r.Use(StandbyGuard(mode, func() string { return activeURL }))
+	ok := func(c *gin.Context) { c.Status(http.StatusOK) }
+	r.GET("/todos", ok)
+	r.POST("/todos", ok)
+	r.Handle("PROPFIND", "/caldav/", ok)
+	return r
+}
+
+func serve(r *gin.Engine, method, path string) *httptest.ResponseRecorder {
+	req, _ := http.NewRequest(method, path, nil)
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+	return w
+}
+
+func TestStandbyGuard(t *testing.T) {
+	defer global.SetStandby()
+	global.SetStandby()
+
+	// 1. reject: Standby는 읽기도 거절
+	r := newStandbyRouter(StandbyReject, "http://app-1:8080")
+	assert.Equal(t, http.StatusServiceUnavailable, serve(r, "GET", "/todos").Code)
+
+	// 2. read_only: 읽기는 처리, 쓰기는 Active 주소로 안내
+	r = newStandbyRouter(StandbyReadOnly, "http://app-1:8080")
+	assert.Equal(t, http.StatusOK, serve(r, "GET", "/todos?q=milk").Code)
+	assert.Equal(t, http.StatusOK, serve(r, "PROPFIND", "/caldav/").Code)
+	w := serve(r, "POST", "/todos?x=1")
+	assert.Equal(t, http.StatusServiceUnavailable, w.Code)
+	assert.Equal(t, "http://app-1:8080/todos?x=1", w.Header().Get("Location"))
+	assert.Contains(t, w.Body.String(), `"active":"http://app-1:8080"`)
+
+	// 3. Active 주소를 모르면 안내 없이 거절
+	w = serve(newStandbyRouter(StandbyReadOnly, ""), "POST", "/todos")
+	assert.Equal(t, http.StatusServiceUnavailable, w.Code)
+	assert.Empty(t, w.Header().Get("Location"))
+
+	// 4. Active면 모두 통과
+	global.SetActive()
+	assert.Equal(t, http.StatusOK, serve(r, "POST", "/todos").Code)
+}
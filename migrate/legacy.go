@@ -0,0 +1,67 @@
+package migrate
+
+import (
+	"fmt"
+
+	"gorm.io/gorm"
+)
+
+// prepare: 버전별로 SQL 전에 실행하는 단계 (SQLite SQL만으로는 할 수 없는 "없으면 추가" 같은 조건부 변경)
+var prepare = map[int]func(tx *gorm.DB) error{
+	1: upgradeLegacyTodos,
+}
+
+// 0001_initial의 todos에는 있지만 초기 버전(id, created_at, updated_at, deleted_at, task, done)에는 없던 열 (추가된 순서대로)
+var legacyTodoColumns = []struct{ name, typ string }{
+	{"priority", "integer"},
+	{"due_at", "datetime"},
+	{"completed_at", "datetime"},
+	{"seq", "integer"},
+	{"uid", "text"},
+}
+
+// upgradeLegacyTodos: 빠진 열이 있는 옛 todos를 0001_initial과 같은 모양으로 맞춤
+// 0001은 CREATE TABLE IF NOT EXISTS라서 이미 있는 todos는 그대로 두고 인덱스만 만들기 때문에, 열이 없으면 인덱스에서 실패합니다.
+// 열을 뒤에 이어 붙이므로 열 순서도 새로 만든 DB와 같고, 이미 있는 행은 우선순위 0 / UID 빈 값 / 새 변경 순번으로 채웁니다.
+func upgradeLegacyTodos(tx *gorm.DB) error {
+	if !tx.Migrator().HasTable("todos") {
+		return nil
+	}
+	var existing []string
+	if err := tx.Raw("SELECT name FROM pragma_table_info('todos')").Scan(&existing).Error; err != nil {
+		return err
+	}
+	have := map[string]bool{}
+	for _, name := range existing {
+		have[name] = true
+	}
+	for _, col := range legacyTodoColumns {
+		if have[col.name] {
+			continue
+		}
+		if err := tx.Exec(fmt.Sprintf("ALTER TABLE `todos` ADD `%s` %s", col.name, col.typ)).Error; err != nil {
+			return fmt.Errorf("add todos.%s: %w", col.name, err)
+		}
+	}
+
+	if err := tx.Exec("UPDATE `todos` SET `priority` = 0 WHERE `priority` IS NULL").Error; err != nil {
+		return err
+	}
+	if err := tx.Exec("UPDATE `todos` SET `uid` = '' WHERE `uid` IS NULL").Error; err != nil {
+		return err
+	}
+
+	// 변경 순번이 없는 행은 지금까지 발급된 가장 큰 순번 다음부터 id 순서대로 (복제본과 오프라인 동기화가 새 변경으로 받아가도록)
+	var base int64
+	if err := tx.Raw("SELECT COALESCE(MAX(`seq`), 0) FROM `todos`").Scan(&base).Error; err != nil {
+		return err
+	}
+	if tx.Migrator().HasTable("sequences") {
+		var issued int64
+		if err := tx.Raw("SELECT COALESCE(MAX(`value`), 0) FROM `sequences` WHERE `name` = 'todos'").Scan(&issued).Error; err != nil {
+			return err
+		}
+		base = max(base, issued)
+	}
+	return tx.Exec("UPDATE `todos` SET `seq` = ? + n.rn FROM (SELECT `id`, ROW_NUMBER() OVER (ORDER BY `id`) AS rn FROM `todos` WHERE `seq` IS NULL) AS n WHERE `todos`.`id` = n.`id`", base).Error
+}
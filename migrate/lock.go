@@ -0,0 +1,83 @@
+package migrate
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"gorm.io/gorm/clause"
+)
+
+// withLock: schema_migrations_lock의 한 줄(id=1)을 차지한 동안 fn 실행
+// 두 서버가 같은 DB 파일로 동시에 시작해도 한 쪽만 마이그레이션하고, 다른 쪽은 기다렸다가 이미 적용된 것을 건너뜁니다.
+func (m *Migrator) withLock(ctx context.Context, fn func() error) error {
+	// 두 서버가 동시에 만들어도 실패하지 않도록 IF NOT EXISTS
+	for _, sql := range []string{
+		"CREATE TABLE IF NOT EXISTS `schema_migrations` (`version` integer PRIMARY KEY,`name` text NOT NULL,`applied_at` datetime NOT NULL)",
+		"CREATE TABLE IF NOT EXISTS `schema_migrations_lock` (`id` integer PRIMARY KEY,`holder` text,`acquired_at` datetime)",
+	} {
+		if err := m.db.Exec(sql).Error; err != nil {
+			return err
+		}
+	}
+
+	deadline := time.Now().Add(m.opts.LockTimeout)
+	for {
+		ok, err := m.tryLock()
+		if err != nil {
+			return err
+		}
+		if ok {
+			break
+		}
+		if time.Now().After(deadline) {
+			var holder lockRecord
+			m.db.First(&holder)
+			return fmt.Errorf("%w (held by %s since %s)", ErrLockTimeout, holder.Holder, holder.AcquiredAt.Format(time.RFC3339))
+		}
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(m.opts.PollEvery):
+		}
+	}
+	defer m.unlock()
+	return fn()
+}
+
+// tryLock: 잠금이 비어 있거나 StaleAfter보다 오래됐으면 차지
+func (m *Migrator) tryLock() (bool, error) {
+	now := time.Now().UTC()
+	res := m.db.Clauses(clause.OnConflict{
+		Columns: []clause.Column{{Name: "id"}},
+		DoUpdates: clause.Assignments(map[string]interface{}{
+			"holder":      m.opts.Holder,
+			"acquired_at": now,
+		}),
+		Where: clause.Where{Exprs: []clause.Expression{
+			clause.Expr{SQL: "julianday(schema_migrations_lock.acquired_at) < julianday(?)", Vars: []interface{}{now.Add(-m.opts.StaleAfter)}},
+		}},
+	}).Create(&lockRecord{ID: 1, Holder: m.opts.Holder, AcquiredAt: now})
+	return res.RowsAffected == 1, res.Error
+}
+
+func (m *Migrator) unlock() {
+	m.db.Where("id = 1 AND holder = ?", m.opts.Holder).Delete(&lockRecord{})
+}
+
+// Holder: 지금 잠금을 잡고 있는 쪽 (잠금이 없거나 StaleAfter보다 오래됐으면 빈 값)
+// 복원처럼 DB 파일을 통째로 바꾸는 작업이 마이그레이션 도중에 끼어들지 않도록 확인할 때 씁니다.
+func (m *Migrator) Holder() (string, error) {
+	if !m.db.Migrator().HasTable(&lockRecord{}) {
+		return "", nil
+	}
+	var rec lockRecord
+	res := m.db.Where("id = 1").Limit(1).Find(&rec)
+	if res.Error != nil || res.RowsAffected == 0 {
+		return "", res.Error
+	}
+	if time.Since(rec.AcquiredAt) > m.opts.StaleAfter {
+		return "", nil
+	}
+	return fmt.Sprintf("%s since %s", rec.Holder, rec.AcquiredAt.Format(time.RFC3339)), nil
+}
@@ -0,0 +1,330 @@
+// Package migrate: 바이너리에 포함된 번호 붙은 up/down SQL 마이그레이션
+// 적용 기록은 schema_migrations 테이블에, 동시에 여러 서버가 마이그레이션하지 않도록 schema_migrations_lock 테이블로 잠급니다.
+package migrate
+
+import (
+	"context"
+	"embed"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+//go:embed sql/*.sql
+var files embed.FS
+
+var (
+	// ErrSchemaTooNew: DB가 이 바이너리가 아는 것보다 새 버전 (새 버전 서버가 마이그레이션한 DB에 옛 서버를 띄운 경우)
+	ErrSchemaTooNew = errors.New("database schema is newer than this binary")
+	// ErrPending: 아직 적용하지 않은 마이그레이션이 있음 (자동 마이그레이션을 끈 경우)
+	ErrPending = errors.New("database has pending migrations")
+	// ErrLockTimeout: 다른 서버가 마이그레이션 중이라 잠금을 얻지 못함
+	ErrLockTimeout = errors.New("timed out waiting for migration lock")
+)
+
+// Migration: 번호 하나의 up/down SQL (sql/0001_name.up.sql, sql/0001_name.down.sql)
+type Migration struct {
+	Version int
+	Name    string
+	Up      string
+	Down    string
+}
+
+// Record: schema_migrations 테이블의 한 줄 (적용된 마이그레이션)
+type Record struct {
+	Version   int       `gorm:"primaryKey;autoIncrement:false"`
+	Name      string    `gorm:"not null"`
+	AppliedAt time.Time `gorm:"not null"`
+}
+
+func (Record) TableName() string { return "schema_migrations" }
+
+// lockRecord: schema_migrations_lock 테이블의 한 줄 (id는 항상 1)
+type lockRecord struct {
+	ID         int `gorm:"primaryKey;autoIncrement:false"`
+	Holder     string
+	AcquiredAt time.Time
+}
+
+func (lockRecord) TableName() string { return "schema_migrations_lock" }
+
+// Status: 마이그레이션 하나의 적용 상태
+type Status struct {
+	Version   int        `json:"version"`
+	Name      string     `json:"name"`
+	AppliedAt *time.Time `json:"applied_at,omitempty"` // nil이면 아직 적용 안 됨
+	Known     bool       `json:"known"`                // false면 DB에는 있지만 이 바이너리에는 없는 (더 새) 마이그레이션
+}
+
+// Options: 잠금 설정
+type Options struct {
+	Holder      string        // 잠금을 잡은 쪽 표시 (기본: hostname:pid)
+	LockTimeout time.Duration // 잠금을 기다리는 최대 시간 (기본 1분)
+	StaleAfter  time.Duration // 이보다 오래된 잠금은 죽은 서버의 것으로 보고 가져옴 (기본 10분)
+	PollEvery   time.Duration // 잠금 재시도 간격 (기본 200ms)
+}
+
+// Migrator: 한 DB에 대한 마이그레이션 실행기
+type Migrator struct {
+	db         *gorm.DB
+	opts       Options
+	migrations []Migration
+}
+
+// 생성자 (바이너리에 포함된 마이그레이션 사용)
+func New(db *gorm.DB, opts Options) *Migrator {
+	migrations, err := Load(files)
+	if err != nil {
+		// 빌드에 포함된 파일이 잘못된 것이므로 실행 중 복구할 방법이 없음
+		panic(err)
+	}
+	return NewWithMigrations(db, opts, migrations)
+}
+
+// NewWithMigrations: 마이그레이션 목록을 직접 넘기는 생성자 (테스트용)
+func NewWithMigrations(db *gorm.DB, opts Options, migrations []Migration) *Migrator {
+	if opts.Holder == "" {
+		host, _ := os.Hostname()
+		opts.Holder = fmt.Sprintf("%s:%d", host, os.Getpid())
+	}
+	if opts.LockTimeout <= 0 {
+		opts.LockTimeout = time.Minute
+	}
+	if opts.StaleAfter <= 0 {
+		opts.StaleAfter = 10 * time.Minute
+	}
+	if opts.PollEvery <= 0 {
+		opts.PollEvery = 200 * time.Millisecond
+	}
+	return &Migrator{db: db, opts: opts, migrations: migrations}
+}
+
+// Load: sql/NNNN_name.up.sql / .down.sql 파일을 번호 순서대로 읽기 (번호마다 up, down 둘 다 있어야 함)
+func Load(fsys fs.FS) ([]Migration, error) {
+	names, err := fs.Glob(fsys, "sql/*.sql")
+	if err != nil {
+		return nil, err
+	}
+	byVersion := map[int]*Migration{}
+	for _, name := range names {
+		base := path.Base(name)
+		var direction string
+		switch {
+		case strings.HasSuffix(base, ".up.sql"):
+			direction = "up"
+		case strings.HasSuffix(base, ".down.sql"):
+			direction = "down"
+		default:
+			return nil, fmt.Errorf("migration %s: must end with .up.sql or .down.sql", base)
+		}
+		stem := strings.TrimSuffix(base, "."+direction+".sql")
+		num, label, ok := strings.Cut(stem, "_")
+		version, err := strconv.Atoi(num)
+		if !ok || err != nil || version <= 0 {
+			return nil, fmt.Errorf("migration %s: name must look like 0001_name.%s.sql", base, direction)
+		}
+		body, err := fs.ReadFile(fsys, name)
+		if err != nil {
+			return nil, err
+		}
+
+		m := byVersion[version]
+		if m == nil {
+			m = &Migration{Version: version, Name: label}
+			byVersion[version] = m
+		} else if m.Name != label {
+			return nil, fmt.Errorf("migration %d: conflicting names %q and %q", version, m.Name, label)
+		}
+		if direction == "up" {
+			m.Up = string(body)
+		} else {
+			m.Down = string(body)
+		}
+	}
+
+	migrations := make([]Migration, 0, len(byVersion))
+	for _, m := range byVersion {
+		if m.Up == "" || m.Down == "" {
+			return nil, fmt.Errorf("migration %04d_%s: both .up.sql and .down.sql are required", m.Version, m.Name)
+		}
+		migrations = append(migrations, *m)
+	}
+	sort.Slice(migrations, func(i, j int) bool { return migrations[i].Version < migrations[j].Version })
+	return migrations, nil
+}
+
+// Latest: 이 바이너리가 아는 가장 높은 버전 (없으면 0)
+func (m *Migrator) Latest() int {
+	if len(m.migrations) == 0 {
+		return 0
+	}
+	return m.migrations[len(m.migrations)-1].Version
+}
+
+// Current: DB에 적용된 가장 높은 버전 (schema_migrations가 없으면 0)
+func (m *Migrator) Current() (int, error) {
+	if !m.db.Migrator().HasTable(&Record{}) {
+		return 0, nil
+	}
+	var version int
+	err := m.db.Model(&Record{}).Select("COALESCE(MAX(version), 0)").Scan(&version).Error
+	return version, err
+}
+
+// Check: 서버 시작 전 확인 (DB가 더 새 버전이면 ErrSchemaTooNew, 덜 적용됐으면 ErrPending)
+func (m *Migrator) Check() error {
+	current, err := m.Current()
+	if err != nil {
+		return err
+	}
+	return m.compare(current)
+}
+
+func (m *Migrator) compare(current int) error {
+	switch latest := m.Latest(); {
+	case current > latest:
+		return fmt.Errorf("%w: database is at version %d, binary knows up to %d", ErrSchemaTooNew, current, latest)
+	case current < latest:
+		return fmt.Errorf("%w: database is at version %d, binary knows up to %d", ErrPending, current, latest)
+	}
+	return nil
+}
+
+// Status: 알고 있는 마이그레이션과 DB에만 있는 마이그레이션의 적용 상태 (버전 순)
+func (m *Migrator) Status() ([]Status, error) {
+	applied, err := m.applied()
+	if err != nil {
+		return nil, err
+	}
+	var result []Status
+	for _, mig := range m.migrations {
+		s := Status{Version: mig.Version, Name: mig.Name, Known: true}
+		if rec, ok := applied[mig.Version]; ok {
+			at := rec.AppliedAt
+			s.AppliedAt = &at
+			delete(applied, mig.Version)
+		}
+		result = append(result, s)
+	}
+	for _, rec := range applied {
+		at := rec.AppliedAt
+		result = append(result, Status{Version: rec.Version, Name: rec.Name, AppliedAt: &at})
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].Version < result[j].Version })
+	return result, nil
+}
+
+func (m *Migrator) applied() (map[int]Record, error) {
+	applied := map[int]Record{}
+	if !m.db.Migrator().HasTable(&Record{}) {
+		return applied, nil
+	}
+	var records []Record
+	if err := m.db.Order("version").Find(&records).Error; err != nil {
+		return nil, err
+	}
+	for _, r := range records {
+		applied[r.Version] = r
+	}
+	return applied, nil
+}
+
+// Up: 적용 안 된 마이그레이션을 버전 순서대로 하나씩(각각 한 트랜잭션) 적용하고, 적용한 목록을 돌려줌
+// 다른 서버가 먼저 잠금을 잡았다면 끝날 때까지 기다린 뒤 남은 것만 적용합니다.
+func (m *Migrator) Up(ctx context.Context) ([]Migration, error) {
+	var done []Migration
+	err := m.withLock(ctx, func() error {
+		applied, err := m.applied()
+		if err != nil {
+			return err
+		}
+		current := 0
+		for v := range applied {
+			current = max(current, v)
+		}
+		if current > m.Latest() {
+			return m.compare(current)
+		}
+		for _, mig := range m.migrations {
+			if _, ok := applied[mig.Version]; ok {
+				continue
+			}
+			if err := ctx.Err(); err != nil {
+				return err
+			}
+			if err := m.apply(mig); err != nil {
+				return err
+			}
+			done = append(done, mig)
+		}
+		return nil
+	})
+	return done, err
+}
+
+// Down: 마지막으로 적용된 마이그레이션부터 n개를 되돌림 (이 바이너리에 없는 버전은 되돌릴 수 없음)
+func (m *Migrator) Down(ctx context.Context, n int) ([]Migration, error) {
+	var done []Migration
+	err := m.withLock(ctx, func() error {
+		applied, err := m.applied()
+		if err != nil {
+			return err
+		}
+		versions := make([]int, 0, len(applied))
+		for v := range applied {
+			versions = append(versions, v)
+		}
+		sort.Sort(sort.Reverse(sort.IntSlice(versions)))
+
+		known := map[int]Migration{}
+		for _, mig := range m.migrations {
+			known[mig.Version] = mig
+		}
+		for _, v := range versions[:min(n, len(versions))] {
+			mig, ok := known[v]
+			if !ok {
+				return fmt.Errorf("%w: cannot revert unknown migration %d (%s)", ErrSchemaTooNew, v, applied[v].Name)
+			}
+			if err := ctx.Err(); err != nil {
+				return err
+			}
+			if err := m.revert(mig); err != nil {
+				return err
+			}
+			done = append(done, mig)
+		}
+		return nil
+	})
+	return done, err
+}
+
+func (m *Migrator) apply(mig Migration) error {
+	return m.db.Transaction(func(tx *gorm.DB) error {
+		if fn := prepare[mig.Version]; fn != nil {
+			if err := fn(tx); err != nil {
+				return fmt.Errorf("migration %04d_%s prepare: %w", mig.Version, mig.Name, err)
+			}
+		}
+		if err := tx.Exec(mig.Up).Error; err != nil {
+			return fmt.Errorf("migration %04d_%s up: %w", mig.Version, mig.Name, err)
+		}
+		return tx.Create(&Record{Version: mig.Version, Name: mig.Name, AppliedAt: time.Now().UTC()}).Error
+	})
+}
+
+func (m *Migrator) revert(mig Migration) error {
+	return m.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Exec(mig.Down).Error; err != nil {
+			return fmt.Errorf("migration %04d_%s down: %w", mig.Version, mig.Name, err)
+		}
+		return tx.Delete(&Record{}, mig.Version).Error
+	})
+}
@@ -0,0 +1,245 @@
+package migrate
+
+import (
+	"context"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"go_study/model"
+
+	"github.com/glebarez/sqlite"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+// 여러 연결(두 서버)이 같은 파일을 쓰는 상황을 흉내 내려고 임시 파일 DB를 씁니다.
+func openTestDB(t *testing.T, file string) *gorm.DB {
+	db, err := gorm.Open(sqlite.Open(file+"?_pragma=busy_timeout(5000)"), &gorm.Config{Logger: logger.Default.LogMode(logger.Silent)})
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		sqlDB, _ := db.DB()
+		sqlDB.Close()
+	})
+	return db
+}
+
+func newTestMigrator(t *testing.T) (*Migrator, *gorm.DB) {
+	db := openTestDB(t, filepath.Join(t.TempDir(), "todos.db"))
+	return New(db, Options{Holder: "test"}), db
+}
+
+// 모델이 바뀌었는데 마이그레이션을 추가하지 않으면 여기서 실패합니다.
+func TestUp_MatchesModels(t *testing.T) {
+	m, db := newTestMigrator(t)
+	applied, err := m.Up(context.Background())
+	require.NoError(t, err)
+	assert.Len(t, applied, m.Latest())
+
+	auto := openTestDB(t, filepath.Join(t.TempDir(), "auto.db"))
+	models := []interface{}{&model.Todo{}, &model.Sequence{}, &model.IdempotencyRecord{}, &model.CalendarToken{}, &model.AuditEvent{}, &model.TodoRevision{},
+		&model.Webhook{}, &model.WebhookDelivery{}, &model.WebhookAttempt{}, &model.OutboxEvent{}, &model.Job{}}
+	require.NoError(t, auto.AutoMigrate(models...))
+
+	type column struct {
+		Name    string
+		Type    string
+		NotNull int
+		Pk      int
+	}
+	for _, mdl := range models {
+		stmt := &gorm.Statement{DB: auto}
+		require.NoError(t, stmt.Parse(mdl))
+		table := stmt.Schema.Table
+
+		var want, got []column
+		auto.Raw("SELECT name, type, \"notnull\" AS not_null, pk FROM pragma_table_info(?) ORDER BY cid", table).Scan(&want)
+		db.Raw("SELECT name, type, \"notnull\" AS not_null, pk FROM pragma_table_info(?) ORDER BY cid", table).Scan(&got)
+		assert.Equal(t, want, got, table)
+
+		var wantIdx, gotIdx []string
+		auto.Raw("SELECT name FROM sqlite_master WHERE type = 'index' AND tbl_name = ? AND sql IS NOT NULL ORDER BY name", table).Scan(&wantIdx)
+		db.Raw("SELECT name FROM sqlite_master WHERE type = 'index' AND tbl_name = ? AND sql IS NOT NULL ORDER BY name", table).Scan(&gotIdx)
+		assert.Equal(t, wantIdx, gotIdx, table)
+	}
+
+	// AutoMigrate가 더 바꿀 것이 없어야 함
+	assert.NoError(t, db.AutoMigrate(models...))
+}
+
+func TestUpDownStatus(t *testing.T) {
+	m, db := newTestMigrator(t)
+	ctx := context.Background()
+
+	// 1. 처음에는 전부 미적용
+	assert.ErrorIs(t, m.Check(), ErrPending)
+	status, err := m.Status()
+	require.NoError(t, err)
+	assert.Len(t, status, m.Latest())
+	for _, s := range status {
+		assert.Nil(t, s.AppliedAt)
+	}
+
+	// 2. up 두 번: 두 번째는 할 일 없음
+	_, err = m.Up(ctx)
+	require.NoError(t, err)
+	again, err := m.Up(ctx)
+	require.NoError(t, err)
+	assert.Empty(t, again)
+	assert.NoError(t, m.Check())
+
+	// 3. down 1: 마지막 하나만 미적용
+	reverted, err := m.Down(ctx, 1)
+	require.NoError(t, err)
+	require.Len(t, reverted, 1)
+	assert.Equal(t, m.Latest(), reverted[0].Version)
+	current, _ := m.Current()
+	assert.Equal(t, m.Latest()-1, current)
+
+	// 4. 전부 down이면 테이블도 사라짐, 다시 up 가능
+	_, err = m.Down(ctx, 100)
+	require.NoError(t, err)
+	assert.False(t, db.Migrator().HasTable("todos"))
+	_, err = m.Up(ctx)
+	require.NoError(t, err)
+	assert.True(t, db.Migrator().HasTable("todos"))
+}
+
+func TestUp_AuditGuards(t *testing.T) {
+	m, db := newTestMigrator(t)
+	_, err := m.Up(context.Background())
+	require.NoError(t, err)
+
+	require.NoError(t, db.Create(&model.AuditEvent{Action: model.AuditTodoCreate}).Error)
+	assert.ErrorContains(t, db.Exec("UPDATE audit_events SET actor = 'x'").Error, "append-only")
+	assert.ErrorContains(t, db.Exec("DELETE FROM audit_events").Error, "append-only")
+}
+
+// AutoMigrate 시절 DB: 그대로 기준 버전이 되고, 완료 시각도 채워짐
+func TestUp_AdoptsAutoMigratedDatabase(t *testing.T) {
+	m, db := newTestMigrator(t)
+	require.NoError(t, db.AutoMigrate(&model.Todo{}, &model.AuditEvent{}))
+	db.Create(&model.Todo{Task: "old", Done: true})
+	db.Model(&model.Todo{}).Where("task = ?", "old").UpdateColumn("completed_at", nil)
+
+	_, err := m.Up(context.Background())
+	require.NoError(t, err)
+
+	var todo model.Todo
+	db.First(&todo)
+	assert.NotNil(t, todo.CompletedAt)
+	assert.NoError(t, m.Check())
+}
+
+// 마이그레이션 도입 전 초기 버전 DB: 빠진 열을 추가하고 값을 채운 뒤 기준 버전이 됨
+func TestUp_UpgradesPreSeriesDatabase(t *testing.T) {
+	m, db := newTestMigrator(t)
+	require.NoError(t, db.Exec("CREATE TABLE `todos` (`id` integer PRIMARY KEY AUTOINCREMENT,`created_at` datetime,`updated_at` datetime,`deleted_at` datetime,`task` text,`done` numeric)").Error)
+	require.NoError(t, db.Exec("CREATE INDEX `idx_todos_deleted_at` ON `todos`(`deleted_at`)").Error)
+	now := time.Now().UTC()
+	for _, task := range []string{"first", "second", "third"} {
+		require.NoError(t, db.Exec("INSERT INTO `todos` (`created_at`,`updated_at`,`task`,`done`) VALUES (?, ?, ?, ?)", now, now, task, task == "second").Error)
+	}
+	db.Exec("UPDATE `todos` SET `deleted_at` = ? WHERE `task` = 'third'", now)
+
+	_, err := m.Up(context.Background())
+	require.NoError(t, err)
+	assert.NoError(t, m.Check())
+
+	// 1. 열 순서와 인덱스가 새로 만든 DB와 같음
+	fresh, freshDB := newTestMigrator(t)
+	_, err = fresh.Up(context.Background())
+	require.NoError(t, err)
+	var want, got []string
+	freshDB.Raw("SELECT name || ' ' || type FROM pragma_table_info('todos') ORDER BY cid").Scan(&want)
+	db.Raw("SELECT name || ' ' || type FROM pragma_table_info('todos') ORDER BY cid").Scan(&got)
+	assert.Equal(t, want, got)
+	freshDB.Raw("SELECT name FROM sqlite_master WHERE type = 'index' AND tbl_name = 'todos' ORDER BY name").Scan(&want)
+	db.Raw("SELECT name FROM sqlite_master WHERE type = 'index' AND tbl_name = 'todos' ORDER BY name").Scan(&got)
+	assert.Equal(t, want, got)
+
+	// 2. 기존 행은 그대로이고 변경 순번은 id 순서대로, 완료된 할 일은 완료 시각도 채워짐
+	var todos []model.Todo
+	require.NoError(t, db.Unscoped().Order("id").Find(&todos).Error)
+	require.Len(t, todos, 3)
+	for i, todo := range todos {
+		assert.Equal(t, int64(i+1), todo.Seq)
+		assert.Empty(t, todo.UID)
+		assert.Zero(t, todo.Priority)
+	}
+	assert.Equal(t, "second", todos[1].Task)
+	assert.NotNil(t, todos[1].CompletedAt)
+	assert.True(t, todos[2].DeletedAt.Valid)
+
+	// 3. 새 변경은 채운 순번 다음부터
+	var issued int64
+	db.Raw("SELECT value FROM sequences WHERE name = 'todos'").Scan(&issued)
+	assert.Equal(t, int64(3), issued)
+}
+
+func TestSchemaTooNew(t *testing.T) {
+	m, db := newTestMigrator(t)
+	_, err := m.Up(context.Background())
+	require.NoError(t, err)
+
+	// 새 버전 서버가 적용한 마이그레이션
+	db.Create(&Record{Version: 999, Name: "future", AppliedAt: time.Now()})
+
+	assert.ErrorIs(t, m.Check(), ErrSchemaTooNew)
+	_, err = m.Up(context.Background())
+	assert.ErrorIs(t, err, ErrSchemaTooNew)
+	_, err = m.Down(context.Background(), 1)
+	assert.ErrorIs(t, err, ErrSchemaTooNew)
+
+	status, _ := m.Status()
+	last := status[len(status)-1]
+	assert.Equal(t, 999, last.Version)
+	assert.False(t, last.Known)
+}
+
+func TestLock(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "todos.db")
+	a := New(openTestDB(t, file), Options{Holder: "a", LockTimeout: 5 * time.Second, PollEvery: 10 * time.Millisecond})
+	b := New(openTestDB(t, file), Options{Holder: "b", LockTimeout: 5 * time.Second, PollEvery: 10 * time.Millisecond})
+
+	// 1. 동시에 시작해도 마이그레이션은 한 번씩만
+	var wg sync.WaitGroup
+	results := make([][]Migration, 2)
+	errs := make([]error, 2)
+	for i, m := range []*Migrator{a, b} {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			results[i], errs[i] = m.Up(context.Background())
+		}()
+	}
+	wg.Wait()
+	require.NoError(t, errs[0])
+	require.NoError(t, errs[1])
+	assert.Equal(t, a.Latest(), len(results[0])+len(results[1]))
+
+	// 2. 다른 서버가 잠금을 잡고 있으면 기다리다 포기
+	db := openTestDB(t, file)
+	db.Create(&lockRecord{ID: 1, Holder: "other", AcquiredAt: time.Now().UTC()})
+	short := New(db, Options{Holder: "c", LockTimeout: 50 * time.Millisecond, PollEvery: 10 * time.Millisecond})
+	_, err := short.Up(context.Background())
+	assert.ErrorIs(t, err, ErrLockTimeout)
+	assert.ErrorContains(t, err, "other")
+	holder, err := short.Holder()
+	assert.NoError(t, err)
+	assert.Contains(t, holder, "other since ")
+
+	// 3. 오래된 잠금(죽은 서버)은 가져오고, 끝나면 풀어줌
+	db.Model(&lockRecord{}).Where("id = 1").Update("acquired_at", time.Now().UTC().Add(-time.Hour))
+	stale := New(db, Options{Holder: "d", StaleAfter: time.Minute, LockTimeout: 50 * time.Millisecond})
+	holder, _ = stale.Holder()
+	assert.Empty(t, holder)
+	_, err = stale.Up(context.Background())
+	assert.NoError(t, err)
+	var count int64
+	db.Model(&lockRecord{}).Count(&count)
+	assert.Zero(t, count)
+}
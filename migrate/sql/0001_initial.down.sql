@@ -0,0 +1,11 @@
+DROP TABLE IF EXISTS `jobs`;
+DROP TABLE IF EXISTS `outbox_events`;
+DROP TABLE IF EXISTS `webhook_attempts`;
+DROP TABLE IF EXISTS `webhook_deliveries`;
+DROP TABLE IF EXISTS `webhooks`;
+DROP TABLE IF EXISTS `todo_revisions`;
+DROP TABLE IF EXISTS `audit_events`;
+DROP TABLE IF EXISTS `calendar_tokens`;
+DROP TABLE IF EXISTS `idempotency_records`;
+DROP TABLE IF EXISTS `sequences`;
+DROP TABLE IF EXISTS `todos`;
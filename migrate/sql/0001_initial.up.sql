@@ -0,0 +1,50 @@
+-- 초기 스키마: AutoMigrate 시절 마지막 버전과 같은 테이블 / 인덱스
+-- IF NOT EXISTS라서 AutoMigrate로 만들어진 기존 DB에서도 그대로 기준 버전이 됩니다.
+-- 열이 빠진 옛 todos(초기 버전)는 이 SQL 전에 빠진 열을 추가하고 값을 채웁니다 (migrate/legacy.go, SQLite SQL로는 "없으면 추가"를 할 수 없음).
+CREATE TABLE IF NOT EXISTS `todos` (`id` integer PRIMARY KEY AUTOINCREMENT,`created_at` datetime,`updated_at` datetime,`deleted_at` datetime,`task` text,`done` numeric,`priority` integer,`due_at` datetime,`completed_at` datetime,`seq` integer,`uid` text);
+CREATE INDEX IF NOT EXISTS `idx_todos_uid` ON `todos`(`uid`);
+CREATE INDEX IF NOT EXISTS `idx_todos_seq` ON `todos`(`seq`);
+CREATE INDEX IF NOT EXISTS `idx_todos_completed_at` ON `todos`(`completed_at`);
+CREATE INDEX IF NOT EXISTS `idx_todos_deleted_at` ON `todos`(`deleted_at`);
+
+CREATE TABLE IF NOT EXISTS `sequences` (`name` text,`value` integer,PRIMARY KEY (`name`));
+-- 기존 할 일에 채운 변경 순번 다음 번호부터 발급되도록
+INSERT INTO `sequences` (`name`, `value`) SELECT 'todos', MAX(`seq`) FROM `todos` WHERE true HAVING MAX(`seq`) IS NOT NULL
+	ON CONFLICT(`name`) DO UPDATE SET `value` = MAX(`value`, excluded.`value`);
+
+CREATE TABLE IF NOT EXISTS `idempotency_records` (`id` integer PRIMARY KEY AUTOINCREMENT,`idem_key` text,`scope` text,`request_hash` text,`status_code` integer,`content_type` text,`body` blob,`created_at` datetime,`expires_at` datetime);
+CREATE INDEX IF NOT EXISTS `idx_idempotency_records_expires_at` ON `idempotency_records`(`expires_at`);
+CREATE UNIQUE INDEX IF NOT EXISTS `idx_idem_key_scope` ON `idempotency_records`(`idem_key`,`scope`);
+
+CREATE TABLE IF NOT EXISTS `calendar_tokens` (`id` integer PRIMARY KEY AUTOINCREMENT,`name` text,`token` text,`created_at` datetime);
+CREATE UNIQUE INDEX IF NOT EXISTS `idx_calendar_tokens_token` ON `calendar_tokens`(`token`);
+
+CREATE TABLE IF NOT EXISTS `audit_events` (`id` integer PRIMARY KEY AUTOINCREMENT,`created_at` datetime,`actor` text,`ip` text,`request_id` text,`node` text,`action` text,`entity_type` text,`entity_id` text,`method` text,`path` text,`status` integer,`before` blob,`after` blob);
+CREATE INDEX IF NOT EXISTS `idx_audit_events_entity_id` ON `audit_events`(`entity_id`);
+CREATE INDEX IF NOT EXISTS `idx_audit_events_action` ON `audit_events`(`action`);
+CREATE INDEX IF NOT EXISTS `idx_audit_events_request_id` ON `audit_events`(`request_id`);
+CREATE INDEX IF NOT EXISTS `idx_audit_events_actor` ON `audit_events`(`actor`);
+CREATE INDEX IF NOT EXISTS `idx_audit_events_created_at` ON `audit_events`(`created_at`);
+
+CREATE TABLE IF NOT EXISTS `todo_revisions` (`id` integer PRIMARY KEY AUTOINCREMENT,`todo_id` integer,`rev` integer,`seq` integer,`action` text,`created_at` datetime,`task` text,`done` numeric,`priority` integer,`due_at` datetime);
+CREATE INDEX IF NOT EXISTS `idx_todo_revisions_seq` ON `todo_revisions`(`seq`);
+CREATE UNIQUE INDEX IF NOT EXISTS `idx_todo_revision` ON `todo_revisions`(`todo_id`,`rev`);
+
+CREATE TABLE IF NOT EXISTS `webhooks` (`id` integer PRIMARY KEY AUTOINCREMENT,`created_at` datetime,`updated_at` datetime,`url` text,`events` text,`secret` text,`active` numeric);
+
+CREATE TABLE IF NOT EXISTS `webhook_deliveries` (`id` integer PRIMARY KEY AUTOINCREMENT,`created_at` datetime,`updated_at` datetime,`webhook_id` integer,`event` text,`payload` text,`status` text,`attempts` integer,`next_attempt_at` datetime,`last_status_code` integer,`last_error` text,`delivered_at` datetime);
+CREATE INDEX IF NOT EXISTS `idx_delivery_due` ON `webhook_deliveries`(`status`,`next_attempt_at`);
+CREATE INDEX IF NOT EXISTS `idx_webhook_deliveries_webhook_id` ON `webhook_deliveries`(`webhook_id`);
+
+CREATE TABLE IF NOT EXISTS `webhook_attempts` (`id` integer PRIMARY KEY AUTOINCREMENT,`created_at` datetime,`delivery_id` integer,`status_code` integer,`error` text,`duration_ms` integer);
+CREATE INDEX IF NOT EXISTS `idx_webhook_attempts_delivery_id` ON `webhook_attempts`(`delivery_id`);
+
+CREATE TABLE IF NOT EXISTS `outbox_events` (`id` integer PRIMARY KEY AUTOINCREMENT,`created_at` datetime,`event` text,`todo_id` integer,`payload` text,`sent_at` datetime,`attempts` integer,`last_error` text);
+CREATE INDEX IF NOT EXISTS `idx_outbox_events_sent_at` ON `outbox_events`(`sent_at`);
+CREATE INDEX IF NOT EXISTS `idx_outbox_events_todo_id` ON `outbox_events`(`todo_id`);
+
+CREATE TABLE IF NOT EXISTS `jobs` (`id` integer PRIMARY KEY AUTOINCREMENT,`created_at` datetime,`updated_at` datetime,`type` text,`key` text,`payload` text,`status` text,`attempts` integer,`max_attempts` integer,`run_at` datetime,`locked_by` text,`locked_until` datetime,`last_error` text,`finished_at` datetime);
+CREATE INDEX IF NOT EXISTS `idx_jobs_run_at` ON `jobs`(`run_at`);
+CREATE INDEX IF NOT EXISTS `idx_jobs_status` ON `jobs`(`status`);
+CREATE UNIQUE INDEX IF NOT EXISTS `idx_jobs_key` ON `jobs`(`key`);
+CREATE INDEX IF NOT EXISTS `idx_jobs_type` ON `jobs`(`type`);
@@ -0,0 +1,5 @@
+-- 감사 로그는 추가만 가능: UPDATE / DELETE는 트리거로 막음
+CREATE TRIGGER IF NOT EXISTS audit_events_no_UPDATE BEFORE UPDATE ON audit_events
+BEGIN SELECT RAISE(ABORT, 'audit_events is append-only'); END;
+CREATE TRIGGER IF NOT EXISTS audit_events_no_DELETE BEFORE DELETE ON audit_events
+BEGIN SELECT RAISE(ABORT, 'audit_events is append-only'); END;
@@ -0,0 +1 @@
+-- 데이터 채우기만 했으므로 되돌릴 스키마 변경 없음 (채운 값은 그대로 둠)
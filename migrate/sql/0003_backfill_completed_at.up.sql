@@ -0,0 +1,2 @@
+-- 완료 시각 컬럼이 생기기 전에 완료된 할 일은 마지막 수정 시각으로 채움
+UPDATE todos SET completed_at = updated_at WHERE done AND completed_at IS NULL;
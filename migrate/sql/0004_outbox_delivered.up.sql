@@ -0,0 +1,2 @@
+-- outbox 이벤트를 이미 받은 구독자 (구독자 하나가 실패해도 받은 구독자에게는 다시 보내지 않음)
+ALTER TABLE `outbox_events` ADD COLUMN `delivered` text;
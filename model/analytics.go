@@ -0,0 +1,47 @@
+package model
+
+// LeadTime: 생성부터 완료까지 걸린 시간(초) 분포
+type LeadTime struct {
+	Count int     `json:"count" example:"12"` // 기간 안에 완료된 할 일 수
+	P50   float64 `json:"p50" example:"3600"`
+	P75   float64 `json:"p75"`
+	P90   float64 `json:"p90"`
+	P95   float64 `json:"p95"`
+	Max   float64 `json:"max"`
+}
+
+// WeekCount: 한 주(월요일 시작) 동안 완료한 할 일 수
+type WeekCount struct {
+	WeekStart string `json:"week_start" example:"2025-01-06"`
+	Completed int    `json:"completed"`
+}
+
+// BurndownPoint: 그날이 끝날 때 남아 있던 할 일 수
+type BurndownPoint struct {
+	Date      string `json:"date" example:"2025-01-06"`
+	Remaining int    `json:"remaining"`
+}
+
+// Streaks: 하루에 하나 이상 완료한 날이 이어진 일수
+type Streaks struct {
+	Current       int    `json:"current" example:"3"` // 오늘(또는 어제)까지 이어지는 연속 일수
+	Longest       int    `json:"longest" example:"7"`
+	LastCompleted string `json:"last_completed,omitempty" example:"2025-01-08"` // 마지막으로 완료한 날
+}
+
+// ProjectBurndown: 프로젝트 하나의 번다운
+type ProjectBurndown struct {
+	Project string          `json:"project" example:"+work"`
+	Points  []BurndownPoint `json:"points"`
+}
+
+// AnalyticsResult: GET /analytics 응답
+type AnalyticsResult struct {
+	TimeZone   string           `json:"time_zone" example:"Asia/Seoul"`
+	From       string           `json:"from" example:"2024-10-14"` // 분석 시작일 (월요일)
+	To         string           `json:"to" example:"2025-01-05"`   // 분석 마지막 날 (오늘)
+	LeadTime   LeadTime         `json:"lead_time"`
+	Throughput []WeekCount      `json:"throughput"`
+	Burndown   *ProjectBurndown `json:"burndown,omitempty"` // project를 지정했을 때만
+	Streaks    Streaks          `json:"streaks"`
+}
@@ -0,0 +1,36 @@
+package model
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// 감사 로그 동작 종류
+const (
+	AuditTodoCreate  = "todo.create"
+	AuditTodoUpdate  = "todo.update"
+	AuditTodoDelete  = "todo.delete"
+	AuditTodoRestore = "todo.restore"
+	AuditTodoRevert  = "todo.revert" // 특정 리비전으로 되돌리기
+	AuditTodoUndo    = "todo.undo"   // POST /undo (직전 변경 취소)
+	AuditAdmin       = "admin.request"
+)
+
+// AuditEvent: 누가, 언제, 어디서(IP/노드), 무엇을 바꿨는지 남기는 추가 전용(append-only) 기록
+// 수정/삭제는 DB 트리거로 막혀 있습니다. (migrate/sql/0002_audit_guards.up.sql)
+type AuditEvent struct {
+	ID         uint            `gorm:"primaryKey" json:"id"`
+	CreatedAt  time.Time       `gorm:"index" json:"created_at"`
+	Actor      string          `gorm:"index" json:"actor" example:"gyong97"` // 요청자 (X-Actor 헤더, 없으면 anonymous)
+	IP         string          `json:"ip" example:"172.18.0.1"`              // 요청자 IP
+	RequestID  string          `gorm:"index" json:"request_id"`              // X-Request-ID
+	Node       string          `json:"node" example:"server-1"`              // 요청을 처리한 서버 hostname
+	Action     string          `gorm:"index" json:"action" example:"todo.delete"`
+	EntityType string          `json:"entity_type" example:"todo"`
+	EntityID   string          `gorm:"index" json:"entity_id" example:"3"`
+	Method     string          `json:"method" example:"DELETE"`
+	Path       string          `json:"path" example:"/todos/3"`
+	Status     int             `json:"status" example:"200"`
+	Before     json.RawMessage `json:"before" swaggertype:"object"` // 변경 전 스냅샷 (생성이면 null)
+	After      json.RawMessage `json:"after" swaggertype:"object"`  // 변경 후 스냅샷 (삭제면 null)
+}
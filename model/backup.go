@@ -0,0 +1,10 @@
+package model
+
+import "time"
+
+// BackupInfo: 백업 파일 하나
+type BackupInfo struct {
+	Name      string    `json:"name" example:"todos-20250102T150405.000Z.db"`
+	SizeBytes int64     `json:"size_bytes" example:"40960"`
+	CreatedAt time.Time `json:"created_at"`
+}
@@ -0,0 +1,17 @@
+package model
+
+import "time"
+
+// CalendarToken: 달력 구독 주소(/calendar/{token}.ics)에 쓰이는 비밀 토큰
+// 토큰을 아는 사람만 피드를 볼 수 있으므로 사용자(또는 기기)마다 따로 발급하고, 유출되면 삭제합니다.
+type CalendarToken struct {
+	ID        uint      `gorm:"primaryKey" json:"id"`
+	Name      string    `json:"name" example:"gyong97 iPhone"` // 누구에게 발급했는지 (메모용)
+	Token     string    `gorm:"uniqueIndex" json:"token"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// CreateCalendarTokenInput: 달력 토큰 발급 요청
+type CreateCalendarTokenInput struct {
+	Name string `json:"name" binding:"required" example:"gyong97 iPhone"`
+}
@@ -0,0 +1,29 @@
+package model
+
+import "time"
+
+// NodeStatus: 서버 하나의 상태 (heartbeat 본문)
+type NodeStatus struct {
+	Name          string    `json:"name" example:"server-1"`
+	Role          string    `json:"role" example:"active"`
+	Term          int64     `json:"term" example:"3"` // Active 임기
+	Version       string    `json:"version" example:"v1.2.3"`
+	StartedAt     time.Time `json:"started_at"`
+	UptimeSeconds int64     `json:"uptime_seconds" example:"3600"`
+}
+
+// PeerStatus: peer에 대해 마지막으로 알고 있는 것
+type PeerStatus struct {
+	URL             string      `json:"url,omitempty" example:"http://app-2:8080"`
+	Reachable       bool        `json:"reachable"` // peer_timeout 안에 heartbeat를 주고받았는지
+	LastHeartbeatAt *time.Time  `json:"last_heartbeat_at,omitempty"`
+	LastError       string      `json:"last_error,omitempty"`
+	Node            *NodeStatus `json:"node,omitempty"`
+}
+
+// ClusterStatus: GET /admin/cluster 응답
+type ClusterStatus struct {
+	Self        NodeStatus   `json:"self"`
+	Peers       []PeerStatus `json:"peers"`
+	LeaseHolder string       `json:"lease_holder" example:"server-1"` // 지금 Active로 인정되는 서버 (없으면 빈 값)
+}
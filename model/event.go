@@ -0,0 +1,12 @@
+package model
+
+// 할 일 변경 이벤트 종류 (웹훅 구독 단위)
+const (
+	EventTodoCreated  = "todo.created"
+	EventTodoUpdated  = "todo.updated"
+	EventTodoDeleted  = "todo.deleted"
+	EventTodoRestored = "todo.restored"
+)
+
+// EventTypes: 구독할 수 있는 모든 이벤트
+var EventTypes = []string{EventTodoCreated, EventTodoUpdated, EventTodoDeleted, EventTodoRestored}
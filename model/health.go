@@ -0,0 +1,30 @@
+package model
+
+import "time"
+
+// LiveStatus: GET /health/live 응답
+type LiveStatus struct {
+	Status        string `json:"status" example:"alive"`
+	Role          string `json:"role" example:"standby"`
+	Node          string `json:"node" example:"server-2"`
+	UptimeSeconds int64  `json:"uptime_seconds" example:"3600"`
+}
+
+// HealthResult: readiness 점검 하나의 결과
+type HealthResult struct {
+	Name          string      `json:"name" example:"database"`
+	Status        string      `json:"status" example:"ok"`
+	LatencyMs     float64     `json:"latency_ms" example:"0.42"`
+	Detail        interface{} `json:"detail,omitempty"`
+	Error         string      `json:"error,omitempty"`      // 이번 점검의 에러
+	LastError     string      `json:"last_error,omitempty"` // 가장 최근에 실패했을 때의 에러 (지금은 정상이어도 남음)
+	LastErrorAt   *time.Time  `json:"last_error_at,omitempty"`
+	LastSuccessAt *time.Time  `json:"last_success_at,omitempty"`
+}
+
+// HealthReport: 전체 점검 결과 (GET /health/details)
+type HealthReport struct {
+	Ready     bool           `json:"ready"`
+	CheckedAt time.Time      `json:"checked_at"`
+	Checks    []HealthResult `json:"checks"`
+}
@@ -0,0 +1,17 @@
+package model
+
+import "time"
+
+// IdempotencyRecord: Idempotency-Key 별로 최초 응답(상태 코드 + 바디)을 저장해두는 테이블
+// 두 서버가 같은 DB 파일을 쓰기 때문에 Active/Standby 어느 쪽으로 재시도가 와도 같은 응답을 돌려줄 수 있습니다.
+type IdempotencyRecord struct {
+	ID          uint      `gorm:"primaryKey" json:"id"`
+	Key         string    `gorm:"column:idem_key;uniqueIndex:idx_idem_key_scope;size:255" json:"key"`
+	Scope       string    `gorm:"uniqueIndex:idx_idem_key_scope" json:"scope"` // 키가 적용되는 범위 (예: "gyong97 POST /todos", 요청자 + 메소드 + 경로)
+	RequestHash string    `json:"-"`                                           // 요청 바디의 SHA-256 (다른 바디로 재사용했는지 판별)
+	StatusCode  int       `json:"status_code"`                                 // 0이면 아직 처리 중
+	ContentType string    `json:"-"`
+	Body        []byte    `json:"-"`
+	CreatedAt   time.Time `json:"created_at"`
+	ExpiresAt   time.Time `gorm:"index" json:"expires_at"` // TTL이 지나면 새 요청으로 취급
+}
@@ -0,0 +1,46 @@
+package model
+
+import "time"
+
+// 작업 상태
+const (
+	JobQueued    = "queued"    // 실행 대기 (run_at이 지나면 실행)
+	JobRunning   = "running"   // 어떤 서버가 가져가서 실행 중 (locked_until까지)
+	JobSucceeded = "succeeded" // 완료
+	JobFailed    = "failed"    // 재시도 한도를 넘겨서 포기 (GET /admin/queue에서 다시 시도 가능)
+)
+
+// 작업 종류
+const (
+	JobDailyReport        = "report.daily"        // POST /reports
+	JobStatsReport        = "stats.report"        // 1분마다 통계 알림
+	JobIdempotencyCleanup = "idempotency.cleanup" // 만료된 Idempotency-Key 정리
+	JobOutboxCleanup      = "outbox.cleanup"      // 보낸 outbox 이벤트 정리
+	JobQueueCleanup       = "jobs.cleanup"        // 끝난 작업 정리
+	JobBackup             = "db.backup"           // 주기 DB 백업 + 오래된 백업 정리
+)
+
+// Job: DB에 저장되는 백그라운드 작업 (재시작해도 사라지지 않음)
+// 실행 중인 서버가 죽으면 locked_until이 지난 뒤 다른 서버(승격된 Standby 등)가 다시 가져갑니다.
+type Job struct {
+	ID          uint       `gorm:"primaryKey" json:"id"`
+	CreatedAt   time.Time  `json:"created_at"`
+	UpdatedAt   time.Time  `json:"updated_at"`
+	Type        string     `gorm:"index" json:"type" example:"report.daily"`
+	Key         *string    `gorm:"uniqueIndex" json:"key,omitempty"` // 같은 키의 작업은 하나만 (주기 작업 중복 방지)
+	Payload     string     `json:"payload"`                          // 작업 입력 JSON
+	Status      string     `gorm:"index" json:"status" example:"queued"`
+	Attempts    int        `json:"attempts"`
+	MaxAttempts int        `json:"max_attempts"`
+	RunAt       time.Time  `gorm:"index" json:"run_at"` // 이 시각 이후에 실행 (재시도 백오프)
+	LockedBy    string     `json:"locked_by,omitempty"` // 실행 중인 서버 (hostname:pid)
+	LockedUntil *time.Time `json:"locked_until,omitempty"`
+	LastError   string     `json:"last_error,omitempty"`
+	FinishedAt  *time.Time `json:"finished_at,omitempty"`
+}
+
+// QueueOverview: GET /admin/queue 응답
+type QueueOverview struct {
+	Counts map[string]int64 `json:"counts"` // 상태별 작업 수 (queued, running, succeeded, failed)
+	Jobs   []Job            `json:"jobs"`
+}
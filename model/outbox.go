@@ -0,0 +1,18 @@
+package model
+
+import "time"
+
+// OutboxEvent: 할 일 변경과 같은 트랜잭션에서 저장되는 발행 대기 이벤트 (transactional outbox)
+// outbox.Dispatcher가 모든 구독자에게 넘긴 뒤 SentAt을 채웁니다. 넘기다 실패하면 Attempts가 늘고 다음에 다시 시도합니다.
+// 이미 받은 구독자는 Delivered에 남겨서 다시 시도할 때 건너뜁니다.
+type OutboxEvent struct {
+	ID        uint       `gorm:"primaryKey" json:"id"`
+	CreatedAt time.Time  `json:"created_at"`
+	Event     string     `json:"event" example:"todo.created"` // model.EventTodo*
+	TodoID    uint       `gorm:"index" json:"todo_id"`
+	Payload   string     `json:"payload"` // 변경 직후 할 일 JSON
+	SentAt    *time.Time `gorm:"index" json:"sent_at"`
+	Attempts  int        `json:"attempts"`
+	LastError string     `json:"last_error,omitempty"`
+	Delivered []string   `gorm:"serializer:json" json:"delivered,omitempty"` // 이미 받은 구독자 이름
+}
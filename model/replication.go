@@ -0,0 +1,59 @@
+package model
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// ReplicatedTodo: 복제용 할 일 (API 응답에서 숨기는 updated_at, deleted_at까지 전부)
+type ReplicatedTodo struct {
+	Todo
+	UpdatedAt time.Time  `json:"updated_at"`
+	DeletedAt *time.Time `json:"deleted_at"`
+}
+
+// NewReplicatedTodo: 할 일 행 전체를 복제용으로
+func NewReplicatedTodo(t Todo) ReplicatedTodo {
+	r := ReplicatedTodo{Todo: t, UpdatedAt: t.UpdatedAt}
+	if t.DeletedAt.Valid {
+		deletedAt := t.DeletedAt.Time
+		r.DeletedAt = &deletedAt
+	}
+	return r
+}
+
+// Row: DB에 그대로 넣을 할 일 행
+func (r ReplicatedTodo) Row() Todo {
+	t := r.Todo
+	t.UpdatedAt = r.UpdatedAt
+	t.DeletedAt = gorm.DeletedAt{}
+	if r.DeletedAt != nil {
+		t.DeletedAt = gorm.DeletedAt{Time: *r.DeletedAt, Valid: true}
+	}
+	return t
+}
+
+// ChangeBatch: Active가 Standby에게 보내는 변경분 (since < seq <= To)
+// 같은 할 일이 여러 번 바뀌었으면 마지막 상태만 들어 있습니다.
+type ChangeBatch struct {
+	Since     int64            `json:"since" example:"40"`
+	To        int64            `json:"to" example:"42"`   // 이 배치를 적용하면 복제본이 도달하는 변경 순번
+	Head      int64            `json:"head" example:"42"` // Active의 현재 변경 순번 (To < Head면 더 받을 게 있음)
+	Todos     []ReplicatedTodo `json:"todos"`
+	Revisions []TodoRevision   `json:"revisions"`
+}
+
+// ReplicationStatus: 복제 상태 (health details, GET /admin/replication)
+type ReplicationStatus struct {
+	Source        string     `json:"source" example:"http://app-1:8080"`
+	Running       bool       `json:"running"`                   // 따라가는 중 (Active이거나 다시 채워야 하면 false)
+	AppliedSeq    int64      `json:"applied_seq" example:"40"`  // 이 DB가 반영한 변경 순번
+	SourceSeq     int64      `json:"source_seq" example:"42"`   // 마지막으로 본 Active의 변경 순번
+	LagSeq        int64      `json:"lag_seq" example:"2"`       // 아직 못 받은 변경 수 (순번 차이)
+	LagSeconds    float64    `json:"lag_seconds" example:"1.5"` // 마지막으로 Active와 같은 순번임을 확인한 뒤 지난 시간 (변경이 없어도 long_poll만큼은 쌓임)
+	LastContactAt *time.Time `json:"last_contact_at,omitempty"` // 마지막으로 Active와 통신한 시각
+	CaughtUpAt    *time.Time `json:"caught_up_at,omitempty"`    // 마지막으로 Active와 같은 순번이었던 시각
+	LastError     string     `json:"last_error,omitempty"`
+	NeedsReseed   bool       `json:"needs_reseed,omitempty"` // Active인 동안 로컬 변경이 있어서 이어서 따라갈 수 없음 (새 Active의 백업으로 복원 후 재시작)
+}
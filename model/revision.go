@@ -0,0 +1,47 @@
+package model
+
+import "time"
+
+// 리비전이 만들어진 이유
+const (
+	RevisionCreate  = "create"
+	RevisionUpdate  = "update"
+	RevisionDelete  = "delete"
+	RevisionRestore = "restore"
+	RevisionRevert  = "revert"
+)
+
+// TodoRevision: 할 일이 바뀔 때마다 남기는 그 시점의 내용 (변경과 같은 트랜잭션에서 저장)
+// Rev는 할 일마다 1부터 다시 시작하는 번호이고, Seq는 그 변경의 전역 변경 순번입니다.
+type TodoRevision struct {
+	ID        uint       `gorm:"primaryKey" json:"-"`
+	TodoID    uint       `gorm:"uniqueIndex:idx_todo_revision" json:"todo_id" example:"3"`
+	Rev       int        `gorm:"uniqueIndex:idx_todo_revision" json:"rev" example:"2"`
+	Seq       int64      `gorm:"index" json:"seq" example:"42"`
+	Action    string     `json:"action" example:"update"` // create | update | delete | restore | revert
+	CreatedAt time.Time  `json:"created_at"`
+	Task      string     `json:"task"`
+	Done      bool       `json:"done"`
+	Priority  int        `json:"priority"`
+	DueAt     *time.Time `json:"due_at"`
+}
+
+// FieldChange: 이전 리비전과 달라진 필드 하나
+type FieldChange struct {
+	Field string      `json:"field" example:"task"`
+	From  interface{} `json:"from"` // 첫 리비전이면 null
+	To    interface{} `json:"to"`
+}
+
+// RevisionEntry: 리비전 + 직전 리비전과의 차이
+type RevisionEntry struct {
+	TodoRevision
+	Changes []FieldChange `json:"changes"`
+}
+
+// UndoResult: POST /undo 응답
+type UndoResult struct {
+	Undone string `json:"undone" example:"todo.update"` // 취소한 변경의 감사 로그 동작
+	TodoID uint   `json:"todo_id" example:"3"`
+	Todo   *Todo  `json:"todo"` // 취소 후 상태 (생성을 취소했으면 null)
+}
@@ -0,0 +1,8 @@
+package model
+
+// Sequence: 이름별로 1씩 증가하는 카운터 (할 일 변경 순번 발급용)
+// 두 서버가 같은 DB 파일을 쓰므로 메모리가 아니라 DB에서 번호를 발급해야 겹치지 않습니다.
+type Sequence struct {
+	Name  string `gorm:"primaryKey"`
+	Value int64
+}
@@ -0,0 +1,57 @@
+package model
+
+import "strings"
+
+// TodoSummary: 할 일 전체 집계
+type TodoSummary struct {
+	Total   int64 `json:"total" example:"10"`
+	Done    int64 `json:"done" example:"4"`
+	Overdue int64 `json:"overdue" example:"1"` // 마감이 지났는데 아직 안 끝난 할 일
+	// 완료된 할 일의 평균 소요 시간(초), 완료된 게 없으면 0
+	AvgCompletionSeconds float64 `json:"avg_completion_seconds" example:"86400"`
+}
+
+// DailyCount: 하루 동안 만들어진 / 완료된 할 일 수 (날짜는 UTC 기준 YYYY-MM-DD)
+type DailyCount struct {
+	Date      string `json:"date" example:"2025-01-02"`
+	Created   int64  `json:"created"`
+	Completed int64  `json:"completed"`
+}
+
+// TagCount: 프로젝트(+이름) / 컨텍스트(@이름)별 할 일 수
+type TagCount struct {
+	Name  string `json:"name" example:"+work"`
+	Total int64  `json:"total"`
+	Done  int64  `json:"done"`
+}
+
+// TagsOf: task 문장 속의 todo.txt 식 태그 (+프로젝트, @컨텍스트)
+// 같은 태그가 여러 번 나와도 한 번만 돌려줍니다.
+func TagsOf(task string) (projects, contexts []string) {
+	seen := map[string]bool{}
+	for _, word := range strings.Fields(task) {
+		if len(word) < 2 || seen[word] {
+			continue
+		}
+		switch word[0] {
+		case '+':
+			projects = append(projects, word)
+		case '@':
+			contexts = append(contexts, word)
+		default:
+			continue
+		}
+		seen[word] = true
+	}
+	return projects, contexts
+}
+
+// DashboardData: GET /dashboard 응답
+type DashboardData struct {
+	TodoSummary
+	CompletionRate float64      `json:"completion_rate" example:"40"` // 완료율(%), 할 일이 없으면 0
+	Days           int          `json:"days" example:"7"`
+	Daily          []DailyCount `json:"daily"`    // 최근 days일 (오래된 날부터, 빈 날은 0)
+	Projects       []TagCount   `json:"projects"` // task 속 +프로젝트별
+	Contexts       []TagCount   `json:"contexts"` // task 속 @컨텍스트별
+}
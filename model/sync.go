@@ -0,0 +1,53 @@
+package model
+
+import "time"
+
+// SyncTombstone: 삭제된 할 일 표시 (클라이언트는 로컬에서도 지움)
+type SyncTombstone struct {
+	ID        uint      `json:"id" example:"3"`
+	UID       string    `json:"uid,omitempty"`
+	Seq       int64     `json:"seq" example:"42"`
+	DeletedAt time.Time `json:"deleted_at"`
+}
+
+// SyncPullResult: GET /sync 응답
+type SyncPullResult struct {
+	Token   string          `json:"token" example:"42"` // 다음 요청의 since 값
+	Full    bool            `json:"full"`               // true면 전체 목록 (클라이언트는 로컬 데이터를 통째로 교체)
+	Changed []Todo          `json:"changed"`
+	Deleted []SyncTombstone `json:"deleted"`
+}
+
+// SyncChange: 클라이언트가 오프라인 동안 쌓아둔 변경 하나
+type SyncChange struct {
+	ClientID  string     `json:"client_id" example:"local-7"` // 클라이언트 쪽 식별자 (결과를 맞춰보는 용도)
+	ID        uint       `json:"id" example:"0"`              // 0이면 새로 만들기
+	BaseSeq   int64      `json:"base_seq" example:"40"`       // 클라이언트가 마지막으로 본 이 항목의 seq
+	Deleted   bool       `json:"deleted"`
+	Task      string     `json:"task" example:"오프라인에서 추가한 일"`
+	Done      bool       `json:"done"`
+	Priority  int        `json:"priority" binding:"min=0,max=9"`
+	DueAt     *time.Time `json:"due_at"`
+	UpdatedAt time.Time  `json:"updated_at"` // 클라이언트에서 수정한 시각 (lww 비교용)
+}
+
+// SyncPushInput: POST /sync 요청
+type SyncPushInput struct {
+	Strategy string       `json:"strategy" binding:"omitempty,oneof=lww report" example:"lww"` // 기본값 lww
+	Changes  []SyncChange `json:"changes" binding:"required,dive"`
+}
+
+// SyncChangeResult: 변경 하나의 처리 결과
+type SyncChangeResult struct {
+	ClientID string `json:"client_id"`
+	ID       uint   `json:"id"`
+	Status   string `json:"status" example:"updated"`
+	Todo     *Todo  `json:"todo,omitempty"` // 적용된 결과 (충돌이면 서버 쪽 현재 값)
+	Message  string `json:"message,omitempty"`
+}
+
+// SyncPushResult: POST /sync 응답
+type SyncPushResult struct {
+	Token   string             `json:"token"`
+	Results []SyncChangeResult `json:"results"`
+}
@@ -16,4 +16,43 @@ type Todo struct {
 
 	Task string `json:"task"`
 	Done bool   `json:"done"`
+
+	Priority int        `json:"priority"` // 0: 미지정, 1(가장 높음) ~ 9(가장 낮음) - iCalendar PRIORITY와 같은 범위
+	DueAt    *time.Time `json:"due_at"`   // 마감 시각 (없으면 null)
+
+	// 완료 시각: 완료될 때 기록되고 다시 열면 지워짐 (미완료면 null)
+	CompletedAt *time.Time `gorm:"index" json:"completed_at"`
+
+	// 변경 순번: 생성/수정/삭제될 때마다 전체 할 일 중 가장 큰 값으로 바뀜 (오프라인 동기화용)
+	Seq int64 `gorm:"index" json:"seq"`
+
+	// iCalendar UID (CalDAV 클라이언트가 만든 항목만 값이 있음, 비어 있으면 "todo-{id}@go-todo-api"로 취급)
+	UID string `gorm:"index" json:"uid,omitempty"`
+}
+
+// CompletionTime: 완료 시각 (완료 시각을 기록하기 전에 완료된 할 일이면 마지막 수정 시각)
+func (t Todo) CompletionTime() time.Time {
+	if t.CompletedAt != nil {
+		return *t.CompletedAt
+	}
+	if t.UpdatedAt.IsZero() {
+		return t.CreatedAt
+	}
+	return t.UpdatedAt
+}
+
+// CreateTodoInput: 할 일 추가 요청 (사용자가 입력할 데이터만 정의한 DTO)
+type CreateTodoInput struct {
+	Task     string     `json:"task" binding:"required" example:"Swagger 문서 수정하기"`
+	Priority int        `json:"priority" binding:"min=0,max=9" example:"1"` // 0: 미지정, 1(높음) ~ 9(낮음)
+	DueAt    *time.Time `json:"due_at" example:"2025-12-31T18:00:00+09:00"` // 마감 시각 (선택)
+}
+
+// UpdateTodoInput: 할 일 수정 요청 (보낸 필드만 바뀜)
+type UpdateTodoInput struct {
+	Task     *string    `json:"task,omitempty" binding:"omitempty,min=1" example:"Swagger 문서 수정하기"`
+	Done     *bool      `json:"done,omitempty" example:"true"`
+	Priority *int       `json:"priority,omitempty" binding:"omitempty,min=0,max=9" example:"2"`
+	DueAt    *time.Time `json:"due_at,omitempty" example:"2025-12-31T18:00:00+09:00"`
+	ClearDue bool       `json:"clear_due,omitempty" example:"false"` // true면 마감 시각 지우기
 }
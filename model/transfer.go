@@ -0,0 +1,17 @@
+package model
+
+// ImportLineError: 가져오기 중 문제가 된 줄 정보
+type ImportLineError struct {
+	Line    int    `json:"line" example:"3"`
+	Message string `json:"message" example:"task is required"`
+}
+
+// ImportResult: 가져오기 결과 리포트
+type ImportResult struct {
+	DryRun     bool              `json:"dry_run"`
+	Total      int               `json:"total"`      // 파일에서 읽은 항목 수
+	Imported   int               `json:"imported"`   // 저장된(dry-run이면 저장될) 항목 수
+	Duplicates []ImportLineError `json:"duplicates"` // 이미 있거나 파일 안에서 중복된 항목
+	Errors     []ImportLineError `json:"errors"`     // 검증 실패 항목
+	Todos      []Todo            `json:"todos"`      // 저장된 항목 (dry-run이면 저장될 항목)
+}
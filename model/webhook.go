@@ -0,0 +1,85 @@
+package model
+
+import "time"
+
+// 웹훅 전송 상태
+const (
+	DeliveryPending   = "pending"   // 보낼 차례를 기다리는 중 (재시도 대기 포함)
+	DeliverySucceeded = "succeeded" // 2xx 응답을 받음
+	DeliveryFailed    = "failed"    // 최대 재시도 횟수를 넘김 (수동 재전송만 가능)
+)
+
+// Webhook: 할 일 변경을 알려줄 구독자
+type Webhook struct {
+	ID        uint      `gorm:"primaryKey" json:"id"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+	URL       string    `json:"url" example:"https://hooks.example.com/todos"`
+	Events    []string  `gorm:"serializer:json" json:"events" example:"todo.created,todo.deleted"` // "*"이면 전부
+	Secret    string    `json:"-"`                                                                 // X-Signature 서명 키 (생성할 때만 응답에 포함)
+	Active    bool      `json:"active"`
+}
+
+// Subscribes: 이 구독자가 event를 받는지
+func (w Webhook) Subscribes(event string) bool {
+	for _, e := range w.Events {
+		if e == "*" || e == event {
+			return true
+		}
+	}
+	return false
+}
+
+// WebhookDelivery: 구독자 하나에게 보낼 이벤트 하나 (DB에 저장된 전송 대기열)
+type WebhookDelivery struct {
+	ID             uint       `gorm:"primaryKey" json:"id"`
+	CreatedAt      time.Time  `json:"created_at"`
+	UpdatedAt      time.Time  `json:"updated_at"`
+	WebhookID      uint       `gorm:"index" json:"webhook_id"`
+	Event          string     `json:"event" example:"todo.created"`
+	Payload        string     `json:"payload"` // 보낸(보낼) 본문 그대로 (서명 대상)
+	Status         string     `gorm:"index:idx_delivery_due" json:"status" example:"pending"`
+	Attempts       int        `json:"attempts"`
+	NextAttemptAt  time.Time  `gorm:"index:idx_delivery_due" json:"next_attempt_at"`
+	LastStatusCode int        `json:"last_status_code,omitempty"`
+	LastError      string     `json:"last_error,omitempty"`
+	DeliveredAt    *time.Time `json:"delivered_at"`
+}
+
+// WebhookAttempt: 전송 시도 한 번의 기록 (전송 로그)
+type WebhookAttempt struct {
+	ID         uint      `gorm:"primaryKey" json:"id"`
+	CreatedAt  time.Time `json:"created_at"`
+	DeliveryID uint      `gorm:"index" json:"delivery_id"`
+	StatusCode int       `json:"status_code"` // 응답을 못 받았으면 0
+	Error      string    `json:"error,omitempty"`
+	DurationMs int64     `json:"duration_ms"`
+}
+
+// CreateWebhookInput: 웹훅 구독 요청
+type CreateWebhookInput struct {
+	URL    string   `json:"url" binding:"required" example:"https://hooks.example.com/todos"`
+	Events []string `json:"events" binding:"required,min=1" example:"todo.created,todo.deleted"` // "*"이면 전부
+	Secret string   `json:"secret" example:""`                                                   // 비워두면 서버가 만들어줌
+	Active *bool    `json:"active"`                                                              // 기본 true
+}
+
+// UpdateWebhookInput: 웹훅 수정 요청 (보낸 필드만 바뀜)
+type UpdateWebhookInput struct {
+	URL    *string  `json:"url"`
+	Events []string `json:"events"`
+	Secret *string  `json:"secret"`
+	Active *bool    `json:"active"`
+}
+
+// WebhookWithSecret: 생성 응답 (서명 키는 이때 한 번만 보여줌)
+type WebhookWithSecret struct {
+	Webhook
+	Secret string `json:"secret"`
+}
+
+// DeliveryDetail: 전송 한 건과 시도 기록
+type DeliveryDetail struct {
+	WebhookDelivery
+	Attempts []WebhookAttempt `json:"attempt_logs"`
+}
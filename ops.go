@@ -0,0 +1,236 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"strconv"
+	"time"
+
+	"go_study/backup"
+	"go_study/certs"
+	"go_study/client"
+	"go_study/config"
+	"go_study/migrate"
+)
+
+// runMigrate: 서버를 띄우지 않고 마이그레이션 적용 / 되돌리기 / 상태 확인
+func runMigrate(args []string) {
+	f := newCommandFlags("migrate", "up | down [n] | status")
+	f.load(args)
+	args = f.Args()
+	if len(args) == 0 {
+		f.Usage()
+		os.Exit(2)
+	}
+	db := openDB()
+	migrator := migrate.New(db, migrate.Options{})
+	ctx := context.Background()
+
+	switch args[0] {
+	case "up":
+		applied, err := migrator.Up(ctx)
+		if err != nil {
+			log.Fatalf("❌ 마이그레이션 실패: %v", err)
+		}
+		for _, m := range applied {
+			log.Printf("⬆️  %04d_%s", m.Version, m.Name)
+		}
+		log.Printf("✅ %d개 적용 (현재 버전 %d)", len(applied), migrator.Latest())
+	case "down":
+		n := 1
+		if len(args) > 1 {
+			var err error
+			if n, err = strconv.Atoi(args[1]); err != nil || n < 1 {
+				f.Usage()
+				os.Exit(2)
+			}
+		}
+		reverted, err := migrator.Down(ctx, n)
+		if err != nil {
+			log.Fatalf("❌ 되돌리기 실패: %v", err)
+		}
+		for _, m := range reverted {
+			log.Printf("⬇️  %04d_%s", m.Version, m.Name)
+		}
+		current, _ := migrator.Current()
+		log.Printf("✅ %d개 되돌림 (현재 버전 %d)", len(reverted), current)
+	case "status":
+		status, err := migrator.Status()
+		if err != nil {
+			log.Fatal(err)
+		}
+		for _, s := range status {
+			state := "pending"
+			if s.AppliedAt != nil {
+				state = "applied " + s.AppliedAt.Format(time.RFC3339)
+			}
+			if !s.Known {
+				state += " (unknown to this binary)"
+			}
+			fmt.Printf("%04d  %-28s %s\n", s.Version, s.Name, state)
+		}
+		if err := migrator.Check(); err != nil {
+			fmt.Println(err)
+		}
+	default:
+		f.Usage()
+		os.Exit(2)
+	}
+}
+
+// runBackup: 서버를 멈추지 않고 스냅샷 백업 (VACUUM INTO), keep이 있으면 오래된 백업 정리
+func runBackup(args []string) {
+	f := newCommandFlags("backup", "")
+	f.override("dir", "backup.dir", "백업 디렉터리 (backup.dir)")
+	f.override("keep", "backup.keep", "남길 백업 개수, 0이면 지우지 않음 (backup.keep)")
+	f.load(args)
+
+	if _, err := os.Stat(config.AppConfig.Database.File); err != nil {
+		log.Fatalf("❌ DB 파일이 없습니다: %v", err)
+	}
+	mgr := backup.NewManager(openDB(), config.AppConfig.Backup.Dir)
+	info, err := mgr.Create(context.Background())
+	if err != nil {
+		log.Fatalf("❌ 백업 실패: %v", err)
+	}
+	log.Printf("✅ %s (%d bytes)", filepath.Join(mgr.Dir(), info.Name), info.SizeBytes)
+
+	if keep := config.AppConfig.Backup.Keep; keep > 0 {
+		removed, err := mgr.Prune(keep)
+		if err != nil {
+			log.Fatalf("❌ 오래된 백업 정리 실패: %v", err)
+		}
+		for _, name := range removed {
+			log.Printf("🗑️  %s", name)
+		}
+	}
+}
+
+// runRestore: 백업 파일을 검증한 뒤 database.file과 교체
+func runRestore(args []string) {
+	f := newCommandFlags("restore", "<backup file path or name in backup.dir>")
+	f.override("dir", "backup.dir", "이름만 주었을 때 찾을 백업 디렉터리 (backup.dir)")
+	f.load(args)
+	if f.NArg() != 1 {
+		f.Usage()
+		os.Exit(2)
+	}
+	src := f.Arg(0)
+	if _, err := os.Stat(src); err != nil {
+		src = filepath.Join(config.AppConfig.Backup.Dir, f.Arg(0))
+	}
+	previous, err := backup.Restore(src, config.AppConfig.Database.File)
+	if errors.Is(err, backup.ErrDatabaseInUse) {
+		log.Fatalf("❌ 복원 거부: %v (DB를 쓰는 서버를 멈춘 뒤 다시 실행하세요)", err)
+	}
+	if err != nil {
+		log.Fatalf("❌ 복원 실패: %v", err)
+	}
+	log.Printf("✅ %s → %s 복원 완료", src, config.AppConfig.Database.File)
+	if previous != "" {
+		log.Printf("   기존 DB는 %s 로 옮겨두었습니다.", previous)
+	}
+}
+
+// runRoleChange: 실행 중인 서버에 POST /admin/promote 또는 /admin/demote (컨테이너 안에서 curl 없이)
+func runRoleChange(cmd string, args []string) {
+	f := newCommandFlags(cmd, "")
+	url := f.String("url", "", "서버 주소 (기본: http(s)://localhost + server.port)")
+	timeout := f.Duration("timeout", time.Minute, "응답 대기 시간 (복제 모드 승격은 남은 변경분을 받을 때까지 걸림)")
+	caFile := f.String("ca", "", "서버 인증서를 확인할 CA PEM (server.tls를 사설 CA로 쓸 때)")
+	certFile := f.String("cert", "", "클라이언트 인증서 PEM (server.tls.client_ca_file로 /admin을 막았을 때)")
+	keyFile := f.String("key", "", "클라이언트 인증서 개인 키 PEM")
+	f.load(args)
+	if *url == "" {
+		scheme := "http://"
+		if config.AppConfig.Server.TLS.Enabled {
+			scheme = "https://"
+		}
+		*url = scheme + "localhost" + config.AppConfig.Server.Port
+	}
+	tlsConfig, err := certs.ClientTLSConfig(*caFile, *certFile, *keyFile)
+	if err != nil {
+		log.Fatalf("❌ TLS 설정 실패: %v", err)
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+	ctx, cancel := context.WithTimeout(ctx, *timeout)
+	defer cancel()
+
+	var opts []client.Option
+	if tlsConfig != nil {
+		opts = append(opts, client.WithHTTPClient(&http.Client{Transport: &http.Transport{TLSClientConfig: tlsConfig}}))
+	}
+	c := client.New(*url, opts...)
+	if cmd == "promote" {
+		err = c.Promote(ctx)
+	} else {
+		err = c.Demote(ctx)
+	}
+	if err != nil {
+		log.Fatalf("❌ %s 실패 (%s): %v", cmd, *url, err)
+	}
+	log.Printf("✅ %s: %s", cmd, *url)
+}
+
+// runCheckConfig: 설정을 읽고 검증한 뒤 서버가 시작할 수 있는 상태인지 점검 (문제가 있으면 종료 코드 1)
+func runCheckConfig(args []string) {
+	f := newCommandFlags("check-config", "")
+	printCfg := f.Bool("print", false, "적용된 설정 출력 (비밀 항목은 가림)")
+	f.load(args)
+	cfg := config.AppConfig
+	if *printCfg {
+		out, _ := json.MarshalIndent(cfg.Effective(), "", "  ")
+		fmt.Println(string(out))
+	}
+
+	var problems []string
+	check := func(name string, err error) {
+		if err != nil {
+			problems = append(problems, fmt.Sprintf("%s: %v", name, err))
+			fmt.Printf("❌ %-16s %v\n", name, err)
+			return
+		}
+		fmt.Printf("✅ %s\n", name)
+	}
+
+	// 항목별 값 검증은 config.Load에서 (잘못된 항목이 있으면 여기까지 오지 않고 전부 출력 후 종료)
+	check("config", nil)
+	if tls := cfg.Server.TLS; tls.Enabled {
+		r, err := certs.NewReloader(tls.CertFile, tls.KeyFile, tls.ClientCAFile)
+		if err == nil && time.Until(r.NotAfter()) < 0 {
+			err = fmt.Errorf("certificate expired at %s", r.NotAfter().Format(time.RFC3339))
+		}
+		check("server.tls", err)
+	}
+	check("database.file", dirExists(filepath.Dir(cfg.Database.File)))
+	if _, err := os.Stat(cfg.Database.File); err == nil {
+		check("schema", migrate.New(openDB(), migrate.Options{}).Check())
+	} else if !cfg.Database.AutoMigrate {
+		check("schema", errors.New("DB 파일이 없고 database.auto_migrate가 false (./main migrate up 필요)"))
+	}
+
+	if len(problems) > 0 {
+		log.Fatalf("❌ 설정 문제 %d개", len(problems))
+	}
+	fmt.Println("✅ 설정 OK")
+}
+
+func dirExists(dir string) error {
+	st, err := os.Stat(dir)
+	if err != nil {
+		return err
+	}
+	if !st.IsDir() {
+		return fmt.Errorf("%s is not a directory", dir)
+	}
+	return nil
+}
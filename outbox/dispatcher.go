@@ -0,0 +1,135 @@
+// Package outbox: 할 일 변경과 같은 트랜잭션에 저장된 outbox 이벤트를 프로세스 안의 구독자에게 전달
+// 구독자에게 넘긴 뒤에 "보냄" 표시를 하므로, 그 사이에 죽으면 같은 이벤트가 한 번 더 갈 수 있습니다. (at-least-once)
+// 구독자는 이벤트 ID로 중복을 걸러내거나, 두 번 처리해도 괜찮게 만들어야 합니다.
+// 구독자 하나가 실패하면 이미 받은 구독자는 기록해 두고 실패한 구독자에게만 다시 보냅니다.
+// 같은 할 일의 이벤트는 순서대로 나가야 하므로, 실패한 이벤트가 보내지거나 한도를 넘길 때까지 그 할 일의 뒤 이벤트는 보내지 않습니다.
+package outbox
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"slices"
+	"sync"
+	"time"
+
+	"go_study/global"
+	"go_study/model"
+	"go_study/repository"
+)
+
+// 한 번에 꺼낼 이벤트 수
+const batchSize = 100
+
+// Subscriber: 이벤트를 받는 함수 (에러를 돌려주면 나중에 다시 받음)
+type Subscriber func(e model.OutboxEvent) error
+
+// Dispatcher: outbox 테이블을 주기적으로 읽어서 구독자에게 넘김 (Active 서버에서만)
+type Dispatcher struct {
+	repo        repository.OutboxRepository
+	interval    time.Duration
+	maxAttempts int
+
+	mu          sync.RWMutex
+	subscribers []namedSubscriber
+}
+
+type namedSubscriber struct {
+	name string
+	fn   Subscriber
+}
+
+// 생성자 (maxAttempts번 실패한 이벤트는 더 이상 시도하지 않고 last_error와 함께 남겨둠)
+func NewDispatcher(repo repository.OutboxRepository, interval time.Duration, maxAttempts int) *Dispatcher {
+	if interval <= 0 {
+		interval = time.Second
+	}
+	if maxAttempts <= 0 {
+		maxAttempts = 10
+	}
+	return &Dispatcher{repo: repo, interval: interval, maxAttempts: maxAttempts}
+}
+
+// Subscribe: 구독자 등록 (Start 전에 등록하는 것을 권장)
+func (d *Dispatcher) Subscribe(name string, fn Subscriber) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.subscribers = append(d.subscribers, namedSubscriber{name: name, fn: fn})
+}
+
+// Start: 백그라운드 전달 시작
+func (d *Dispatcher) Start() {
+	go func() {
+		ticker := time.NewTicker(d.interval)
+		defer ticker.Stop()
+		for range ticker.C {
+			// 같은 DB를 보는 Standby가 같이 돌면 이벤트가 두 번씩 나가므로 Active만
+			if !global.IsActive() {
+				continue
+			}
+			d.RunOnce()
+		}
+	}()
+}
+
+// RunOnce: 쌓인 이벤트를 한 번 처리 (보냄 표시한 개수 반환)
+func (d *Dispatcher) RunOnce() int {
+	events, err := d.repo.Pending(d.maxAttempts, batchSize)
+	if err != nil {
+		log.Printf("❌ [Outbox] 이벤트 조회 실패: %v\n", err)
+		return 0
+	}
+
+	d.mu.RLock()
+	subscribers := d.subscribers
+	d.mu.RUnlock()
+
+	sent := 0
+	blocked := map[uint]bool{} // 이번 주기에 실패한 이벤트가 있는 할 일 (뒤 이벤트는 다음 주기로)
+	for _, e := range events {
+		if blocked[e.TodoID] {
+			continue
+		}
+		delivered, err := deliver(subscribers, e)
+		if err != nil {
+			blocked[e.TodoID] = true
+			log.Printf("⚠️ [Outbox] 이벤트 #%d (%s) 전달 실패 %d회째: %v\n", e.ID, e.Event, e.Attempts+1, err)
+			if err := d.repo.MarkFailed(e.ID, err.Error(), delivered); err != nil {
+				log.Printf("❌ [Outbox] 실패 기록 저장 실패 (#%d): %v\n", e.ID, err)
+			}
+			continue
+		}
+		if err := d.repo.MarkSent(e.ID, time.Now()); err != nil {
+			log.Printf("❌ [Outbox] 보냄 표시 실패 (#%d): %v\n", e.ID, err)
+			continue
+		}
+		sent++
+	}
+	return sent
+}
+
+// 아직 받지 않은 구독자에게 넘김 (하나라도 실패하면 에러, delivered는 지금까지 받은 구독자 전부)
+func deliver(subscribers []namedSubscriber, e model.OutboxEvent) (delivered []string, err error) {
+	delivered = append(delivered, e.Delivered...)
+	for _, s := range subscribers {
+		if slices.Contains(e.Delivered, s.name) {
+			continue
+		}
+		if serr := call(s.fn, e); serr != nil {
+			err = errors.Join(err, fmt.Errorf("%s: %w", s.name, serr))
+			continue
+		}
+		delivered = append(delivered, s.name)
+	}
+	return delivered, err
+}
+
+// 구독자의 panic이 Dispatcher 고루틴을 죽이지 않도록
+func call(fn Subscriber, e model.OutboxEvent) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("panic: %v", r)
+		}
+	}()
+	return fn(e)
+}
@@ -0,0 +1,132 @@
+package outbox
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"go_study/model"
+	"go_study/repository"
+
+	"github.com/glebarez/sqlite"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+)
+
+func newTestRepos(t *testing.T) (*repository.SQLiteRepository, *repository.SQLiteOutboxRepository) {
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	db.AutoMigrate(&model.Todo{}, &model.Sequence{}, &model.TodoRevision{}, &model.OutboxEvent{})
+	return repository.NewSQLiteRepository(db), repository.NewSQLiteOutboxRepository(db)
+}
+
+func TestDispatcher_RetriesOnlyFailedSubscriber(t *testing.T) {
+	todos, repo := newTestRepos(t)
+	todos.Save(model.Todo{Task: "A"})
+	todos.Save(model.Todo{Task: "B"})
+
+	var recorded, flakyGot []string
+	failing := true
+	d := NewDispatcher(repo, 0, 3)
+	d.Subscribe("recorder", func(e model.OutboxEvent) error {
+		recorded = append(recorded, e.Payload)
+		return nil
+	})
+	d.Subscribe("flaky", func(e model.OutboxEvent) error {
+		if failing {
+			return errors.New("unavailable")
+		}
+		flakyGot = append(flakyGot, e.Payload)
+		return nil
+	})
+
+	// 1. 구독자 하나가 실패하면 보냄 표시를 하지 않고, 받은 구독자를 기록
+	assert.Equal(t, 0, d.RunOnce())
+	assert.Len(t, recorded, 2)
+	pending, _ := repo.Pending(3, 100)
+	assert.Len(t, pending, 2)
+	assert.Equal(t, 1, pending[0].Attempts)
+	assert.Contains(t, pending[0].LastError, "flaky: unavailable")
+	assert.Equal(t, []string{"recorder"}, pending[0].Delivered)
+
+	// 2. 또 실패해도 이미 받은 구독자에게는 다시 보내지 않음
+	assert.Equal(t, 0, d.RunOnce())
+	assert.Len(t, recorded, 2)
+
+	// 3. 회복되면 실패한 구독자에게만 저장된 순서대로 넘기고 보냄 표시
+	failing = false
+	assert.Equal(t, 2, d.RunOnce())
+	assert.Len(t, recorded, 2, "recorder must not get duplicates")
+	assert.Len(t, flakyGot, 2)
+	assert.Contains(t, flakyGot[0], `"task":"A"`)
+	assert.Contains(t, flakyGot[1], `"task":"B"`)
+
+	// 4. 이미 보낸 이벤트는 다시 나가지 않음
+	assert.Equal(t, 0, d.RunOnce())
+	assert.Len(t, recorded, 2)
+	assert.Len(t, flakyGot, 2)
+}
+
+func TestDispatcher_KeepsPerTodoOrder(t *testing.T) {
+	todos, repo := newTestRepos(t)
+	a, _ := todos.Save(model.Todo{Task: "A"})
+	todos.Save(model.Todo{Task: "B"})
+	todos.Update(fmt.Sprint(a.ID)) // A의 두 번째 이벤트
+
+	var got []string
+	failFirst := true
+	d := NewDispatcher(repo, 0, 2)
+	d.Subscribe("recorder", func(e model.OutboxEvent) error {
+		if e.ID == 1 && failFirst {
+			return errors.New("unavailable")
+		}
+		got = append(got, fmt.Sprintf("%d:%s", e.TodoID, e.Event))
+		return nil
+	})
+
+	// 1. A의 첫 이벤트가 실패하면 A의 뒤 이벤트는 기다리고, 다른 할 일(B)은 그대로 나감
+	assert.Equal(t, 1, d.RunOnce())
+	assert.Equal(t, []string{"2:todo.created"}, got)
+
+	// 2. 실패한 이벤트가 나가야 A의 뒤 이벤트도 순서대로 나감
+	failFirst = false
+	assert.Equal(t, 2, d.RunOnce())
+	assert.Equal(t, []string{"2:todo.created", "1:todo.created", "1:todo.updated"}, got)
+}
+
+func TestDispatcher_DeadEventUnblocksTodo(t *testing.T) {
+	todos, repo := newTestRepos(t)
+	a, _ := todos.Save(model.Todo{Task: "A"})
+	todos.Update(fmt.Sprint(a.ID))
+
+	var got []string
+	d := NewDispatcher(repo, 0, 2)
+	d.Subscribe("recorder", func(e model.OutboxEvent) error {
+		if e.Event == model.EventTodoCreated {
+			return errors.New("rejected")
+		}
+		got = append(got, e.Event)
+		return nil
+	})
+
+	// 한도(2회)를 다 쓴 이벤트는 더 이상 뒤 이벤트를 막지 않음
+	assert.Equal(t, 0, d.RunOnce())
+	assert.Equal(t, 0, d.RunOnce())
+	assert.Equal(t, 1, d.RunOnce())
+	assert.Equal(t, []string{model.EventTodoUpdated}, got)
+}
+
+func TestDispatcher_RecoversFromPanic(t *testing.T) {
+	todos, repo := newTestRepos(t)
+	todos.Save(model.Todo{Task: "A"})
+
+	d := NewDispatcher(repo, 0, 1)
+	d.Subscribe("panicky", func(e model.OutboxEvent) error { panic("oops") })
+
+	assert.Equal(t, 0, d.RunOnce())
+	// 한도(1회)를 다 써서 더 이상 시도하지 않음
+	pending, _ := repo.Pending(1, 100)
+	assert.Empty(t, pending)
+}
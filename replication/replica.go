@@ -0,0 +1,302 @@
+// Package replication: Standby가 자기 SQLite 파일을 따로 두고 Active의 변경분을 따라가는 복제
+// Active의 GET /replication/changes를 long-poll로 호출해서 변경 순번(seq) 순서대로 적용합니다.
+// 승격할 때는 따라가기를 멈추고 Active에 남은 변경분을 마저 받은 뒤에 Active가 됩니다.
+// 다시 Standby가 되면 Active인 동안 로컬 변경이 없었을 때만 이어서 따라가고, 있었으면 새 Active의 백업으로 다시 채워야 합니다.
+package replication
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"go_study/cluster"
+	"go_study/global"
+	"go_study/model"
+	"go_study/repository"
+)
+
+// Options: 복제 설정 (0이면 기본값)
+type Options struct {
+	Source    func() string // Active 주소 (예: http://app-1:8080), 빈 값이면 잠시 기다렸다 다시
+	Token     string
+	BatchSize int
+	LongPoll  time.Duration // 변경이 없을 때 Active가 응답을 미루는 최대 시간
+	MaxLag    time.Duration // 이보다 오래 따라잡지 못하면 health 점검 실패
+	Retry     time.Duration // 실패 후 다시 시도하기까지 대기
+}
+
+func (o Options) withDefaults() Options {
+	if o.BatchSize <= 0 {
+		o.BatchSize = 500
+	}
+	if o.LongPoll <= 0 {
+		o.LongPoll = 10 * time.Second
+	}
+	if o.MaxLag <= 0 {
+		o.MaxLag = 30 * time.Second
+	}
+	if o.Retry <= 0 {
+		o.Retry = 2 * time.Second
+	}
+	return o
+}
+
+// Replica: Standby에서 Active의 변경분을 따라감
+type Replica struct {
+	repo   repository.ReplicationRepository
+	opts   Options
+	client *http.Client
+
+	mu      sync.Mutex
+	status  model.ReplicationStatus
+	cancel  context.CancelFunc // 진행 중인 long-poll 취소
+	stopped bool               // Active라서 적용하지 않음 (승격됐거나 Active로 시작함)
+	leftAt  int64              // 따라가기를 멈춘 시점의 변경 순번 (Standby로 돌아올 때 로컬 변경이 있었는지 비교)
+}
+
+// 생성자
+func NewReplica(repo repository.ReplicationRepository, opts Options) *Replica {
+	opts = opts.withDefaults()
+	return &Replica{
+		repo:   repo,
+		opts:   opts,
+		client: &http.Client{Timeout: opts.LongPoll + 10*time.Second},
+		status: model.ReplicationStatus{Running: true},
+	}
+}
+
+// Start: 백그라운드로 따라가기 시작 (Active인 동안은 쉬고, Standby로 돌아오면 이어서 따라감)
+func (r *Replica) Start() {
+	go func() {
+		for {
+			if global.IsActive() {
+				r.pause()
+				time.Sleep(r.opts.Retry)
+				continue
+			}
+			if !r.resume() {
+				time.Sleep(r.opts.Retry)
+				continue
+			}
+			if _, err := r.SyncOnce(context.Background(), r.opts.LongPoll); err != nil {
+				if !errors.Is(err, context.Canceled) {
+					log.Printf("⚠️ [Replication] 변경분 적용 실패: %v\n", err)
+				}
+				time.Sleep(r.opts.Retry)
+			}
+		}
+	}()
+}
+
+// pause: Active가 됐으니 따라가기를 멈추고 지금 순번을 기억
+func (r *Replica) pause() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.stopLocked()
+}
+
+// stopLocked: r.mu를 잡은 상태에서 호출 (이미 멈췄으면 처음 멈춘 순번을 유지)
+func (r *Replica) stopLocked() {
+	if r.stopped {
+		return
+	}
+	pos, err := r.repo.Position()
+	if err != nil {
+		r.status.LastError = err.Error()
+		return
+	}
+	r.stopped, r.leftAt, r.status.Running = true, pos, false
+}
+
+// resume: Standby로 돌아왔으면 다시 따라갈 수 있는지 확인 (따라가도 되면 true)
+// 멈춘 뒤 로컬 변경이 있었으면 새 Active와 이력이 갈라졌을 수 있으므로 이어서 적용하지 않고 다시 채우기를 요구합니다.
+func (r *Replica) resume() bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if !r.stopped {
+		return true
+	}
+	if global.IsActive() || r.status.NeedsReseed {
+		return false
+	}
+	pos, err := r.repo.Position()
+	if err != nil {
+		r.status.LastError = err.Error()
+		return false
+	}
+	if pos != r.leftAt {
+		r.status.NeedsReseed = true
+		r.status.LastError = fmt.Sprintf("local changes while active (seq %d -> %d): restore this node from the active node's backup and restart", r.leftAt, pos)
+		log.Printf("🚨 [Replication] Active인 동안 로컬 변경(순번 %d → %d)이 있어 복제를 이어갈 수 없음: 새 Active의 백업으로 복원 후 재시작하세요\n", r.leftAt, pos)
+		return false
+	}
+	r.stopped, r.status.Running = false, true
+	log.Printf("🔁 [Replication] STANDBY 전환: 변경 순번 %d부터 다시 따라감\n", pos)
+	return true
+}
+
+// SyncOnce: 변경분 한 배치를 받아서 적용 (Active와 같은 순번이 되면 true)
+// wait가 0보다 크면 새 변경이 없을 때 Active가 그 시간만큼 기다렸다 응답합니다.
+func (r *Replica) SyncOnce(ctx context.Context, wait time.Duration) (bool, error) {
+	source := ""
+	if r.opts.Source != nil {
+		source = strings.TrimRight(r.opts.Source(), "/")
+	}
+	if source == "" {
+		return false, r.fail(errors.New("no active node to replicate from"))
+	}
+
+	pos, err := r.repo.Position()
+	if err != nil {
+		return false, r.fail(err)
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	r.mu.Lock()
+	if r.stopped {
+		r.mu.Unlock()
+		cancel()
+		return false, context.Canceled
+	}
+	r.cancel = cancel
+	r.status.Source = source
+	r.mu.Unlock()
+	defer cancel()
+
+	batch, err := r.fetch(ctx, source, pos, wait)
+	if err != nil {
+		return false, r.fail(err)
+	}
+	if batch.Head < pos {
+		return false, r.fail(fmt.Errorf("source %s is behind this replica (%d < %d)", source, batch.Head, pos))
+	}
+
+	// 승격과 겹치지 않도록 잠근 상태에서 적용
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.stopped {
+		return false, context.Canceled
+	}
+	if err := r.repo.Apply(batch); err != nil {
+		r.status.LastError = err.Error()
+		return false, err
+	}
+
+	now := time.Now()
+	r.status.AppliedSeq, r.status.SourceSeq, r.status.LastContactAt, r.status.LastError = batch.To, batch.Head, &now, ""
+	caughtUp := batch.To >= batch.Head
+	if caughtUp {
+		r.status.CaughtUpAt = &now
+	}
+	return caughtUp, nil
+}
+
+// Active의 GET /replication/changes 호출
+func (r *Replica) fetch(ctx context.Context, source string, since int64, wait time.Duration) (model.ChangeBatch, error) {
+	var batch model.ChangeBatch
+	q := url.Values{}
+	q.Set("since", strconv.FormatInt(since, 10))
+	q.Set("limit", strconv.Itoa(r.opts.BatchSize))
+	q.Set("wait", wait.String())
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, source+"/replication/changes?"+q.Encode(), nil)
+	if err != nil {
+		return batch, err
+	}
+	if r.opts.Token != "" {
+		req.Header.Set(cluster.TokenHeader, r.opts.Token)
+	}
+	resp, err := r.client.Do(req)
+	if err != nil {
+		return batch, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return batch, fmt.Errorf("%s returned %d", source, resp.StatusCode)
+	}
+	err = json.NewDecoder(resp.Body).Decode(&model.WebResponse{Data: &batch})
+	return batch, err
+}
+
+func (r *Replica) fail(err error) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.status.LastError = err.Error()
+	return err
+}
+
+// Promote: 따라가기를 멈추고 Active가 됨
+// Active에 남은 변경분을 timeout 안에 마저 받아보고, 못 받으면(Active가 죽었으면) 지금까지 받은 데이터로 승격합니다.
+// 배치는 통째로 적용되므로 할 일 하나하나는 Active에 있었던 상태지만, Head까지 따라잡지 못했으면
+// 아직 받지 못한 할 일이 빠져 있을 수 있습니다 (ChangesSince 참고, 얼마나 못 받았는지는 LagSeq).
+func (r *Replica) Promote(ctx context.Context, timeout time.Duration) model.ReplicationStatus {
+	// 1. 진행 중인 long-poll을 끊고 남은 변경분 받기
+	r.mu.Lock()
+	if r.cancel != nil {
+		r.cancel()
+	}
+	r.mu.Unlock()
+
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+	for ctx.Err() == nil {
+		caughtUp, err := r.SyncOnce(ctx, 0)
+		if err != nil || caughtUp {
+			break
+		}
+	}
+
+	// 2. 더 이상 적용하지 않도록 막고 승격 (따라가기 루프가 그 사이에 다시 시작하지 않도록 잠근 채로)
+	r.mu.Lock()
+	r.stopLocked()
+	global.SetActive()
+	r.mu.Unlock()
+
+	status := r.Status()
+	if status.LagSeq > 0 {
+		log.Printf("⚠️ [Replication] 변경 순번 %d까지만 반영한 상태로 ACTIVE 승격 (Active의 %d까지 %d개 못 받음)\n", status.AppliedSeq, status.SourceSeq, status.LagSeq)
+	} else {
+		log.Printf("🚀 [Replication] 변경 순번 %d까지 반영한 상태로 ACTIVE 승격\n", status.AppliedSeq)
+	}
+	return status
+}
+
+// Status: 현재 복제 상태
+func (r *Replica) Status() model.ReplicationStatus {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	s := r.status
+	if pos, err := r.repo.Position(); err == nil {
+		s.AppliedSeq = pos
+	}
+	s.LagSeq = max(s.SourceSeq-s.AppliedSeq, 0)
+	if s.Running && s.CaughtUpAt != nil {
+		s.LagSeconds = time.Since(*s.CaughtUpAt).Seconds()
+	}
+	return s
+}
+
+// Check: health 점검 (Standby로 따라가는 중에 max_lag보다 오래 따라잡지 못하면 실패)
+func (r *Replica) Check(ctx context.Context) (interface{}, error) {
+	s := r.Status()
+	if s.NeedsReseed && !global.IsActive() {
+		return s, errors.New(s.LastError)
+	}
+	if !s.Running || global.IsActive() {
+		return s, nil
+	}
+	if s.CaughtUpAt == nil {
+		return s, fmt.Errorf("not caught up yet: %s", s.LastError)
+	}
+	if lag := time.Since(*s.CaughtUpAt); lag > r.opts.MaxLag {
+		return s, fmt.Errorf("replication lag %s exceeds %s (%d changes behind)", lag.Round(time.Second), r.opts.MaxLag, s.LagSeq)
+	}
+	return s, nil
+}
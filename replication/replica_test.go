@@ -0,0 +1,184 @@
+package replication
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"go_study/cluster"
+	"go_study/global"
+	"go_study/model"
+	"go_study/repository"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/glebarez/sqlite"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+func newTestDB(t *testing.T) *gorm.DB {
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
+	require.NoError(t, err)
+	db.AutoMigrate(&model.Todo{}, &model.Sequence{}, &model.TodoRevision{}, &model.OutboxEvent{})
+	return db
+}
+
+// fakeSource: Active의 GET /replication/changes 흉내 (토큰 확인 후 원본 DB의 변경분을 그대로 돌려줌)
+func fakeSource(t *testing.T, db *gorm.DB) *httptest.Server {
+	repo := repository.NewSQLiteReplicationRepository(db)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		if req.Header.Get(cluster.TokenHeader) != "secret" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		since, _ := strconv.ParseInt(req.URL.Query().Get("since"), 10, 64)
+		limit, _ := strconv.Atoi(req.URL.Query().Get("limit"))
+		batch, err := repo.ChangesSince(since, limit)
+		if err != nil {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		json.NewEncoder(w).Encode(model.WebResponse{Code: http.StatusOK, Data: batch})
+	}))
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+func newTestReplica(db *gorm.DB, source, token string) *Replica {
+	return NewReplica(repository.NewSQLiteReplicationRepository(db), Options{
+		Source:    func() string { return source },
+		Token:     token,
+		BatchSize: 2,
+		MaxLag:    time.Minute,
+	})
+}
+
+func TestReplica_TailsInOrder(t *testing.T) {
+	global.SetStandby()
+	sourceDB, replicaDB := newTestDB(t), newTestDB(t)
+	source := repository.NewSQLiteRepository(sourceDB)
+	srv := fakeSource(t, sourceDB)
+	r := newTestReplica(replicaDB, srv.URL, "secret")
+
+	for i := 0; i < 3; i++ {
+		source.Save(model.Todo{Task: fmt.Sprintf("task %d", i)})
+	}
+	source.Update("1")
+
+	// 1. 처음 따라잡기 전에는 health 점검 실패
+	_, err := r.Check(context.Background())
+	assert.Error(t, err)
+
+	// 2. 배치 크기(2)씩 순서대로 적용해서 원본과 같아짐
+	caughtUp, err := r.SyncOnce(context.Background(), 0)
+	require.NoError(t, err)
+	assert.False(t, caughtUp)
+	for !caughtUp {
+		caughtUp, err = r.SyncOnce(context.Background(), 0)
+		require.NoError(t, err)
+	}
+	replica := repository.NewSQLiteRepository(replicaDB)
+	assert.Equal(t, source.GetAll(), replica.GetAll())
+	revs, _ := replica.Revisions(1)
+	assert.Len(t, revs, 2)
+
+	status := r.Status()
+	assert.True(t, status.Running)
+	assert.Equal(t, int64(4), status.AppliedSeq)
+	assert.Zero(t, status.LagSeq)
+	_, err = r.Check(context.Background())
+	assert.NoError(t, err)
+}
+
+func TestReplica_RejectsBadTokenAndBehindSource(t *testing.T) {
+	global.SetStandby()
+	sourceDB, replicaDB := newTestDB(t), newTestDB(t)
+	srv := fakeSource(t, sourceDB)
+
+	// 1. 토큰이 틀리면 적용하지 않고 에러를 상태에 남김
+	r := newTestReplica(replicaDB, srv.URL, "wrong")
+	_, err := r.SyncOnce(context.Background(), 0)
+	assert.ErrorContains(t, err, "401")
+	assert.Contains(t, r.Status().LastError, "401")
+
+	// 2. 원본이 복제본보다 뒤에 있으면 (다른 DB를 가리키면) 적용하지 않음
+	repository.NewSQLiteRepository(replicaDB).Save(model.Todo{Task: "local"})
+	r = newTestReplica(replicaDB, srv.URL, "secret")
+	_, err = r.SyncOnce(context.Background(), 0)
+	assert.ErrorContains(t, err, "is behind this replica")
+
+	// 3. 원본 주소를 모르면 에러
+	r = newTestReplica(replicaDB, "", "secret")
+	_, err = r.SyncOnce(context.Background(), 0)
+	assert.Error(t, err)
+}
+
+func TestReplica_PromoteAndResumeOnDemote(t *testing.T) {
+	defer global.SetStandby()
+	global.SetStandby()
+	sourceDB, replicaDB := newTestDB(t), newTestDB(t)
+	source := repository.NewSQLiteRepository(sourceDB)
+	srv := fakeSource(t, sourceDB)
+	r := newTestReplica(replicaDB, srv.URL, "secret")
+
+	for i := 0; i < 3; i++ {
+		source.Save(model.Todo{Task: fmt.Sprintf("task %d", i)})
+	}
+
+	// 1. 승격: 남은 변경분을 모두 받고 Active가 됨, 이후로는 적용하지 않음
+	status := r.Promote(context.Background(), time.Second)
+	assert.True(t, global.IsActive())
+	assert.False(t, status.Running)
+	assert.Equal(t, int64(3), status.AppliedSeq)
+	source.Save(model.Todo{Task: "after promote"})
+	_, err := r.SyncOnce(context.Background(), 0)
+	assert.ErrorIs(t, err, context.Canceled)
+	assert.False(t, r.resume())
+
+	// 2. 로컬 변경 없이 Standby로 돌아오면 멈춘 순번부터 이어서 따라감
+	global.SetStandby()
+	assert.True(t, r.resume())
+	assert.True(t, r.Status().Running)
+	caughtUp, err := r.SyncOnce(context.Background(), 0)
+	require.NoError(t, err)
+	assert.True(t, caughtUp)
+	assert.Len(t, repository.NewSQLiteRepository(replicaDB).GetAll(), 4)
+}
+
+func TestReplica_RequiresReseedAfterLocalWrites(t *testing.T) {
+	defer global.SetStandby()
+	global.SetStandby()
+	sourceDB, replicaDB := newTestDB(t), newTestDB(t)
+	source := repository.NewSQLiteRepository(sourceDB)
+	srv := fakeSource(t, sourceDB)
+	r := newTestReplica(replicaDB, srv.URL, "secret")
+
+	source.Save(model.Todo{Task: "shared"})
+	r.Promote(context.Background(), time.Second)
+
+	// 1. Active인 동안 두 DB에 각자 변경이 생김 (같은 순번 2가 서로 다른 할 일)
+	repository.NewSQLiteRepository(replicaDB).Save(model.Todo{Task: "written while active"})
+	source.Save(model.Todo{Task: "written on the other node"})
+	source.Save(model.Todo{Task: "and another"})
+
+	// 2. Standby로 돌아와도 이어서 적용하지 않고 다시 채우기를 요구 (health 점검 실패)
+	global.SetStandby()
+	assert.False(t, r.resume())
+	status := r.Status()
+	assert.True(t, status.NeedsReseed)
+	assert.False(t, status.Running)
+	assert.Contains(t, status.LastError, "seq 1 -> 2")
+	_, err := r.Check(context.Background())
+	assert.Error(t, err)
+	_, err = r.SyncOnce(context.Background(), 0)
+	assert.ErrorIs(t, err, context.Canceled)
+
+	// 다시 Active가 되면 점검은 통과
+	global.SetActive()
+	_, err = r.Check(context.Background())
+	assert.NoError(t, err)
+}
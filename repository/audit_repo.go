@@ -0,0 +1,69 @@
+package repository
+
+import (
+	"go_study/model"
+
+	"gorm.io/gorm"
+)
+
+// SQLiteAuditRepository: 감사 로그 저장소 (SQLite 구현체)
+// 추가와 조회만 있고, 수정/삭제 메소드는 일부러 만들지 않습니다.
+type SQLiteAuditRepository struct {
+	db *gorm.DB
+}
+
+// 생성자 함수
+func NewSQLiteAuditRepository(db *gorm.DB) *SQLiteAuditRepository {
+	return &SQLiteAuditRepository{db: db}
+}
+
+func (r *SQLiteAuditRepository) Append(e model.AuditEvent) error {
+	return r.db.Create(&e).Error
+}
+
+// Find: 조건에 맞는 감사 로그를 최신순으로 (limit/offset 페이지)
+func (r *SQLiteAuditRepository) Find(filter AuditFilter, limit, offset int) ([]model.AuditEvent, error) {
+	var events []model.AuditEvent
+	err := applyAuditFilter(r.db, filter).Order("id DESC").Limit(limit).Offset(offset).Find(&events).Error
+	return events, err
+}
+
+// Stream: 조건에 맞는 감사 로그를 오래된 순서로 하나씩 넘겨줌 (내보내기용)
+func (r *SQLiteAuditRepository) Stream(filter AuditFilter, fn func(model.AuditEvent) error) error {
+	var batch []model.AuditEvent
+	var fnErr error
+	result := applyAuditFilter(r.db, filter).FindInBatches(&batch, 500, func(tx *gorm.DB, _ int) error {
+		for _, e := range batch {
+			if fnErr = fn(e); fnErr != nil {
+				return fnErr
+			}
+		}
+		return nil
+	})
+	if fnErr != nil {
+		return fnErr
+	}
+	return result.Error
+}
+
+func applyAuditFilter(db *gorm.DB, filter AuditFilter) *gorm.DB {
+	if filter.Actor != "" {
+		db = db.Where("actor = ?", filter.Actor)
+	}
+	if filter.Action != "" {
+		db = db.Where("action = ?", filter.Action)
+	}
+	if filter.EntityID != "" {
+		db = db.Where("entity_id = ?", filter.EntityID)
+	}
+	if filter.RequestID != "" {
+		db = db.Where("request_id = ?", filter.RequestID)
+	}
+	if filter.From != nil {
+		db = db.Where("created_at >= ?", *filter.From)
+	}
+	if filter.To != nil {
+		db = db.Where("created_at < ?", *filter.To)
+	}
+	return db
+}
@@ -0,0 +1,71 @@
+package repository
+
+import (
+	"context"
+	"go_study/migrate"
+	"go_study/model"
+	"testing"
+
+	"github.com/glebarez/sqlite"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+)
+
+// 테스트용 인메모리 감사 로그 저장소 (트리거 포함)
+func newTestAuditRepository() (*SQLiteAuditRepository, *gorm.DB) {
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
+	if err != nil {
+		panic("failed to connect database")
+	}
+	// 트리거는 마이그레이션에 있으므로 실제 마이그레이션으로 스키마를 만듦
+	if _, err := migrate.New(db, migrate.Options{}).Up(context.Background()); err != nil {
+		panic(err)
+	}
+	return NewSQLiteAuditRepository(db), db
+}
+
+func TestAuditRepository_AppendAndFind(t *testing.T) {
+	repo, _ := newTestAuditRepository()
+	assert.NoError(t, repo.Append(model.AuditEvent{Actor: "alice", Action: model.AuditTodoCreate, EntityID: "1", RequestID: "r1"}))
+	assert.NoError(t, repo.Append(model.AuditEvent{Actor: "bob", Action: model.AuditTodoDelete, EntityID: "1", RequestID: "r2"}))
+	assert.NoError(t, repo.Append(model.AuditEvent{Actor: "alice", Action: model.AuditTodoUpdate, EntityID: "2", RequestID: "r3"}))
+
+	// 최신순
+	all, err := repo.Find(AuditFilter{}, 10, 0)
+	assert.NoError(t, err)
+	assert.Len(t, all, 3)
+	assert.Equal(t, "r3", all[0].RequestID)
+
+	byActor, _ := repo.Find(AuditFilter{Actor: "alice"}, 10, 0)
+	assert.Len(t, byActor, 2)
+
+	byEntity, _ := repo.Find(AuditFilter{EntityID: "1", Action: model.AuditTodoDelete}, 10, 0)
+	assert.Len(t, byEntity, 1)
+	assert.Equal(t, "bob", byEntity[0].Actor)
+
+	page, _ := repo.Find(AuditFilter{}, 1, 1)
+	assert.Equal(t, "r2", page[0].RequestID)
+
+	// Stream은 오래된 순
+	var ids []string
+	assert.NoError(t, repo.Stream(AuditFilter{}, func(e model.AuditEvent) error {
+		ids = append(ids, e.RequestID)
+		return nil
+	}))
+	assert.Equal(t, []string{"r1", "r2", "r3"}, ids)
+}
+
+func TestAuditRepository_AppendOnly(t *testing.T) {
+	repo, db := newTestAuditRepository()
+	assert.NoError(t, repo.Append(model.AuditEvent{Actor: "alice", Action: model.AuditTodoCreate}))
+
+	// DB를 직접 건드려도 수정/삭제는 트리거가 막아야 함
+	err := db.Model(&model.AuditEvent{}).Where("id = ?", 1).Update("actor", "mallory").Error
+	assert.ErrorContains(t, err, "append-only")
+	err = db.Where("id = ?", 1).Delete(&model.AuditEvent{}).Error
+	assert.ErrorContains(t, err, "append-only")
+
+	events, _ := repo.Find(AuditFilter{}, 10, 0)
+	assert.Len(t, events, 1)
+	assert.Equal(t, "alice", events[0].Actor)
+}
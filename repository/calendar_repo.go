@@ -0,0 +1,46 @@
+package repository
+
+import (
+	"go_study/model"
+
+	"gorm.io/gorm"
+)
+
+// SQLiteCalendarTokenRepository: 달력 구독 토큰 저장소 (SQLite 구현체)
+type SQLiteCalendarTokenRepository struct {
+	db *gorm.DB
+}
+
+// 생성자 함수
+func NewSQLiteCalendarTokenRepository(db *gorm.DB) *SQLiteCalendarTokenRepository {
+	return &SQLiteCalendarTokenRepository{db: db}
+}
+
+func (r *SQLiteCalendarTokenRepository) Create(t model.CalendarToken) (model.CalendarToken, error) {
+	err := r.db.Create(&t).Error
+	return t, err
+}
+
+func (r *SQLiteCalendarTokenRepository) List() ([]model.CalendarToken, error) {
+	var tokens []model.CalendarToken
+	err := r.db.Order("id").Find(&tokens).Error
+	return tokens, err
+}
+
+// FindByToken: 토큰 문자열로 조회 (없으면 gorm.ErrRecordNotFound)
+func (r *SQLiteCalendarTokenRepository) FindByToken(token string) (model.CalendarToken, error) {
+	var t model.CalendarToken
+	err := r.db.Where("token = ?", token).First(&t).Error
+	return t, err
+}
+
+func (r *SQLiteCalendarTokenRepository) Delete(id string) error {
+	result := r.db.Delete(&model.CalendarToken{}, id)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
@@ -0,0 +1,34 @@
+package repository
+
+import (
+	"go_study/model"
+
+	"gorm.io/gorm"
+)
+
+// 임기를 저장하는 sequences 행 이름
+const termSeqName = "cluster_term"
+
+// SQLiteClusterRepository: Active 임기(term) 저장소 (SQLite 구현체)
+// 임기가 메모리에만 있으면 재시작한 서버가 0부터 다시 세서, 다음 승격 때 이미 쓴 임기를 또 받을 수 있습니다.
+type SQLiteClusterRepository struct {
+	db *gorm.DB
+}
+
+// 생성자 함수
+func NewSQLiteClusterRepository(db *gorm.DB) *SQLiteClusterRepository {
+	return &SQLiteClusterRepository{db: db}
+}
+
+// LoadTerm: 지금까지 저장된 가장 큰 임기 (없으면 0)
+func (r *SQLiteClusterRepository) LoadTerm() (int64, error) {
+	var term int64
+	err := r.db.Model(&model.Sequence{}).Where("name = ?", termSeqName).Select("value").Scan(&term).Error
+	return term, err
+}
+
+// SaveTerm: 임기 저장 (더 작은 값으로는 되돌리지 않음 - 같은 DB를 쓰는 다른 서버가 먼저 더 큰 값을 썼을 수 있음)
+func (r *SQLiteClusterRepository) SaveTerm(term int64) error {
+	return r.db.Exec(`INSERT INTO sequences (name, value) VALUES (?, ?)
+		ON CONFLICT(name) DO UPDATE SET value = MAX(value, excluded.value)`, termSeqName, term).Error
+}
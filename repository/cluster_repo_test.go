@@ -0,0 +1,27 @@
+package repository
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCluster_TermSurvivesRestart(t *testing.T) {
+	db := newTestSQLiteRepository().GetDB()
+	repo := NewSQLiteClusterRepository(db)
+
+	// 1. 저장된 적이 없으면 0
+	term, err := repo.LoadTerm()
+	assert.NoError(t, err)
+	assert.Equal(t, int64(0), term)
+
+	// 2. 저장한 임기는 새 저장소(재시작)에서도 보임
+	assert.NoError(t, repo.SaveTerm(3))
+	term, _ = NewSQLiteClusterRepository(db).LoadTerm()
+	assert.Equal(t, int64(3), term)
+
+	// 3. 더 작은 값으로는 되돌아가지 않음 (같은 DB를 쓰는 다른 서버가 더 큰 값을 썼을 때)
+	assert.NoError(t, repo.SaveTerm(2))
+	term, _ = repo.LoadTerm()
+	assert.Equal(t, int64(3), term)
+}
@@ -0,0 +1,66 @@
+package repository
+
+import (
+	"go_study/model"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// SQLiteIdempotencyRepository: Idempotency-Key 저장소 (SQLite 구현체)
+type SQLiteIdempotencyRepository struct {
+	db *gorm.DB
+}
+
+// 생성자 함수
+func NewSQLiteIdempotencyRepository(db *gorm.DB) *SQLiteIdempotencyRepository {
+	return &SQLiteIdempotencyRepository{db: db}
+}
+
+// Reserve: 키를 선점합니다.
+// 처음 들어온 키라면 rec를 저장하고 (rec, true)를, 이미 있는 키라면 (기존 레코드, false)를 반환합니다.
+// INSERT ... ON CONFLICT DO NOTHING 을 쓰기 때문에 두 서버가 동시에 선점하려 해도 한쪽만 성공합니다.
+func (r *SQLiteIdempotencyRepository) Reserve(rec model.IdempotencyRecord) (model.IdempotencyRecord, bool, error) {
+	// 1. 만료된 기존 레코드는 먼저 지워서 새 요청으로 취급
+	if err := r.db.Where("idem_key = ? AND scope = ? AND expires_at <= ?", rec.Key, rec.Scope, time.Now()).
+		Delete(&model.IdempotencyRecord{}).Error; err != nil {
+		return rec, false, err
+	}
+
+	// 2. 선점 시도
+	result := r.db.Clauses(clause.OnConflict{DoNothing: true}).Create(&rec)
+	if result.Error != nil {
+		return rec, false, result.Error
+	}
+	if result.RowsAffected == 1 {
+		return rec, true, nil
+	}
+
+	// 3. 이미 누가 선점함 -> 기존 레코드 조회
+	var existing model.IdempotencyRecord
+	if err := r.db.Where("idem_key = ? AND scope = ?", rec.Key, rec.Scope).First(&existing).Error; err != nil {
+		return rec, false, err
+	}
+	return existing, false, nil
+}
+
+// Complete: 처리가 끝난 응답을 저장 (이후 재시도는 이 응답을 그대로 받음)
+func (r *SQLiteIdempotencyRepository) Complete(id uint, status int, contentType string, body []byte) error {
+	return r.db.Model(&model.IdempotencyRecord{}).Where("id = ?", id).Updates(map[string]interface{}{
+		"status_code":  status,
+		"content_type": contentType,
+		"body":         body,
+	}).Error
+}
+
+// Release: 선점을 취소 (서버 에러 등으로 응답을 저장하지 않을 때, 클라이언트가 다시 시도할 수 있게)
+func (r *SQLiteIdempotencyRepository) Release(id uint) error {
+	return r.db.Delete(&model.IdempotencyRecord{}, id).Error
+}
+
+// DeleteExpired: TTL이 지난 레코드 일괄 삭제 (삭제된 개수 반환)
+func (r *SQLiteIdempotencyRepository) DeleteExpired(now time.Time) (int64, error) {
+	result := r.db.Where("expires_at <= ?", now).Delete(&model.IdempotencyRecord{})
+	return result.RowsAffected, result.Error
+}
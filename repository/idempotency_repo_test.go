@@ -0,0 +1,74 @@
+package repository
+
+import (
+	"go_study/model"
+	"testing"
+	"time"
+
+	"github.com/glebarez/sqlite"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+)
+
+// 테스트용 인메모리 Idempotency 저장소
+func newTestIdempotencyRepository() *SQLiteIdempotencyRepository {
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
+	if err != nil {
+		panic("failed to connect database")
+	}
+	db.AutoMigrate(&model.IdempotencyRecord{})
+	return NewSQLiteIdempotencyRepository(db)
+}
+
+func TestIdempotencyRepository_ReserveAndComplete(t *testing.T) {
+	repo := newTestIdempotencyRepository()
+	rec := model.IdempotencyRecord{Key: "abc", Scope: "POST /todos", RequestHash: "h1", ExpiresAt: time.Now().Add(time.Hour)}
+
+	// 1. 첫 선점은 성공
+	first, created, err := repo.Reserve(rec)
+	assert.NoError(t, err)
+	assert.True(t, created)
+
+	// 2. 같은 키로 다시 선점하면 기존 레코드(처리 중)를 돌려받음
+	second, created, err := repo.Reserve(rec)
+	assert.NoError(t, err)
+	assert.False(t, created)
+	assert.Equal(t, first.ID, second.ID)
+	assert.Equal(t, 0, second.StatusCode)
+
+	// 3. 응답 저장 후에는 저장된 응답이 보여야 함
+	assert.NoError(t, repo.Complete(first.ID, 201, "application/json", []byte(`{"id":1}`)))
+	third, _, _ := repo.Reserve(rec)
+	assert.Equal(t, 201, third.StatusCode)
+	assert.Equal(t, `{"id":1}`, string(third.Body))
+
+	// 4. 범위(Scope)가 다르면 별개의 키
+	_, created, err = repo.Reserve(model.IdempotencyRecord{Key: "abc", Scope: "POST /reports", ExpiresAt: time.Now().Add(time.Hour)})
+	assert.NoError(t, err)
+	assert.True(t, created)
+}
+
+func TestIdempotencyRepository_ExpiredAndRelease(t *testing.T) {
+	repo := newTestIdempotencyRepository()
+
+	// 만료된 레코드는 새 요청으로 취급
+	expired := model.IdempotencyRecord{Key: "old", Scope: "POST /todos", ExpiresAt: time.Now().Add(-time.Minute)}
+	_, created, _ := repo.Reserve(expired)
+	assert.True(t, created)
+	expired.ExpiresAt = time.Now().Add(time.Hour)
+	_, created, err := repo.Reserve(expired)
+	assert.NoError(t, err)
+	assert.True(t, created, "만료된 키는 다시 선점 가능해야 함")
+
+	// Release 후에는 다시 선점 가능
+	rec, _, _ := repo.Reserve(model.IdempotencyRecord{Key: "retry", Scope: "POST /todos", ExpiresAt: time.Now().Add(time.Hour)})
+	assert.NoError(t, repo.Release(rec.ID))
+	_, created, _ = repo.Reserve(model.IdempotencyRecord{Key: "retry", Scope: "POST /todos", ExpiresAt: time.Now().Add(time.Hour)})
+	assert.True(t, created)
+
+	// DeleteExpired
+	repo.Reserve(model.IdempotencyRecord{Key: "gone", Scope: "POST /todos", ExpiresAt: time.Now().Add(-time.Second)})
+	deleted, err := repo.DeleteExpired(time.Now())
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1), deleted)
+}
@@ -1,7 +1,9 @@
 package repository
 
 import (
+	"context"
 	"go_study/model"
+	"time"
 
 	"gorm.io/gorm"
 )
@@ -13,12 +15,135 @@ type TodoRepository interface {
 	GetAll() []model.Todo
 	Update(id string) (model.Todo, error)
 	Delete(id string) error
+	Restore(id string) (model.Todo, error) // 👈 [추가] 삭제 취소
 
 	// 👇 [추가] 통계 정보를 가져오는 함수 (전체 개수, 완료 개수, 에러)
 	GetStats() (int64, int64, error)
 	// 👇 [추가] 완료되지 않은 할 일만 가져오는 함수
 	GetPendingTodos() ([]model.Todo, error)
+	// 👇 [추가] 대시보드용 집계 (요청 단위 시간 제한을 위해 context를 받음)
+	Summary(ctx context.Context, now time.Time) (model.TodoSummary, error)
+	DailyCounts(ctx context.Context, since time.Time) ([]model.DailyCount, error)
+	TagCounts(ctx context.Context) (projects, contexts []model.TagCount, err error)
+	// 👇 [추가] 생산성 분석용: since 이후 완료됐거나 아직 열린 할 일
+	Timings(ctx context.Context, since time.Time) ([]model.Todo, error)
+
+	// 👇 [추가] 조건에 맞는 할 일을 ID 순서대로 하나씩 fn에 넘겨주는 함수 (내보내기용, 전체를 메모리에 올리지 않음)
+	Stream(filter TodoFilter, fn func(model.Todo) error) error
+	// 👇 [추가] 여러 개를 한 트랜잭션으로 저장하는 함수 (가져오기용)
+	SaveAll(todos []model.Todo) ([]model.Todo, error)
+
+	// 👇 [추가] 한 건 조회 (없으면 gorm.ErrRecordNotFound)
+	FindByID(id uint) (model.Todo, error)
+	FindByUID(uid string) (model.Todo, error)
+	// 👇 [추가] 할 일 내용 전체 덮어쓰기 (CalDAV PUT 등, 없으면 gorm.ErrRecordNotFound)
+	Replace(t model.Todo) (model.Todo, error)
+
+	// 👇 [추가] 오프라인 동기화용: 현재 변경 순번, since 이후 변경분(삭제 포함)
+	CurrentSeq() (int64, error)
+	Changes(since, until int64) ([]model.Todo, error)
+
+	// 👇 [추가] 변경 이력: 리비전 목록, 특정 리비전 내용으로 되돌리기
+	Revisions(todoID uint) ([]model.TodoRevision, error)
+	Revert(id uint, rev int) (model.Todo, error)
 
 	// 🚀 [추가] DB 연결 상태 확인용 접근자
 	GetDB() *gorm.DB
 }
+
+// TodoFilter: 목록 조회 조건 (비어 있으면 전체)
+type TodoFilter struct {
+	Done  *bool  // nil이면 완료 여부 상관없이
+	Query string // task에 포함된 문자열
+}
+
+// IdempotencyRepository: Idempotency-Key 별 최초 응답 저장소
+type IdempotencyRepository interface {
+	Reserve(rec model.IdempotencyRecord) (model.IdempotencyRecord, bool, error)
+	Complete(id uint, status int, contentType string, body []byte) error
+	Release(id uint) error
+	DeleteExpired(now time.Time) (int64, error)
+}
+
+// CalendarTokenRepository: 달력 구독 토큰 저장소
+type CalendarTokenRepository interface {
+	Create(t model.CalendarToken) (model.CalendarToken, error)
+	List() ([]model.CalendarToken, error)
+	FindByToken(token string) (model.CalendarToken, error)
+	Delete(id string) error
+}
+
+// AuditRepository: 감사 로그 저장소 (추가 전용)
+type AuditRepository interface {
+	Append(e model.AuditEvent) error
+	Find(filter AuditFilter, limit, offset int) ([]model.AuditEvent, error)
+	Stream(filter AuditFilter, fn func(model.AuditEvent) error) error
+}
+
+// AuditFilter: 감사 로그 조회 조건 (비어 있으면 전체)
+type AuditFilter struct {
+	Actor     string
+	Action    string
+	EntityID  string
+	RequestID string
+	From      *time.Time // 이 시각 이후 (포함)
+	To        *time.Time // 이 시각 이전 (미포함)
+}
+
+// WebhookRepository: 웹훅 구독자와 전송 대기열 저장소
+type WebhookRepository interface {
+	Create(w model.Webhook) (model.Webhook, error)
+	List() ([]model.Webhook, error)
+	Find(id uint) (model.Webhook, error)
+	Update(w model.Webhook) (model.Webhook, error)
+	Delete(id uint) error
+
+	Enqueue(event string, payload []byte) (int, error)
+	DueDeliveries(now time.Time, limit int) ([]model.WebhookDelivery, error)
+	RecordAttempt(d model.WebhookDelivery, attempt model.WebhookAttempt) error
+	Deliveries(webhookID uint, limit, offset int) ([]model.WebhookDelivery, error)
+	FindDelivery(webhookID, id uint) (model.WebhookDelivery, error)
+	Attempts(deliveryID uint) ([]model.WebhookAttempt, error)
+	Redeliver(webhookID, id uint) (model.WebhookDelivery, error)
+}
+
+// OutboxRepository: 발행 대기 이벤트 저장소 (이벤트 기록은 TodoRepository의 변경 트랜잭션 안에서)
+type OutboxRepository interface {
+	Pending(maxAttempts, limit int) ([]model.OutboxEvent, error)
+	MarkSent(id uint, at time.Time) error
+	MarkFailed(id uint, reason string, delivered []string) error
+	DeleteSentBefore(t time.Time) (int64, error)
+}
+
+// ClusterRepository: 재시작해도 유지되어야 하는 클러스터 상태 (Active 임기)
+type ClusterRepository interface {
+	LoadTerm() (int64, error)
+	SaveTerm(term int64) error
+}
+
+// JobRepository: 백그라운드 작업 큐 저장소
+type JobRepository interface {
+	Enqueue(j model.Job) (model.Job, error)
+	Claim(jobType, worker string, now, dueBy time.Time, visibility time.Duration, limit int) ([]model.Job, error)
+	Complete(j model.Job, at time.Time) error
+	Fail(j model.Job, reason string, retryAt *time.Time, at time.Time) error
+
+	Find(id uint) (model.Job, error)
+	List(filter JobFilter, limit, offset int) ([]model.Job, error)
+	Counts() (map[string]int64, error)
+	Retry(id uint, now time.Time) (model.Job, error)
+	DeleteFinishedBefore(t time.Time) (int64, error)
+}
+
+// JobFilter: 작업 조회 조건 (비어 있으면 전체)
+type JobFilter struct {
+	Status string
+	Type   string
+}
+
+// ReplicationRepository: 변경 순번 기반 복제 (Active: 변경분 읽기, Standby: 적용)
+type ReplicationRepository interface {
+	Position() (int64, error)
+	ChangesSince(since int64, limit int) (model.ChangeBatch, error)
+	Apply(batch model.ChangeBatch) error
+}
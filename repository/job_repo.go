@@ -0,0 +1,144 @@
+package repository
+
+import (
+	"errors"
+	"go_study/model"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// ErrJobNotFailed: 실패(failed) 상태가 아닌 작업은 다시 시도할 수 없음
+var ErrJobNotFailed = errors.New("job is not failed")
+
+// SQLiteJobRepository: 백그라운드 작업 큐 (SQLite 구현체)
+type SQLiteJobRepository struct {
+	db *gorm.DB
+}
+
+// 생성자 함수
+func NewSQLiteJobRepository(db *gorm.DB) *SQLiteJobRepository {
+	return &SQLiteJobRepository{db: db}
+}
+
+// Enqueue: 작업 추가
+// Key가 있고 같은 키의 작업이 이미 있으면 새로 만들지 않고 기존 작업을 돌려줍니다.
+func (r *SQLiteJobRepository) Enqueue(j model.Job) (model.Job, error) {
+	if j.Status == "" {
+		j.Status = model.JobQueued
+	}
+	if j.RunAt.IsZero() {
+		j.RunAt = time.Now()
+	}
+	result := r.db.Clauses(clause.OnConflict{DoNothing: true}).Create(&j)
+	if result.Error != nil || result.RowsAffected == 1 || j.Key == nil {
+		return j, result.Error
+	}
+	var existing model.Job
+	err := r.db.Where("key = ?", *j.Key).First(&existing).Error
+	return existing, err
+}
+
+// Claim: 실행할 작업을 최대 limit개 가져감 (worker가 visibility 동안 잡고 있음)
+// 대기 중이고 run_at이 dueBy 이전인 작업, 또는 실행 중이지만 locked_until이 지난 작업(잡고 있던 서버가 죽음)이 대상입니다.
+// 보통 dueBy는 now이고, 공유 DB의 Standby는 한참 밀린 작업만 가져가도록 더 이른 시각을 넘깁니다.
+// UPDATE ... RETURNING 한 문장이라 여러 서버가 동시에 가져가도 한 작업은 한 서버에만 갑니다.
+func (r *SQLiteJobRepository) Claim(jobType, worker string, now, dueBy time.Time, visibility time.Duration, limit int) ([]model.Job, error) {
+	var jobs []model.Job
+	err := r.db.Raw(`UPDATE jobs SET status = ?, locked_by = ?, locked_until = ?, attempts = attempts + 1, updated_at = ?
+		WHERE id IN (
+			SELECT id FROM jobs WHERE type = ? AND (
+				(status = ? AND julianday(run_at) <= julianday(?)) OR
+				(status = ? AND julianday(locked_until) < julianday(?)))
+			ORDER BY run_at, id LIMIT ?)
+		RETURNING *`,
+		model.JobRunning, worker, now.Add(visibility), now,
+		jobType, model.JobQueued, dueBy, model.JobRunning, now, limit,
+	).Scan(&jobs).Error
+	return jobs, err
+}
+
+// 가져간 그 실행이 아직 작업을 잡고 있는지 (visibility timeout이 지나 다시 나간 작업이면 결과를 쓰지 않음)
+func (r *SQLiteJobRepository) claimed(j model.Job) *gorm.DB {
+	return r.db.Model(&model.Job{}).Where("id = ? AND status = ? AND locked_by = ? AND attempts = ?", j.ID, model.JobRunning, j.LockedBy, j.Attempts)
+}
+
+// Complete: Claim으로 가져간 작업 성공 처리
+func (r *SQLiteJobRepository) Complete(j model.Job, at time.Time) error {
+	return r.claimed(j).
+		Updates(map[string]interface{}{
+			"status":       model.JobSucceeded,
+			"locked_until": nil,
+			"finished_at":  at,
+			"last_error":   "",
+		}).Error
+}
+
+// Fail: Claim으로 가져간 작업 실패 처리 (retryAt이 있으면 그때 다시 실행, nil이면 포기)
+func (r *SQLiteJobRepository) Fail(j model.Job, reason string, retryAt *time.Time, at time.Time) error {
+	updates := map[string]interface{}{"locked_until": nil, "last_error": reason}
+	if retryAt != nil {
+		updates["status"], updates["run_at"] = model.JobQueued, *retryAt
+	} else {
+		updates["status"], updates["finished_at"] = model.JobFailed, at
+	}
+	return r.claimed(j).Updates(updates).Error
+}
+
+// Find: 한 건 조회 (없으면 gorm.ErrRecordNotFound)
+func (r *SQLiteJobRepository) Find(id uint) (model.Job, error) {
+	var j model.Job
+	err := r.db.First(&j, id).Error
+	return j, err
+}
+
+// List: 최신순 조회
+func (r *SQLiteJobRepository) List(filter JobFilter, limit, offset int) ([]model.Job, error) {
+	q := r.db.Order("id DESC").Limit(limit).Offset(offset)
+	if filter.Status != "" {
+		q = q.Where("status = ?", filter.Status)
+	}
+	if filter.Type != "" {
+		q = q.Where("type = ?", filter.Type)
+	}
+	var jobs []model.Job
+	err := q.Find(&jobs).Error
+	return jobs, err
+}
+
+// Counts: 상태별 작업 수
+func (r *SQLiteJobRepository) Counts() (map[string]int64, error) {
+	var rows []struct {
+		Status string
+		Count  int64
+	}
+	if err := r.db.Model(&model.Job{}).Select("status, COUNT(*) AS count").Group("status").Scan(&rows).Error; err != nil {
+		return nil, err
+	}
+	counts := map[string]int64{model.JobQueued: 0, model.JobRunning: 0, model.JobSucceeded: 0, model.JobFailed: 0}
+	for _, row := range rows {
+		counts[row.Status] = row.Count
+	}
+	return counts, nil
+}
+
+// Retry: 포기한 작업을 처음부터 다시 시도 (없으면 gorm.ErrRecordNotFound, 실패 상태가 아니면 ErrJobNotFailed)
+func (r *SQLiteJobRepository) Retry(id uint, now time.Time) (model.Job, error) {
+	j, err := r.Find(id)
+	if err != nil {
+		return j, err
+	}
+	if j.Status != model.JobFailed {
+		return j, ErrJobNotFailed
+	}
+	j.Status, j.Attempts, j.RunAt, j.LockedBy, j.FinishedAt = model.JobQueued, 0, now, "", nil
+	err = r.db.Model(&j).Select("status", "attempts", "run_at", "locked_by", "finished_at").Updates(j).Error
+	return j, err
+}
+
+// DeleteFinishedBefore: 끝난 지 오래된 성공 작업 정리 (실패 작업은 사람이 볼 수 있도록 남김)
+func (r *SQLiteJobRepository) DeleteFinishedBefore(t time.Time) (int64, error) {
+	result := r.db.Where("status = ? AND julianday(finished_at) < julianday(?)", model.JobSucceeded, t).Delete(&model.Job{})
+	return result.RowsAffected, result.Error
+}
@@ -0,0 +1,51 @@
+package repository
+
+import (
+	"encoding/json"
+	"go_study/model"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// SQLiteOutboxRepository: outbox 이벤트 저장소 (SQLite 구현체)
+// 이벤트를 쓰는 쪽은 SQLiteRepository(같은 트랜잭션), 여기는 읽고 처리 결과를 남기는 쪽입니다.
+type SQLiteOutboxRepository struct {
+	db *gorm.DB
+}
+
+// 생성자 함수
+func NewSQLiteOutboxRepository(db *gorm.DB) *SQLiteOutboxRepository {
+	return &SQLiteOutboxRepository{db: db}
+}
+
+// Pending: 아직 발행되지 않았고 재시도 한도(maxAttempts)가 남은 이벤트 (저장된 순서대로 limit개)
+func (r *SQLiteOutboxRepository) Pending(maxAttempts, limit int) ([]model.OutboxEvent, error) {
+	var events []model.OutboxEvent
+	err := r.db.Where("sent_at IS NULL AND attempts < ?", maxAttempts).Order("id").Limit(limit).Find(&events).Error
+	return events, err
+}
+
+// MarkSent: 모든 구독자에게 넘김
+func (r *SQLiteOutboxRepository) MarkSent(id uint, at time.Time) error {
+	return r.db.Model(&model.OutboxEvent{}).Where("id = ?", id).Update("sent_at", at).Error
+}
+
+// MarkFailed: 구독자 중 하나가 실패함 (다음 주기에 다시 시도, delivered는 이미 받은 구독자 - 다시 보내지 않음)
+func (r *SQLiteOutboxRepository) MarkFailed(id uint, reason string, delivered []string) error {
+	names, err := json.Marshal(delivered)
+	if err != nil {
+		return err
+	}
+	return r.db.Model(&model.OutboxEvent{}).Where("id = ?", id).Updates(map[string]interface{}{
+		"attempts":   gorm.Expr("attempts + 1"),
+		"last_error": reason,
+		"delivered":  string(names),
+	}).Error
+}
+
+// DeleteSentBefore: 발행이 끝난 지 오래된 이벤트 정리 (삭제한 개수 반환)
+func (r *SQLiteOutboxRepository) DeleteSentBefore(t time.Time) (int64, error) {
+	result := r.db.Where("sent_at IS NOT NULL AND julianday(sent_at) < julianday(?)", t).Delete(&model.OutboxEvent{})
+	return result.RowsAffected, result.Error
+}
@@ -0,0 +1,94 @@
+package repository
+
+import (
+	"errors"
+	"fmt"
+	"go_study/model"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// ErrReplicationGap: 배치의 시작 순번이 복제본의 현재 순번과 다름 (순서대로 적용해야 함)
+var ErrReplicationGap = errors.New("replication gap")
+
+// SQLiteReplicationRepository: 변경 순번(seq) 기반 복제
+// Active 쪽에서는 변경분을 읽고(ChangesSince), Standby 쪽에서는 자기 DB에 적용합니다(Apply).
+// SQLite는 쓰기 트랜잭션이 하나씩만 돌기 때문에 순번을 받은 순서와 커밋 순서가 같아서, 순번만 따라가면 빠지는 변경이 없습니다.
+type SQLiteReplicationRepository struct {
+	db *gorm.DB
+}
+
+// 생성자 함수
+func NewSQLiteReplicationRepository(db *gorm.DB) *SQLiteReplicationRepository {
+	return &SQLiteReplicationRepository{db: db}
+}
+
+// Position: 이 DB가 반영한 마지막 변경 순번
+func (r *SQLiteReplicationRepository) Position() (int64, error) {
+	var seq int64
+	err := r.db.Model(&model.Sequence{}).Where("name = ?", todoSeqName).Select("value").Scan(&seq).Error
+	return seq, err
+}
+
+// ChangesSince: since 이후 변경분을 최대 limit개 (한 트랜잭션 안에서 읽어서 할 일과 리비전이 같은 시점)
+// 할 일은 행 하나에 마지막 변경 순번(seq)만 남으므로 그 순번 기준으로 페이지를 나눕니다.
+// 그래서 To까지 적용한 DB가 Active의 To 시점 스냅샷은 아닙니다: To 이전에 바뀌었다가 To 이후에 다시 바뀐 할 일은
+// 나중 배치에서 최신 상태로 오므로, Head까지 따라잡기 전에는 그런 할 일이 아직 없거나 옛 리비전만 있을 수 있습니다.
+func (r *SQLiteReplicationRepository) ChangesSince(since int64, limit int) (model.ChangeBatch, error) {
+	batch := model.ChangeBatch{Since: since, Todos: []model.ReplicatedTodo{}, Revisions: []model.TodoRevision{}}
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Model(&model.Sequence{}).Where("name = ?", todoSeqName).Select("value").Scan(&batch.Head).Error; err != nil {
+			return err
+		}
+		var todos []model.Todo
+		if err := tx.Unscoped().Where("seq > ? AND seq <= ?", since, batch.Head).Order("seq").Limit(limit).Find(&todos).Error; err != nil {
+			return err
+		}
+		batch.To = batch.Head
+		if len(todos) == limit {
+			batch.To = todos[len(todos)-1].Seq
+		}
+		if batch.To < since {
+			batch.To = since
+		}
+		for _, t := range todos {
+			batch.Todos = append(batch.Todos, model.NewReplicatedTodo(t))
+		}
+		return tx.Where("seq > ? AND seq <= ?", since, batch.To).Order("seq, id").Find(&batch.Revisions).Error
+	})
+	return batch, err
+}
+
+// Apply: 변경분을 이 DB에 한 트랜잭션으로 적용하고 변경 순번을 batch.To로 맞춤
+// 복제본의 현재 순번이 batch.Since와 다르면 ErrReplicationGap
+func (r *SQLiteReplicationRepository) Apply(batch model.ChangeBatch) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		var pos int64
+		if err := tx.Model(&model.Sequence{}).Where("name = ?", todoSeqName).Select("value").Scan(&pos).Error; err != nil {
+			return err
+		}
+		if pos != batch.Since {
+			return fmt.Errorf("%w: at %d, batch starts at %d", ErrReplicationGap, pos, batch.Since)
+		}
+
+		for _, rt := range batch.Todos {
+			row := rt.Row()
+			if err := tx.Unscoped().Clauses(clause.OnConflict{UpdateAll: true}).Create(&row).Error; err != nil {
+				return err
+			}
+		}
+		for _, rev := range batch.Revisions {
+			rev.ID = 0
+			if err := tx.Clauses(clause.OnConflict{
+				Columns:   []clause.Column{{Name: "todo_id"}, {Name: "rev"}},
+				DoNothing: true,
+			}).Create(&rev).Error; err != nil {
+				return err
+			}
+		}
+		// 승격된 뒤 새 변경이 batch.To 다음 번호부터 받도록
+		return tx.Exec(`INSERT INTO sequences (name, value) VALUES (?, ?)
+			ON CONFLICT(name) DO UPDATE SET value = excluded.value`, todoSeqName, batch.To).Error
+	})
+}
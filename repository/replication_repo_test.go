@@ -0,0 +1,58 @@
+package repository
+
+import (
+	"fmt"
+	"go_study/model"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReplication_ChangesSinceAndApply(t *testing.T) {
+	source := newTestSQLiteRepository()
+	replica := newTestSQLiteRepository()
+	from := NewSQLiteReplicationRepository(source.GetDB())
+	to := NewSQLiteReplicationRepository(replica.GetDB())
+
+	a, _ := source.Save(model.Todo{Task: "A"})
+	b, _ := source.Save(model.Todo{Task: "B"})
+	source.Update(fmt.Sprint(a.ID))
+	source.Delete(fmt.Sprint(b.ID))
+
+	// 1. 배치 크기만큼 끊어서 순서대로 적용 (seq 1~4 중 마지막 상태는 a=3, b=4)
+	batch, err := from.ChangesSince(0, 1)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(4), batch.Head)
+	assert.Equal(t, int64(3), batch.To)
+	assert.Len(t, batch.Todos, 1)
+	assert.Len(t, batch.Revisions, 3) // a 생성, b 생성, a 수정
+	assert.NoError(t, to.Apply(batch))
+
+	// 같은 배치를 다시 적용하거나 건너뛰면 gap
+	assert.ErrorIs(t, to.Apply(batch), ErrReplicationGap)
+
+	batch, _ = from.ChangesSince(3, 100)
+	assert.Equal(t, int64(4), batch.To)
+	assert.NoError(t, to.Apply(batch))
+	pos, _ := to.Position()
+	assert.Equal(t, int64(4), pos)
+
+	// 2. 복제본 내용이 원본과 같음 (삭제 상태, 수정 시각, 리비전 포함)
+	assert.Equal(t, source.GetAll(), replica.GetAll())
+	assert.Len(t, replica.GetAll(), 1)
+	ra, _ := replica.FindByID(a.ID)
+	sa, _ := source.FindByID(a.ID)
+	assert.True(t, ra.Done)
+	assert.True(t, sa.UpdatedAt.Equal(ra.UpdatedAt))
+	revs, _ := replica.Revisions(b.ID)
+	assert.Len(t, revs, 2)
+
+	// 3. 변경이 없으면 빈 배치
+	batch, _ = from.ChangesSince(4, 100)
+	assert.Empty(t, batch.Todos)
+	assert.Equal(t, int64(4), batch.To)
+
+	// 4. 승격 후 새 변경은 이어지는 순번을 받음
+	c, _ := replica.Save(model.Todo{Task: "C"})
+	assert.Equal(t, int64(5), c.Seq)
+}
@@ -0,0 +1,142 @@
+package repository
+
+import (
+	"context"
+	"go_study/model"
+	"sort"
+	"time"
+)
+
+// -------------------------------------------------------
+// [Dashboard용] 집계 쿼리
+// 대시보드가 여러 개를 동시에 부르고 요청 단위로 시간 제한을 걸 수 있도록 모두 context를 받습니다.
+// -------------------------------------------------------
+
+// Summary: 전체 / 완료 / 기한 초과 개수와 평균 완료 소요 시간을 한 번에 집계
+func (r *SQLiteRepository) Summary(ctx context.Context, now time.Time) (model.TodoSummary, error) {
+	var row struct {
+		Total   int64
+		Done    int64
+		Overdue int64
+		AvgDays float64
+	}
+	// 시각은 "2025-01-02 10:00:00+09:00" 같은 문자열로 저장되므로 julianday로 바꿔서 비교/계산
+	err := r.db.WithContext(ctx).Model(&model.Todo{}).Select(`
+		COUNT(*) AS total,
+		COALESCE(SUM(CASE WHEN done THEN 1 ELSE 0 END), 0) AS done,
+		COALESCE(SUM(CASE WHEN NOT done AND due_at IS NOT NULL AND julianday(due_at) < julianday(?) THEN 1 ELSE 0 END), 0) AS overdue,
+		COALESCE(AVG(CASE WHEN done THEN julianday(completed_at) - julianday(created_at) END), 0) AS avg_days`, now).
+		Scan(&row).Error
+	if err != nil {
+		return model.TodoSummary{}, err
+	}
+	return model.TodoSummary{
+		Total:                row.Total,
+		Done:                 row.Done,
+		Overdue:              row.Overdue,
+		AvgCompletionSeconds: row.AvgDays * 24 * 60 * 60,
+	}, nil
+}
+
+// DailyCounts: since 이후 날짜별(UTC) 생성 / 완료 개수 (날짜 오름차순, 아무 일도 없던 날은 빠짐)
+func (r *SQLiteRepository) DailyCounts(ctx context.Context, since time.Time) ([]model.DailyCount, error) {
+	type dayCount struct {
+		Day   string
+		Count int64
+	}
+	var created, completed []dayCount
+	err := r.db.WithContext(ctx).Model(&model.Todo{}).Select("date(created_at) AS day, COUNT(*) AS count").
+		Where("julianday(created_at) >= julianday(?)", since).Group("day").Scan(&created).Error
+	if err != nil {
+		return nil, err
+	}
+	err = r.db.WithContext(ctx).Model(&model.Todo{}).Select("date(completed_at) AS day, COUNT(*) AS count").
+		Where("done AND julianday(completed_at) >= julianday(?)", since).Group("day").Scan(&completed).Error
+	if err != nil {
+		return nil, err
+	}
+
+	byDay := map[string]*model.DailyCount{}
+	get := func(day string) *model.DailyCount {
+		if byDay[day] == nil {
+			byDay[day] = &model.DailyCount{Date: day}
+		}
+		return byDay[day]
+	}
+	for _, c := range created {
+		get(c.Day).Created = c.Count
+	}
+	for _, c := range completed {
+		get(c.Day).Completed = c.Count
+	}
+	days := make([]model.DailyCount, 0, len(byDay))
+	for _, d := range byDay {
+		days = append(days, *d)
+	}
+	sort.Slice(days, func(i, j int) bool { return days[i].Date < days[j].Date })
+	return days, nil
+}
+
+// TagCounts: +프로젝트 / @컨텍스트별 개수 (개수 많은 순)
+// 태그는 task 문장 안에 있어서 SQL로 나눌 수 없으므로, task와 done만 읽어서 Go에서 셉니다.
+func (r *SQLiteRepository) TagCounts(ctx context.Context) (projects, contexts []model.TagCount, err error) {
+	rows, err := r.db.WithContext(ctx).Model(&model.Todo{}).Select("task", "done").
+		Where("task LIKE '%+%' OR task LIKE '%@%'").Rows()
+	if err != nil {
+		return nil, nil, err
+	}
+	defer rows.Close()
+
+	projectCounts := map[string]*model.TagCount{}
+	contextCounts := map[string]*model.TagCount{}
+	add := func(m map[string]*model.TagCount, name string, done bool) {
+		if m[name] == nil {
+			m[name] = &model.TagCount{Name: name}
+		}
+		m[name].Total++
+		if done {
+			m[name].Done++
+		}
+	}
+	for rows.Next() {
+		var task string
+		var done bool
+		if err := rows.Scan(&task, &done); err != nil {
+			return nil, nil, err
+		}
+		ps, cs := model.TagsOf(task)
+		for _, p := range ps {
+			add(projectCounts, p, done)
+		}
+		for _, c := range cs {
+			add(contextCounts, c, done)
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return nil, nil, err
+	}
+	return sortedTagCounts(projectCounts), sortedTagCounts(contextCounts), nil
+}
+
+func sortedTagCounts(m map[string]*model.TagCount) []model.TagCount {
+	counts := make([]model.TagCount, 0, len(m))
+	for _, c := range m {
+		counts = append(counts, *c)
+	}
+	sort.Slice(counts, func(i, j int) bool {
+		if counts[i].Total != counts[j].Total {
+			return counts[i].Total > counts[j].Total
+		}
+		return counts[i].Name < counts[j].Name
+	})
+	return counts
+}
+
+// [Analytics용] since 이후에 완료됐거나 아직 열려 있는 할 일 (id, task, done, 생성/완료 시각만)
+func (r *SQLiteRepository) Timings(ctx context.Context, since time.Time) ([]model.Todo, error) {
+	var todos []model.Todo
+	err := r.db.WithContext(ctx).Select("id", "task", "done", "created_at", "completed_at").
+		Where("completed_at IS NULL OR julianday(completed_at) >= julianday(?)", since).
+		Order("id").Find(&todos).Error
+	return todos, err
+}
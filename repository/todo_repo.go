@@ -1,7 +1,11 @@
 package repository
 
 import (
+	"encoding/json"
+	"errors"
 	"go_study/model"
+	"strings"
+	"time"
 
 	"gorm.io/gorm"
 )
@@ -21,10 +25,89 @@ func NewSQLiteRepository(db *gorm.DB) *SQLiteRepository {
 // 메소드 이름과 시그니처가 interface.go에 정의된 것과 똑같아야 합니다.
 // -------------------------------------------------------
 
+// todos 변경 순번의 카운터 이름
+const todoSeqName = "todos"
+
+// nextSeq: 변경 순번 발급 (반드시 변경과 같은 트랜잭션 안에서 호출)
+// UPSERT ... RETURNING 한 문장으로 증가와 조회를 동시에 하므로 두 서버가 동시에 불러도 겹치지 않고,
+// SQLite는 쓰기 트랜잭션을 한 번에 하나만 허용하므로 커밋 순서도 순번 순서와 같습니다.
+func nextSeq(tx *gorm.DB) (int64, error) {
+	var seq int64
+	err := tx.Raw(`INSERT INTO sequences (name, value) VALUES (?, 1)
+		ON CONFLICT(name) DO UPDATE SET value = value + 1 RETURNING value`, todoSeqName).Scan(&seq).Error
+	return seq, err
+}
+
+// ErrRevisionNotFound: 요청한 리비전 번호가 그 할 일에 없음
+var ErrRevisionNotFound = errors.New("revision not found")
+
+// recordChange: 변경된 할 일의 리비전과 outbox 이벤트를 저장 (반드시 변경과 같은 트랜잭션 안에서 호출)
+// 같은 트랜잭션이므로 프로세스가 중간에 죽어도 "변경은 됐는데 이벤트는 없는" 상태가 생기지 않습니다.
+func recordChange(tx *gorm.DB, t model.Todo, action, event string) error {
+	if err := writeRevision(tx, t, action); err != nil {
+		return err
+	}
+	return writeOutbox(tx, event, t)
+}
+
+// writeOutbox: 이벤트를 outbox 테이블에 저장 (발행은 outbox.Dispatcher가 커밋 후에 함)
+func writeOutbox(tx *gorm.DB, event string, t model.Todo) error {
+	payload, err := json.Marshal(t)
+	if err != nil {
+		return err
+	}
+	return tx.Create(&model.OutboxEvent{Event: event, TodoID: t.ID, Payload: string(payload)}).Error
+}
+
+// writeRevision: 변경된 할 일의 현재 내용을 리비전으로 저장
+func writeRevision(tx *gorm.DB, t model.Todo, action string) error {
+	var last int
+	if err := tx.Model(&model.TodoRevision{}).Where("todo_id = ?", t.ID).Select("COALESCE(MAX(rev), 0)").Scan(&last).Error; err != nil {
+		return err
+	}
+	return tx.Create(&model.TodoRevision{
+		TodoID:   t.ID,
+		Rev:      last + 1,
+		Seq:      t.Seq,
+		Action:   action,
+		Task:     t.Task,
+		Done:     t.Done,
+		Priority: t.Priority,
+		DueAt:    t.DueAt,
+	}).Error
+}
+
+// completionTime: 저장할 완료 시각
+// 완료로 바뀌면 지금(클라이언트가 준 값이 있으면 그 값), 계속 완료 상태면 기존 값, 미완료면 nil
+func completionTime(prev model.Todo, done bool, given *time.Time) *time.Time {
+	if !done {
+		return nil
+	}
+	if given != nil {
+		return given
+	}
+	if prev.Done && prev.CompletedAt != nil {
+		return prev.CompletedAt
+	}
+	now := time.Now()
+	return &now
+}
+
 func (r *SQLiteRepository) Save(t model.Todo) (model.Todo, error) {
 	// r.db 를 사용 (전역변수 db가 아님)
-	result := r.db.Create(&t)
-	return t, result.Error
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		seq, err := nextSeq(tx)
+		if err != nil {
+			return err
+		}
+		t.Seq = seq
+		t.CompletedAt = completionTime(model.Todo{}, t.Done, t.CompletedAt)
+		if err := tx.Create(&t).Error; err != nil {
+			return err
+		}
+		return recordChange(tx, t, model.RevisionCreate, model.EventTodoCreated)
+	})
+	return t, err
 }
 
 func (r *SQLiteRepository) GetAll() []model.Todo {
@@ -35,28 +118,56 @@ func (r *SQLiteRepository) GetAll() []model.Todo {
 
 func (r *SQLiteRepository) Update(id string) (model.Todo, error) {
 	var todo model.Todo
-	if err := r.db.First(&todo, id).Error; err != nil {
-		return todo, err
-	}
-	err := r.db.Model(&todo).Update("done", !todo.Done).Error
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.First(&todo, id).Error; err != nil {
+			return err
+		}
+		seq, err := nextSeq(tx)
+		if err != nil {
+			return err
+		}
+		completedAt := completionTime(todo, !todo.Done, nil)
+		if err := tx.Model(&todo).Updates(map[string]interface{}{"done": !todo.Done, "completed_at": completedAt, "seq": seq}).Error; err != nil {
+			return err
+		}
+		return recordChange(tx, todo, model.RevisionUpdate, model.EventTodoUpdated)
+	})
 	return todo, err
 }
 
 func (r *SQLiteRepository) Delete(id string) error {
-	// 1. 삭제 명령 실행
-	result := r.db.Delete(&model.Todo{}, id)
+	// 리비전에 남길 삭제 직전 내용
+	var todo model.Todo
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.First(&todo, id).Error; err != nil {
+			return err
+		}
+		seq, err := nextSeq(tx)
+		if err != nil {
+			return err
+		}
+		// 0. Soft Delete는 deleted_at만 바꾸므로, 동기화 클라이언트가 알 수 있게 순번을 먼저 올림
+		if err := tx.Model(&model.Todo{}).Where("id = ?", id).Update("seq", seq).Error; err != nil {
+			return err
+		}
 
-	// 2. DB 에러 체크 (문법 에러나 커넥션 에러 등)
-	if result.Error != nil {
-		return result.Error
-	}
+		// 1. 삭제 명령 실행
+		result := tx.Delete(&model.Todo{}, id)
 
-	// 3. ✨ 영향받은 행 개수 체크 (C의 SQL%ROWCOUNT)
-	if result.RowsAffected == 0 {
-		// GORM에 정의된 "데이터 없음" 에러를 리턴합니다.
-		return gorm.ErrRecordNotFound
-	}
-	return nil
+		// 2. DB 에러 체크 (문법 에러나 커넥션 에러 등)
+		if result.Error != nil {
+			return result.Error
+		}
+
+		// 3. ✨ 영향받은 행 개수 체크 (C의 SQL%ROWCOUNT)
+		if result.RowsAffected == 0 {
+			// GORM에 정의된 "데이터 없음" 에러를 리턴합니다. (트랜잭션이 롤백되므로 발급한 순번도 되돌아감)
+			return gorm.ErrRecordNotFound
+		}
+		todo.Seq = seq
+		return recordChange(tx, todo, model.RevisionDelete, model.EventTodoDeleted)
+	})
+	return err
 }
 
 // [Dashboard용] 통계 쿼리 (SELECT COUNT)
@@ -86,6 +197,182 @@ func (r *SQLiteRepository) GetPendingTodos() ([]model.Todo, error) {
 	return todos, nil
 }
 
+// [Export용] 조건에 맞는 할 일을 배치(500개) 단위로 읽어서 하나씩 넘겨줌
+// 커서를 오래 잡고 있지 않도록 FindInBatches(기본키 기준 페이지 조회)를 사용합니다.
+func (r *SQLiteRepository) Stream(filter TodoFilter, fn func(model.Todo) error) error {
+	var batch []model.Todo
+	var fnErr error
+	result := applyTodoFilter(r.db, filter).FindInBatches(&batch, 500, func(tx *gorm.DB, _ int) error {
+		for _, t := range batch {
+			if fnErr = fn(t); fnErr != nil {
+				return fnErr
+			}
+		}
+		return nil
+	})
+	if fnErr != nil {
+		return fnErr
+	}
+	return result.Error
+}
+
+// [Import용] 여러 건을 한 번에 저장 (중간에 실패하면 전부 롤백)
+func (r *SQLiteRepository) SaveAll(todos []model.Todo) ([]model.Todo, error) {
+	if len(todos) == 0 {
+		return todos, nil
+	}
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		for i := range todos {
+			seq, err := nextSeq(tx)
+			if err != nil {
+				return err
+			}
+			todos[i].Seq = seq
+			todos[i].CompletedAt = completionTime(model.Todo{}, todos[i].Done, todos[i].CompletedAt)
+		}
+		if err := tx.CreateInBatches(&todos, 100).Error; err != nil {
+			return err
+		}
+		for _, t := range todos {
+			if err := recordChange(tx, t, model.RevisionCreate, model.EventTodoCreated); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	return todos, err
+}
+
+func (r *SQLiteRepository) FindByID(id uint) (model.Todo, error) {
+	var todo model.Todo
+	err := r.db.First(&todo, id).Error
+	return todo, err
+}
+
+// FindByUID: CalDAV 클라이언트가 정한 UID로 조회
+func (r *SQLiteRepository) FindByUID(uid string) (model.Todo, error) {
+	var todo model.Todo
+	err := r.db.Where("uid = ?", uid).First(&todo).Error
+	return todo, err
+}
+
+// Replace: 사용자가 바꿀 수 있는 필드(task, done, priority, due_at, uid)를 통째로 덮어씀
+func (r *SQLiteRepository) Replace(t model.Todo) (model.Todo, error) {
+	var todo model.Todo
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.First(&todo, t.ID).Error; err != nil {
+			return err
+		}
+		seq, err := nextSeq(tx)
+		if err != nil {
+			return err
+		}
+		t.Seq = seq
+		t.CompletedAt = completionTime(todo, t.Done, t.CompletedAt)
+		// Select로 지정해야 false / 0 / nil 같은 제로값도 그대로 저장됨
+		if err := tx.Model(&todo).Select("task", "done", "priority", "due_at", "completed_at", "uid", "seq").Updates(t).Error; err != nil {
+			return err
+		}
+		// 바뀐 값(수정 시각 포함)을 다시 읽어서 반환
+		if err := tx.First(&todo, t.ID).Error; err != nil {
+			return err
+		}
+		return recordChange(tx, todo, model.RevisionUpdate, model.EventTodoUpdated)
+	})
+	return todo, err
+}
+
+// Restore: Soft Delete된 할 일 되살리기 (삭제된 적이 없거나 아예 없으면 gorm.ErrRecordNotFound)
+func (r *SQLiteRepository) Restore(id string) (model.Todo, error) {
+	var todo model.Todo
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Unscoped().Where("deleted_at IS NOT NULL").First(&todo, id).Error; err != nil {
+			return err
+		}
+		seq, err := nextSeq(tx)
+		if err != nil {
+			return err
+		}
+		if err := tx.Unscoped().Model(&todo).Updates(map[string]interface{}{"deleted_at": nil, "seq": seq}).Error; err != nil {
+			return err
+		}
+		if err := tx.First(&todo, todo.ID).Error; err != nil {
+			return err
+		}
+		return recordChange(tx, todo, model.RevisionRestore, model.EventTodoRestored)
+	})
+	return todo, err
+}
+
+// Revisions: 할 일의 리비전 목록 (오래된 순, 삭제된 할 일 포함)
+func (r *SQLiteRepository) Revisions(todoID uint) ([]model.TodoRevision, error) {
+	var revs []model.TodoRevision
+	err := r.db.Where("todo_id = ?", todoID).Order("rev").Find(&revs).Error
+	return revs, err
+}
+
+// Revert: 할 일 내용(task, done, priority, due_at)을 rev번 리비전으로 되돌림
+// 되돌린 결과도 새 리비전으로 남으므로 되돌리기 자체를 다시 되돌릴 수 있습니다.
+// 할 일이 없거나 삭제되어 있으면 gorm.ErrRecordNotFound, 리비전이 없으면 ErrRevisionNotFound
+func (r *SQLiteRepository) Revert(id uint, rev int) (model.Todo, error) {
+	var todo model.Todo
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.First(&todo, id).Error; err != nil {
+			return err
+		}
+		var target model.TodoRevision
+		if err := tx.Where("todo_id = ? AND rev = ?", id, rev).First(&target).Error; err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return ErrRevisionNotFound
+			}
+			return err
+		}
+		seq, err := nextSeq(tx)
+		if err != nil {
+			return err
+		}
+		err = tx.Model(&todo).Updates(map[string]interface{}{
+			"task": target.Task, "done": target.Done, "priority": target.Priority, "due_at": target.DueAt,
+			"completed_at": completionTime(todo, target.Done, nil), "seq": seq,
+		}).Error
+		if err != nil {
+			return err
+		}
+		if err := tx.First(&todo, id).Error; err != nil {
+			return err
+		}
+		return recordChange(tx, todo, model.RevisionRevert, model.EventTodoUpdated)
+	})
+	return todo, err
+}
+
+// [Sync용] 지금까지 발급된 가장 큰 변경 순번 (한 번도 변경이 없었으면 0)
+func (r *SQLiteRepository) CurrentSeq() (int64, error) {
+	var seq int64
+	err := r.db.Model(&model.Sequence{}).Where("name = ?", todoSeqName).Select("value").Scan(&seq).Error
+	return seq, err
+}
+
+// [Sync용] since < seq <= until 인 할 일 (삭제된 것 포함, 순번 순서)
+func (r *SQLiteRepository) Changes(since, until int64) ([]model.Todo, error) {
+	var todos []model.Todo
+	err := r.db.Unscoped().Where("seq > ? AND seq <= ?", since, until).Order("seq").Find(&todos).Error
+	return todos, err
+}
+
+// 조회 조건을 WHERE 절로 변환
+func applyTodoFilter(db *gorm.DB, filter TodoFilter) *gorm.DB {
+	if filter.Done != nil {
+		db = db.Where("done = ?", *filter.Done)
+	}
+	if filter.Query != "" {
+		// %, _ 가 검색어에 있으면 와일드카드가 아니라 글자 그대로 찾도록 이스케이프
+		escaped := strings.NewReplacer(`\`, `\\`, "%", `\%`, "_", `\_`).Replace(filter.Query)
+		db = db.Where(`task LIKE ? ESCAPE '\'`, "%"+escaped+"%")
+	}
+	return db
+}
+
 // GetDB: 내부의 gorm.DB 객체를 반환 (Health Check 용도)
 func (r *SQLiteRepository) GetDB() *gorm.DB {
 	return r.db
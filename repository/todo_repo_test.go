@@ -1,9 +1,11 @@
 package repository
 
 import (
+	"context"
 	"fmt"
 	"go_study/model"
 	"testing"
+	"time"
 
 	"github.com/glebarez/sqlite"
 	"github.com/stretchr/testify/assert"
@@ -17,7 +19,7 @@ func newTestSQLiteRepository() *SQLiteRepository {
 	if err != nil {
 		panic("failed to connect database")
 	}
-	db.AutoMigrate(&model.Todo{})
+	db.AutoMigrate(&model.Todo{}, &model.Sequence{}, &model.TodoRevision{}, &model.OutboxEvent{})
 
 	// 우리가 만든 생성자 함수를 이용해 Repository 인스턴스 반환
 	return NewSQLiteRepository(db)
@@ -72,3 +74,204 @@ func TestSQLiteRepository_UpdateAndDelete(t *testing.T) {
 	todos := repo.GetAll()
 	assert.Equal(t, 0, len(todos), "데이터가 비어 있어야 함")
 }
+
+func TestSQLiteRepository_SaveAllAndStream(t *testing.T) {
+	repo := newTestSQLiteRepository()
+
+	saved, err := repo.SaveAll([]model.Todo{
+		{Task: "장보기"},
+		{Task: "100% 완료", Done: true},
+		{Task: "청소"},
+	})
+	assert.NoError(t, err)
+	assert.Len(t, saved, 3)
+	assert.NotZero(t, saved[2].ID)
+
+	// 필터 없이 전체
+	var all []string
+	assert.NoError(t, repo.Stream(TodoFilter{}, func(t model.Todo) error {
+		all = append(all, t.Task)
+		return nil
+	}))
+	assert.Equal(t, []string{"장보기", "100% 완료", "청소"}, all)
+
+	// 완료 여부 + 검색어 (%는 글자 그대로 검색)
+	done := false
+	var pending []string
+	repo.Stream(TodoFilter{Done: &done}, func(t model.Todo) error {
+		pending = append(pending, t.Task)
+		return nil
+	})
+	assert.Equal(t, []string{"장보기", "청소"}, pending)
+
+	var matched []string
+	repo.Stream(TodoFilter{Query: "0%"}, func(t model.Todo) error {
+		matched = append(matched, t.Task)
+		return nil
+	})
+	assert.Equal(t, []string{"100% 완료"}, matched)
+}
+
+func TestSQLiteRepository_ChangeSequence(t *testing.T) {
+	repo := newTestSQLiteRepository()
+
+	seq, err := repo.CurrentSeq()
+	assert.NoError(t, err)
+	assert.Equal(t, int64(0), seq, "변경이 없으면 0")
+
+	a, _ := repo.Save(model.Todo{Task: "A"})
+	b, _ := repo.Save(model.Todo{Task: "B"})
+	assert.Equal(t, int64(1), a.Seq)
+	assert.Equal(t, int64(2), b.Seq)
+
+	// 수정과 삭제도 순번을 올림
+	toggled, _ := repo.Update(fmt.Sprint(a.ID))
+	assert.Equal(t, int64(3), toggled.Seq)
+	assert.NoError(t, repo.Delete(fmt.Sprint(b.ID)))
+	assert.Error(t, repo.Delete("999"))
+
+	seq, _ = repo.CurrentSeq()
+	assert.Equal(t, int64(4), seq, "없는 ID 삭제는 순번을 소모하지 않아야 함")
+
+	// since=2 이후: A 수정(3), B 삭제(4, tombstone)
+	changes, err := repo.Changes(2, seq)
+	assert.NoError(t, err)
+	if assert.Len(t, changes, 2) {
+		assert.Equal(t, a.ID, changes[0].ID)
+		assert.False(t, changes[0].DeletedAt.Valid)
+		assert.Equal(t, b.ID, changes[1].ID)
+		assert.True(t, changes[1].DeletedAt.Valid)
+	}
+}
+
+func TestSQLiteRepository_RevisionsAndRevert(t *testing.T) {
+	repo := newTestSQLiteRepository()
+	created, _ := repo.Save(model.Todo{Task: "초안"})
+	id := fmt.Sprint(created.ID)
+	repo.Replace(model.Todo{ID: created.ID, Task: "수정본", Priority: 2})
+	repo.Update(id)
+
+	revs, err := repo.Revisions(created.ID)
+	assert.NoError(t, err)
+	assert.Len(t, revs, 3)
+	assert.Equal(t, []string{model.RevisionCreate, model.RevisionUpdate, model.RevisionUpdate},
+		[]string{revs[0].Action, revs[1].Action, revs[2].Action})
+	assert.Equal(t, "수정본", revs[2].Task)
+	assert.True(t, revs[2].Done)
+
+	// 1번 리비전으로 되돌리면 내용은 초안, 리비전은 하나 더 쌓임
+	reverted, err := repo.Revert(created.ID, 1)
+	assert.NoError(t, err)
+	assert.Equal(t, "초안", reverted.Task)
+	assert.False(t, reverted.Done)
+	assert.Equal(t, 0, reverted.Priority)
+	revs, _ = repo.Revisions(created.ID)
+	assert.Equal(t, model.RevisionRevert, revs[3].Action)
+	assert.Equal(t, reverted.Seq, revs[3].Seq)
+
+	_, err = repo.Revert(created.ID, 99)
+	assert.ErrorIs(t, err, ErrRevisionNotFound)
+
+	// 삭제 / 복구도 리비전으로 남고, 삭제된 할 일은 되돌릴 수 없음
+	assert.NoError(t, repo.Delete(id))
+	_, err = repo.Revert(created.ID, 1)
+	assert.ErrorIs(t, err, gorm.ErrRecordNotFound)
+	repo.Restore(id)
+	revs, _ = repo.Revisions(created.ID)
+	assert.Len(t, revs, 6)
+	assert.Equal(t, model.RevisionDelete, revs[4].Action)
+	assert.Equal(t, model.RevisionRestore, revs[5].Action)
+}
+
+func TestSQLiteRepository_DashboardAggregates(t *testing.T) {
+	repo := newTestSQLiteRepository()
+	ctx := context.Background()
+	past := time.Now().Add(-time.Hour)
+	repo.Save(model.Todo{Task: "기획서 +work @office", DueAt: &past}) // 기한 초과
+	done, _ := repo.Save(model.Todo{Task: "회의 +work"})
+	repo.Save(model.Todo{Task: "장보기 @home"})
+	repo.Update(fmt.Sprint(done.ID))
+
+	summary, err := repo.Summary(ctx, time.Now())
+	assert.NoError(t, err)
+	assert.Equal(t, model.TodoSummary{Total: 3, Done: 1, Overdue: 1, AvgCompletionSeconds: summary.AvgCompletionSeconds}, summary)
+	assert.GreaterOrEqual(t, summary.AvgCompletionSeconds, 0.0)
+
+	daily, err := repo.DailyCounts(ctx, time.Now().Add(-24*time.Hour))
+	assert.NoError(t, err)
+	var created, completed int64
+	for _, d := range daily {
+		created += d.Created
+		completed += d.Completed
+	}
+	assert.Equal(t, int64(3), created)
+	assert.Equal(t, int64(1), completed)
+
+	projects, contexts, err := repo.TagCounts(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, []model.TagCount{{Name: "+work", Total: 2, Done: 1}}, projects)
+	assert.Equal(t, []model.TagCount{{Name: "@home", Total: 1}, {Name: "@office", Total: 1}}, contexts)
+
+	// 이미 취소된 context면 쿼리도 실패
+	canceled, cancel := context.WithCancel(ctx)
+	cancel()
+	_, err = repo.Summary(canceled, time.Now())
+	assert.Error(t, err)
+}
+
+func TestSQLiteRepository_CompletedAt(t *testing.T) {
+	repo := newTestSQLiteRepository()
+	created, _ := repo.Save(model.Todo{Task: "완료 시각 기록"})
+	assert.Nil(t, created.CompletedAt)
+	id := fmt.Sprint(created.ID)
+
+	// 완료하면 기록, 다시 열면 지워짐
+	done, _ := repo.Update(id)
+	assert.NotNil(t, done.CompletedAt)
+	reopened, _ := repo.Update(id)
+	assert.Nil(t, reopened.CompletedAt)
+
+	// 이미 완료된 할 일을 덮어써도 완료 시각은 유지
+	done, _ = repo.Update(id)
+	replaced, _ := repo.Replace(model.Todo{ID: created.ID, Task: "이름만 변경", Done: true})
+	assert.True(t, done.CompletedAt.Equal(*replaced.CompletedAt))
+
+	// 가져오기처럼 완료 시각을 알려주면 그 값을 저장
+	at := time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC)
+	imported, _ := repo.Save(model.Todo{Task: "가져온 완료 항목", Done: true, CompletedAt: &at})
+	assert.True(t, at.Equal(*imported.CompletedAt))
+}
+
+func TestSQLiteRepository_WritesOutboxInSameTransaction(t *testing.T) {
+	repo := newTestSQLiteRepository()
+	outbox := NewSQLiteOutboxRepository(repo.GetDB())
+
+	a, _ := repo.Save(model.Todo{Task: "A"})
+	repo.Update(fmt.Sprint(a.ID))
+	repo.Delete(fmt.Sprint(a.ID))
+	repo.Restore(fmt.Sprint(a.ID))
+	// 없는 할 일은 변경이 롤백되므로 이벤트도 남지 않음
+	assert.Error(t, repo.Delete("999"))
+
+	events, err := outbox.Pending(10, 100)
+	assert.NoError(t, err)
+	var names []string
+	for _, e := range events {
+		names = append(names, e.Event)
+		assert.Equal(t, a.ID, e.TodoID)
+	}
+	assert.Equal(t, []string{model.EventTodoCreated, model.EventTodoUpdated, model.EventTodoDeleted, model.EventTodoRestored}, names)
+	assert.Contains(t, events[1].Payload, `"done":true`)
+
+	// 보낸 이벤트와 한도만큼 실패한 이벤트는 더 이상 나오지 않음
+	assert.NoError(t, outbox.MarkSent(events[0].ID, time.Now()))
+	for i := 0; i < 2; i++ {
+		assert.NoError(t, outbox.MarkFailed(events[1].ID, "boom", nil))
+	}
+	events, _ = outbox.Pending(2, 100)
+	assert.Len(t, events, 2)
+
+	deleted, err := outbox.DeleteSentBefore(time.Now().Add(time.Minute))
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1), deleted)
+}
@@ -0,0 +1,135 @@
+package repository
+
+import (
+	"go_study/model"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// SQLiteWebhookRepository: 웹훅 구독자와 전송 대기열 저장소 (SQLite 구현체)
+type SQLiteWebhookRepository struct {
+	db *gorm.DB
+}
+
+// 생성자 함수
+func NewSQLiteWebhookRepository(db *gorm.DB) *SQLiteWebhookRepository {
+	return &SQLiteWebhookRepository{db: db}
+}
+
+func (r *SQLiteWebhookRepository) Create(w model.Webhook) (model.Webhook, error) {
+	err := r.db.Create(&w).Error
+	return w, err
+}
+
+func (r *SQLiteWebhookRepository) List() ([]model.Webhook, error) {
+	var hooks []model.Webhook
+	err := r.db.Order("id").Find(&hooks).Error
+	return hooks, err
+}
+
+// Find: 한 건 조회 (없으면 gorm.ErrRecordNotFound)
+func (r *SQLiteWebhookRepository) Find(id uint) (model.Webhook, error) {
+	var w model.Webhook
+	err := r.db.First(&w, id).Error
+	return w, err
+}
+
+// Update: URL, 이벤트, 서명 키, 활성 여부를 통째로 저장
+func (r *SQLiteWebhookRepository) Update(w model.Webhook) (model.Webhook, error) {
+	err := r.db.Model(&w).Select("url", "events", "secret", "active").Updates(w).Error
+	return w, err
+}
+
+// Delete: 구독자와 그 전송 기록을 함께 삭제
+func (r *SQLiteWebhookRepository) Delete(id uint) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		result := tx.Delete(&model.Webhook{}, id)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return gorm.ErrRecordNotFound
+		}
+		deliveries := tx.Model(&model.WebhookDelivery{}).Select("id").Where("webhook_id = ?", id)
+		if err := tx.Where("delivery_id IN (?)", deliveries).Delete(&model.WebhookAttempt{}).Error; err != nil {
+			return err
+		}
+		return tx.Where("webhook_id = ?", id).Delete(&model.WebhookDelivery{}).Error
+	})
+}
+
+// Enqueue: event를 구독하는 활성 구독자마다 전송 대기열에 한 건씩 추가 (추가한 개수 반환)
+func (r *SQLiteWebhookRepository) Enqueue(event string, payload []byte) (int, error) {
+	var hooks []model.Webhook
+	if err := r.db.Where("active = ?", true).Find(&hooks).Error; err != nil {
+		return 0, err
+	}
+	var deliveries []model.WebhookDelivery
+	now := time.Now()
+	for _, w := range hooks {
+		if w.Subscribes(event) {
+			deliveries = append(deliveries, model.WebhookDelivery{
+				WebhookID: w.ID, Event: event, Payload: string(payload),
+				Status: model.DeliveryPending, NextAttemptAt: now,
+			})
+		}
+	}
+	if len(deliveries) == 0 {
+		return 0, nil
+	}
+	return len(deliveries), r.db.Create(&deliveries).Error
+}
+
+// DueDeliveries: 지금 보낼 차례인 전송 (오래된 순으로 limit개)
+func (r *SQLiteWebhookRepository) DueDeliveries(now time.Time, limit int) ([]model.WebhookDelivery, error) {
+	var deliveries []model.WebhookDelivery
+	err := r.db.Where("status = ? AND julianday(next_attempt_at) <= julianday(?)", model.DeliveryPending, now).
+		Order("next_attempt_at, id").Limit(limit).Find(&deliveries).Error
+	return deliveries, err
+}
+
+// RecordAttempt: 전송 결과(상태, 재시도 시각 등)를 저장하고 시도 기록을 한 줄 남김
+func (r *SQLiteWebhookRepository) RecordAttempt(d model.WebhookDelivery, attempt model.WebhookAttempt) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		err := tx.Model(&d).Select("status", "attempts", "next_attempt_at", "last_status_code", "last_error", "delivered_at").
+			Updates(d).Error
+		if err != nil {
+			return err
+		}
+		attempt.DeliveryID = d.ID
+		return tx.Create(&attempt).Error
+	})
+}
+
+// Deliveries: 구독자 하나의 전송 목록 (최신순)
+func (r *SQLiteWebhookRepository) Deliveries(webhookID uint, limit, offset int) ([]model.WebhookDelivery, error) {
+	var deliveries []model.WebhookDelivery
+	err := r.db.Where("webhook_id = ?", webhookID).Order("id DESC").Limit(limit).Offset(offset).Find(&deliveries).Error
+	return deliveries, err
+}
+
+// FindDelivery: 구독자의 전송 한 건 (다른 구독자의 것이면 gorm.ErrRecordNotFound)
+func (r *SQLiteWebhookRepository) FindDelivery(webhookID, id uint) (model.WebhookDelivery, error) {
+	var d model.WebhookDelivery
+	err := r.db.Where("webhook_id = ?", webhookID).First(&d, id).Error
+	return d, err
+}
+
+// Attempts: 전송 한 건의 시도 기록 (오래된 순)
+func (r *SQLiteWebhookRepository) Attempts(deliveryID uint) ([]model.WebhookAttempt, error) {
+	var attempts []model.WebhookAttempt
+	err := r.db.Where("delivery_id = ?", deliveryID).Order("id").Find(&attempts).Error
+	return attempts, err
+}
+
+// Redeliver: 전송을 처음부터 다시 시도하도록 대기열에 되돌림 (성공했던 것도 다시 보냄)
+func (r *SQLiteWebhookRepository) Redeliver(webhookID, id uint) (model.WebhookDelivery, error) {
+	d, err := r.FindDelivery(webhookID, id)
+	if err != nil {
+		return d, err
+	}
+	d.Status, d.Attempts, d.NextAttemptAt = model.DeliveryPending, 0, time.Now()
+	err = r.db.Model(&d).Select("status", "attempts", "next_attempt_at").Updates(d).Error
+	return d, err
+}
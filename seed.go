@@ -0,0 +1,89 @@
+package main
+
+import (
+	"log"
+	"math/rand/v2"
+	"time"
+
+	"go_study/config"
+	"go_study/model"
+	"go_study/repository"
+)
+
+// 데모 할 일 재료: "<동사> <대상> +프로젝트 @컨텍스트"
+var (
+	seedVerbs    = []string{"작성하기", "검토하기", "정리하기", "예약하기", "수정하기", "보내기", "준비하기", "확인하기"}
+	seedObjects  = []string{"주간 보고서", "발표 자료", "회의록", "청구서", "이력서", "여행 일정", "치과 예약", "장보기 목록", "블로그 글", "코드 리뷰"}
+	seedProjects = []string{"", "+work", "+home", "+study", "+travel"}
+	seedContexts = []string{"", "@office", "@phone", "@computer", "@errands"}
+)
+
+// seedBatchSize: 한 트랜잭션에 넣는 개수
+const seedBatchSize = 500
+
+// runSeed: 대시보드/분석 화면을 확인할 수 있도록 최근 60일에 걸친 데모 할 일 생성
+// 절반쯤은 완료(생성 뒤 몇 시간~며칠 뒤 완료), 일부는 마감일이 있고 그중 일부는 이미 지났습니다.
+func runSeed(args []string) {
+	f := newCommandFlags("seed", "")
+	count := f.Int("count", 100, "만들 할 일 개수")
+	allowWebhooks := f.Bool("allow-webhooks", false, "활성 웹훅이 있어도 생성 (할 일마다 todo.created가 전송됨)")
+	f.load(args)
+	if *count < 1 {
+		f.Usage()
+		log.Fatal("--count must be positive")
+	}
+
+	db := openMigratedDB()
+	// 할 일 저장과 같은 트랜잭션에 outbox 이벤트가 남으므로, 구독자가 있으면 데모 데이터가 그대로 웹훅으로 나감
+	var hooks int64
+	if err := db.Model(&model.Webhook{}).Where("active = ?", true).Count(&hooks).Error; err != nil {
+		log.Fatalf("❌ 웹훅 확인 실패: %v", err)
+	}
+	if hooks > 0 && !*allowWebhooks {
+		log.Fatalf("❌ 활성 웹훅 %d개가 있어서 데모 할 일 %d개가 모두 전송됩니다. 웹훅을 끄거나 --allow-webhooks로 다시 실행하세요", hooks, *count)
+	}
+
+	repo := repository.NewSQLiteRepository(db)
+	now := time.Now()
+	created := 0
+	for created < *count {
+		batch := make([]model.Todo, 0, min(seedBatchSize, *count-created))
+		for len(batch) < cap(batch) {
+			batch = append(batch, seedTodo(now))
+		}
+		saved, err := repo.SaveAll(batch)
+		if err != nil {
+			log.Fatalf("❌ 데모 데이터 저장 실패: %v", err)
+		}
+		created += len(saved)
+	}
+	log.Printf("✅ 데모 할 일 %d개 생성 (%s)", created, config.AppConfig.Database.File)
+}
+
+func seedTodo(now time.Time) model.Todo {
+	createdAt := now.Add(-time.Duration(rand.Int64N(int64(60 * 24 * time.Hour))))
+	t := model.Todo{
+		Task:      seedObjects[rand.IntN(len(seedObjects))] + " " + seedVerbs[rand.IntN(len(seedVerbs))],
+		Priority:  rand.IntN(10),
+		CreatedAt: createdAt,
+	}
+	if p := seedProjects[rand.IntN(len(seedProjects))]; p != "" {
+		t.Task += " " + p
+	}
+	if c := seedContexts[rand.IntN(len(seedContexts))]; c != "" {
+		t.Task += " " + c
+	}
+	if rand.IntN(2) == 0 {
+		due := createdAt.Add(time.Duration(1+rand.IntN(14)) * 24 * time.Hour).Truncate(time.Hour)
+		t.DueAt = &due
+	}
+	if rand.IntN(2) == 0 {
+		done := createdAt.Add(time.Duration(rand.Int64N(int64(5 * 24 * time.Hour))))
+		if done.After(now) {
+			done = now
+		}
+		t.Done = true
+		t.CompletedAt = &done
+	}
+	return t
+}
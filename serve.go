@@ -0,0 +1,69 @@
+package main
+
+import (
+	"fmt"
+	"log"
+	"os"
+	"strings"
+
+	"go_study/app"
+	"go_study/config"
+	"go_study/global"
+	"go_study/middleware"
+)
+
+// runServe: ./main serve [--role active|standby] [--port :8080] [--db file]
+func runServe(args []string) {
+	f := newCommandFlags("serve", "")
+	role := f.String("role", "", "시작 역할 active | standby (기본: INITIAL_ROLE 환경 변수, 없으면 standby)")
+	f.override("port", "server.port", "listen 주소 (server.port, 예: :8080)")
+	f.override("standby-mode", "standby.mode", "Standby 동작 reject | read_only (standby.mode)")
+	f.override("node-name", "cluster.node_name", "heartbeat에 쓰는 서버 이름 (cluster.node_name)")
+	f.load(args)
+
+	initialRole, err := startRole(*role, os.Getenv("INITIAL_ROLE"))
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	// 1. DB 연결 (Infrastructure Layer) + 마이그레이션
+	db := openMigratedDB()
+
+	// 2. 저장소 → 핸들러 → 라우터 조립 (의존성 주입은 app 패키지에서) ⭐
+	server := app.New(db, config.AppConfig)
+
+	// 역할은 저장된 임기를 읽은 뒤에 정함 (Active로 시작하면 저장된 임기 + 1)
+	if initialRole == "active" {
+		global.SetActive()
+		middleware.Log.Info("🚀 서버가 ACTIVE 모드로 시작됩니다.")
+	} else {
+		global.SetStandby()
+		middleware.Log.Info("💤 서버가 STANDBY 모드로 시작됩니다.")
+	}
+	server.Start()
+
+	// 🔄 [추가] config.yaml이 바뀌면 다시 읽어서 log.level, backup 주기/보관 개수는 재시작 없이 적용
+	if err := server.Reloader.Watch(f.configPath, f.overrides); err != nil {
+		middleware.Log.Warn("config watch disabled: " + err.Error())
+	}
+
+	middleware.Log.Info("Starting Server with Dependency Injection...")
+	if err := server.Serve(); err != nil {
+		log.Fatalf("❌ server stopped: %v", err)
+	}
+}
+
+// startRole: 시작 역할 --role > INITIAL_ROLE 환경변수 (Docker Compose에서 넣어준 값) > standby
+func startRole(flagRole, envRole string) (string, error) {
+	role := strings.ToLower(flagRole)
+	if role == "" {
+		role = strings.ToLower(envRole)
+	}
+	switch role {
+	case "":
+		return "standby", nil
+	case "active", "standby":
+		return role, nil
+	}
+	return "", fmt.Errorf("unknown role %q (active | standby)", role)
+}
@@ -0,0 +1,146 @@
+package todoio
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"go_study/model"
+)
+
+// CSV 컬럼 순서 (가져오기 시에는 헤더 이름으로 찾으므로 순서가 달라도 됨)
+var csvHeader = []string{"id", "task", "done", "priority", "due_at", "created_at"}
+
+type csvEncoder struct {
+	w           *csv.Writer
+	wroteHeader bool
+}
+
+func newCSVEncoder(w io.Writer) *csvEncoder {
+	return &csvEncoder{w: csv.NewWriter(w)}
+}
+
+func (e *csvEncoder) writeHeader() error {
+	if e.wroteHeader {
+		return nil
+	}
+	e.wroteHeader = true
+	return e.w.Write(csvHeader)
+}
+
+func (e *csvEncoder) Encode(t model.Todo) error {
+	if err := e.writeHeader(); err != nil {
+		return err
+	}
+	dueAt := ""
+	if t.DueAt != nil {
+		dueAt = t.DueAt.UTC().Format(time.RFC3339)
+	}
+	return e.w.Write([]string{
+		strconv.FormatUint(uint64(t.ID), 10),
+		t.Task,
+		strconv.FormatBool(t.Done),
+		strconv.Itoa(t.Priority),
+		dueAt,
+		t.CreatedAt.UTC().Format(time.RFC3339),
+	})
+}
+
+func (e *csvEncoder) Close() error {
+	// 데이터가 없어도 헤더는 남김
+	if err := e.writeHeader(); err != nil {
+		return err
+	}
+	e.w.Flush()
+	return e.w.Error()
+}
+
+func decodeCSV(r io.Reader) ([]Record, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1 // 줄마다 컬럼 수가 달라도 줄 단위 에러로 처리
+
+	header, err := reader.Read()
+	if err == io.EOF {
+		return nil, errors.New("empty CSV (header row is required)")
+	}
+	if err != nil {
+		return nil, fmt.Errorf("invalid CSV header: %w", err)
+	}
+
+	// 헤더 이름 -> 컬럼 위치
+	cols := map[string]int{}
+	for i, name := range header {
+		cols[strings.ToLower(strings.TrimSpace(name))] = i
+	}
+	if _, ok := cols["task"]; !ok {
+		return nil, errors.New(`CSV header must contain a "task" column`)
+	}
+	field := func(row []string, name string) string {
+		if i, ok := cols[name]; ok && i < len(row) {
+			return strings.TrimSpace(row[i])
+		}
+		return ""
+	}
+
+	var records []Record
+	for {
+		row, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			// 따옴표가 깨진 줄 등은 복구할 수 없으므로 여기서 멈춤
+			var parseErr *csv.ParseError
+			if errors.As(err, &parseErr) {
+				return nil, fmt.Errorf("line %d: %w", parseErr.Line, parseErr.Err)
+			}
+			return nil, err
+		}
+
+		line, _ := reader.FieldPos(0)
+		rec := Record{Line: line}
+		rec.Todo, rec.Err = parseCSVRow(field(row, "task"), field(row, "done"), field(row, "priority"), field(row, "due_at"), field(row, "created_at"))
+		records = append(records, rec)
+	}
+	return records, nil
+}
+
+func parseCSVRow(task, done, priority, dueAt, createdAt string) (model.Todo, error) {
+	t := model.Todo{Task: task}
+	if done != "" {
+		b, err := strconv.ParseBool(done)
+		if err != nil {
+			return model.Todo{}, fmt.Errorf("invalid done value %q", done)
+		}
+		t.Done = b
+	}
+	if priority != "" {
+		p, err := strconv.Atoi(priority)
+		if err != nil {
+			return model.Todo{}, fmt.Errorf("invalid priority %q", priority)
+		}
+		t.Priority = p
+	}
+	if dueAt != "" {
+		ts, err := time.Parse(time.RFC3339, dueAt)
+		if err != nil {
+			return model.Todo{}, fmt.Errorf("invalid due_at %q (RFC3339 expected)", dueAt)
+		}
+		t.DueAt = &ts
+	}
+	if createdAt != "" {
+		ts, err := time.Parse(time.RFC3339, createdAt)
+		if err != nil {
+			return model.Todo{}, fmt.Errorf("invalid created_at %q (RFC3339 expected)", createdAt)
+		}
+		t.CreatedAt = ts
+	}
+	if err := validate(t); err != nil {
+		return model.Todo{}, err
+	}
+	return t, nil
+}
@@ -0,0 +1,82 @@
+package todoio
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"time"
+
+	"go_study/model"
+)
+
+// JSON 내보내기: [ {...}, {...} ] 배열을 항목마다 바로 써서 전체를 메모리에 올리지 않음
+type jsonEncoder struct {
+	w     io.Writer
+	count int
+}
+
+func newJSONEncoder(w io.Writer) *jsonEncoder {
+	return &jsonEncoder{w: w}
+}
+
+func (e *jsonEncoder) Encode(t model.Todo) error {
+	b, err := json.Marshal(t)
+	if err != nil {
+		return err
+	}
+	prefix := ",\n"
+	if e.count == 0 {
+		prefix = "[\n"
+	}
+	e.count++
+	if _, err := io.WriteString(e.w, prefix); err != nil {
+		return err
+	}
+	_, err = e.w.Write(b)
+	return err
+}
+
+func (e *jsonEncoder) Close() error {
+	if e.count == 0 {
+		_, err := io.WriteString(e.w, "[]\n")
+		return err
+	}
+	_, err := io.WriteString(e.w, "\n]\n")
+	return err
+}
+
+// 가져오기용 항목 (id 등 서버가 정하는 값은 무시)
+type jsonItem struct {
+	Task      string     `json:"task"`
+	Done      bool       `json:"done"`
+	Priority  int        `json:"priority"`
+	DueAt     *time.Time `json:"due_at"`
+	CreatedAt *time.Time `json:"created_at"`
+}
+
+func decodeJSON(r io.Reader) ([]Record, error) {
+	var raw []json.RawMessage
+	if err := json.NewDecoder(r).Decode(&raw); err != nil {
+		return nil, fmt.Errorf("invalid JSON array: %w", err)
+	}
+
+	records := make([]Record, 0, len(raw))
+	for i, msg := range raw {
+		rec := Record{Line: i + 1}
+		var item jsonItem
+		if err := json.Unmarshal(msg, &item); err != nil {
+			rec.Err = err
+		} else {
+			rec.Todo = model.Todo{Task: item.Task, Done: item.Done, Priority: item.Priority, DueAt: item.DueAt}
+			if item.CreatedAt != nil {
+				rec.Todo.CreatedAt = *item.CreatedAt
+			}
+			rec.Err = validate(rec.Todo)
+		}
+		if rec.Err != nil {
+			rec.Todo = model.Todo{}
+		}
+		records = append(records, rec)
+	}
+	return records, nil
+}
@@ -0,0 +1,103 @@
+// Package todoio: 할 일 목록을 JSON / CSV / todo.txt 형식으로 내보내고(Encode) 읽어들이는(Decode) 코덱 모음
+package todoio
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"go_study/model"
+)
+
+// Format: 지원하는 파일 형식
+type Format string
+
+const (
+	FormatJSON    Format = "json"
+	FormatCSV     Format = "csv"
+	FormatTodoTxt Format = "todotxt"
+)
+
+// ParseFormat: 쿼리 파라미터 문자열을 Format으로 변환 (빈 값이면 JSON)
+func ParseFormat(s string) (Format, error) {
+	switch Format(strings.ToLower(strings.TrimSpace(s))) {
+	case "", FormatJSON:
+		return FormatJSON, nil
+	case FormatCSV:
+		return FormatCSV, nil
+	case FormatTodoTxt, "txt":
+		return FormatTodoTxt, nil
+	}
+	return "", fmt.Errorf("unsupported format %q (json, csv, todotxt)", s)
+}
+
+// ContentType: HTTP 응답용 Content-Type
+func (f Format) ContentType() string {
+	switch f {
+	case FormatCSV:
+		return "text/csv; charset=utf-8"
+	case FormatTodoTxt:
+		return "text/plain; charset=utf-8"
+	}
+	return "application/json; charset=utf-8"
+}
+
+// FileName: 다운로드 파일 이름
+func (f Format) FileName() string {
+	switch f {
+	case FormatCSV:
+		return "todos.csv"
+	case FormatTodoTxt:
+		return "todo.txt"
+	}
+	return "todos.json"
+}
+
+// Encoder: 할 일을 하나씩 흘려보내며(Streaming) 쓰는 인코더
+// 모든 항목을 쓴 뒤 반드시 Close()를 호출해야 마지막 버퍼/닫는 괄호가 기록됩니다.
+type Encoder interface {
+	Encode(t model.Todo) error
+	Close() error
+}
+
+// NewEncoder: 형식에 맞는 인코더 생성
+func NewEncoder(f Format, w io.Writer) Encoder {
+	switch f {
+	case FormatCSV:
+		return newCSVEncoder(w)
+	case FormatTodoTxt:
+		return newTodoTxtEncoder(w)
+	}
+	return newJSONEncoder(w)
+}
+
+// Record: 파일에서 읽은 한 줄(한 항목)
+// Err가 nil이 아니면 그 줄은 검증에 실패한 것이고, Todo는 비어 있습니다.
+type Record struct {
+	Line int
+	Todo model.Todo
+	Err  error
+}
+
+// Decode: 파일 전체를 읽어서 줄 단위 Record 목록을 반환
+// 개별 줄의 문제는 Record.Err로, 파일 자체를 읽을 수 없는 문제(깨진 JSON, CSV 헤더 누락 등)는 error로 돌려줍니다.
+func Decode(f Format, r io.Reader) ([]Record, error) {
+	switch f {
+	case FormatCSV:
+		return decodeCSV(r)
+	case FormatTodoTxt:
+		return decodeTodoTxt(r)
+	}
+	return decodeJSON(r)
+}
+
+// 공통 검증: task는 공백이 아니어야 하고, priority는 0~9
+func validate(t model.Todo) error {
+	if strings.TrimSpace(t.Task) == "" {
+		return fmt.Errorf("task is required")
+	}
+	if t.Priority < 0 || t.Priority > 9 {
+		return fmt.Errorf("priority must be between 0 and 9")
+	}
+	return nil
+}
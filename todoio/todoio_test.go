@@ -0,0 +1,88 @@
+package todoio
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"go_study/model"
+)
+
+// todo.txt는 날짜 단위까지만 저장하므로 자정 기준 시각을 사용
+var sampleDue = time.Date(2025, 1, 10, 0, 0, 0, 0, time.UTC)
+
+var sampleTodos = []model.Todo{
+	{ID: 1, Task: "우유 사기", Done: false, Priority: 2, DueAt: &sampleDue, CreatedAt: time.Date(2025, 1, 2, 0, 0, 0, 0, time.UTC)},
+	{ID: 2, Task: "보고서, \"최종\" 제출", Done: true, CreatedAt: time.Date(2025, 1, 3, 0, 0, 0, 0, time.UTC), UpdatedAt: time.Date(2025, 1, 5, 0, 0, 0, 0, time.UTC)},
+}
+
+// 내보낸 결과를 다시 읽으면 같은 내용이 나와야 함
+func TestRoundTrip(t *testing.T) {
+	for _, f := range []Format{FormatJSON, FormatCSV, FormatTodoTxt} {
+		var buf bytes.Buffer
+		enc := NewEncoder(f, &buf)
+		for _, todo := range sampleTodos {
+			assert.NoError(t, enc.Encode(todo))
+		}
+		assert.NoError(t, enc.Close())
+
+		records, err := Decode(f, &buf)
+		assert.NoError(t, err, f)
+		if assert.Len(t, records, 2, f) {
+			for i, rec := range records {
+				assert.NoError(t, rec.Err, f)
+				assert.Equal(t, sampleTodos[i].Task, rec.Todo.Task, f)
+				assert.Equal(t, sampleTodos[i].Done, rec.Todo.Done, f)
+				assert.Equal(t, sampleTodos[i].Priority, rec.Todo.Priority, f)
+				assert.Equal(t, sampleTodos[i].DueAt, rec.Todo.DueAt, f)
+				assert.True(t, sampleTodos[i].CreatedAt.Equal(rec.Todo.CreatedAt), f)
+			}
+		}
+	}
+}
+
+func TestEmptyExport(t *testing.T) {
+	var buf bytes.Buffer
+	enc := NewEncoder(FormatJSON, &buf)
+	assert.NoError(t, enc.Close())
+	assert.Equal(t, "[]\n", buf.String())
+}
+
+func TestDecode_LineErrors(t *testing.T) {
+	csvInput := "task,done\n밥 먹기,false\n,true\n운동,maybe\n"
+	records, err := Decode(FormatCSV, strings.NewReader(csvInput))
+	assert.NoError(t, err)
+	assert.Len(t, records, 3)
+	assert.NoError(t, records[0].Err)
+	assert.Equal(t, 3, records[1].Line)
+	assert.EqualError(t, records[1].Err, "task is required")
+	assert.Equal(t, 4, records[2].Line)
+	assert.Error(t, records[2].Err)
+
+	// todo.txt: 우선순위는 무시하고, 빈 줄도 줄 번호는 셈
+	records, err = Decode(FormatTodoTxt, strings.NewReader("(A) 2025-02-01 전화하기\n\nx \n"))
+	assert.NoError(t, err)
+	assert.Len(t, records, 2)
+	assert.Equal(t, "전화하기", records[0].Todo.Task)
+	assert.Equal(t, 3, records[1].Line)
+	assert.Error(t, records[1].Err)
+
+	// 헤더에 task가 없거나 JSON이 깨지면 파일 전체 에러
+	_, err = Decode(FormatCSV, strings.NewReader("name\nfoo\n"))
+	assert.Error(t, err)
+	_, err = Decode(FormatJSON, strings.NewReader(`[{"task": "a"`))
+	assert.Error(t, err)
+}
+
+func TestParseFormat(t *testing.T) {
+	f, err := ParseFormat("")
+	assert.NoError(t, err)
+	assert.Equal(t, FormatJSON, f)
+	f, _ = ParseFormat("CSV")
+	assert.Equal(t, FormatCSV, f)
+	_, err = ParseFormat("xml")
+	assert.Error(t, err)
+}
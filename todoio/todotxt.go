@@ -0,0 +1,130 @@
+package todoio
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"regexp"
+	"strings"
+	"time"
+
+	"go_study/model"
+)
+
+// todo.txt 형식 (https://github.com/todotxt/todo.txt)
+// 미완료 항목은 "(A) 2025-01-02 할 일 due:2025-01-10", 완료 항목은 "x 2025-01-05 2025-01-02 할 일" (완료일 생성일 순서)
+// 우선순위 (A)~(I)는 priority 1~9에 대응하고, 마감일은 due: 태그로 표현합니다.
+const todoTxtDate = "2006-01-02"
+
+type todoTxtEncoder struct {
+	w *bufio.Writer
+}
+
+func newTodoTxtEncoder(w io.Writer) *todoTxtEncoder {
+	return &todoTxtEncoder{w: bufio.NewWriter(w)}
+}
+
+func (e *todoTxtEncoder) Encode(t model.Todo) error {
+	// 줄바꿈이 들어간 task는 한 줄로 합쳐서 씀 (todo.txt는 한 줄 = 한 항목)
+	task := strings.Join(strings.Fields(t.Task), " ")
+	created := t.CreatedAt.Format(todoTxtDate)
+
+	if t.DueAt != nil {
+		task += " due:" + t.DueAt.Format(todoTxtDate)
+	}
+
+	var line string
+	switch {
+	case t.Done:
+		line = fmt.Sprintf("x %s %s %s\n", t.CompletionTime().Format(todoTxtDate), created, task)
+	case t.Priority > 0:
+		line = fmt.Sprintf("(%c) %s %s\n", 'A'+rune(t.Priority-1), created, task)
+	default:
+		line = fmt.Sprintf("%s %s\n", created, task)
+	}
+	_, err := e.w.WriteString(line)
+	return err
+}
+
+func (e *todoTxtEncoder) Close() error {
+	return e.w.Flush()
+}
+
+var (
+	todoTxtPriority = regexp.MustCompile(`^\(([A-Z])\) `)
+	todoTxtDateRe   = regexp.MustCompile(`^\d{4}-\d{2}-\d{2} `)
+	todoTxtDue      = regexp.MustCompile(`(^|\s)due:(\S+)`)
+)
+
+func decodeTodoTxt(r io.Reader) ([]Record, error) {
+	var records []Record
+	scanner := bufio.NewScanner(r)
+	lineNo := 0
+	for scanner.Scan() {
+		lineNo++
+		text := strings.TrimSpace(scanner.Text())
+		if text == "" {
+			continue // 빈 줄은 무시
+		}
+		t, err := parseTodoTxtLine(text)
+		records = append(records, Record{Line: lineNo, Todo: t, Err: err})
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return records, nil
+}
+
+func parseTodoTxtLine(text string) (model.Todo, error) {
+	var t model.Todo
+	rest := text + " "
+
+	// 1. 완료 표시
+	if strings.HasPrefix(rest, "x ") {
+		t.Done = true
+		rest = rest[2:]
+	}
+	// 2. 우선순위 "(A) " -> 1, "(B) " -> 2 ... (I 이후는 모두 가장 낮은 9)
+	if m := todoTxtPriority.FindStringSubmatch(rest); m != nil && !t.Done {
+		t.Priority = min(int(m[1][0]-'A')+1, 9)
+		rest = rest[len(m[0]):]
+	}
+
+	// 3. 날짜: 완료된 항목은 "완료일 생성일", 미완료 항목은 "생성일"
+	var dates []time.Time
+	for len(dates) < 2 && todoTxtDateRe.MatchString(rest) {
+		d, err := time.Parse(todoTxtDate, rest[:10])
+		if err != nil {
+			return model.Todo{}, fmt.Errorf("invalid date %q", rest[:10])
+		}
+		dates = append(dates, d)
+		rest = rest[11:]
+		if !t.Done {
+			break
+		}
+	}
+	switch {
+	case t.Done && len(dates) == 2:
+		t.CompletedAt, t.CreatedAt = &dates[0], dates[1]
+	case t.Done && len(dates) == 1:
+		t.CompletedAt = &dates[0]
+	case !t.Done && len(dates) == 1:
+		t.CreatedAt = dates[0]
+	}
+
+	// 4. 마감일 태그 due:YYYY-MM-DD 는 task 본문에서 떼어냄
+	if m := todoTxtDue.FindStringSubmatch(rest); m != nil {
+		d, err := time.Parse(todoTxtDate, m[2])
+		if err != nil {
+			return model.Todo{}, fmt.Errorf("invalid due date %q", m[2])
+		}
+		t.DueAt = &d
+		rest = todoTxtDue.ReplaceAllString(rest, "")
+	}
+
+	t.Task = strings.TrimSpace(rest)
+	if err := validate(t); err != nil {
+		return model.Todo{}, err
+	}
+	return t, nil
+}
@@ -0,0 +1,48 @@
+package utils
+
+import (
+	"os"
+
+	"go_study/model"
+
+	"github.com/gin-gonic/gin"
+)
+
+// 요청 식별 관련 헤더와 컨텍스트 키
+const (
+	RequestIDHeader = "X-Request-ID"
+	ActorHeader     = "X-Actor"
+	RequestIDKey    = "request_id"
+)
+
+// RequestIDOf: middleware.RequestID가 정해둔 요청 ID
+func RequestIDOf(c *gin.Context) string {
+	return c.GetString(RequestIDKey)
+}
+
+// ActorOf: 요청자 이름
+// 이 서비스에는 아직 인증이 없으므로 앞단(nginx, 클라이언트)이 X-Actor 헤더로 알려준 값을 그대로 믿습니다.
+func ActorOf(c *gin.Context) string {
+	if actor := c.GetHeader(ActorHeader); actor != "" {
+		return actor
+	}
+	return "anonymous"
+}
+
+// NewAuditEvent: 요청 정보(요청자, IP, 요청 ID, 노드, 메소드, 경로, 상태 코드)를 채운 감사 이벤트
+// 변경 전/후 스냅샷(Before/After)은 호출하는 쪽에서 채웁니다.
+func NewAuditEvent(c *gin.Context, action, entityType, entityID string) model.AuditEvent {
+	hostname, _ := os.Hostname()
+	return model.AuditEvent{
+		Actor:      ActorOf(c),
+		IP:         c.ClientIP(),
+		RequestID:  RequestIDOf(c),
+		Node:       hostname,
+		Action:     action,
+		EntityType: entityType,
+		EntityID:   entityID,
+		Method:     c.Request.Method,
+		Path:       c.Request.URL.Path,
+		Status:     c.Writer.Status(),
+	}
+}
@@ -0,0 +1,84 @@
+// Package webhook: 할 일 변경 이벤트를 구독자 URL로 보내는 웹훅
+// 이벤트는 outbox(할 일 변경과 같은 트랜잭션)를 거쳐 DB의 전송 대기열(webhook_deliveries)에 저장되고, Worker가 Active 서버에서만 꺼내서 보냅니다.
+// 실패하면 지수 백오프로 다시 시도하고, 모든 시도는 webhook_attempts에 남습니다.
+package webhook
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"strconv"
+	"time"
+
+	"go_study/model"
+	"go_study/repository"
+	"go_study/utils"
+)
+
+// 요청 헤더
+const (
+	SignatureHeader = "X-Signature"         // "sha256=" + hex(HMAC-SHA256(secret, body))
+	EventHeader     = "X-Webhook-Event"     // todo.created ...
+	DeliveryHeader  = "X-Webhook-Delivery"  // 전송 ID (재시도/재전송해도 같음 → 수신 측 중복 제거용)
+	TimestampHeader = "X-Webhook-Timestamp" // 보낸 시각 (Unix 초)
+)
+
+// Envelope: 구독자에게 보내는 본문
+type Envelope struct {
+	ID         string      `json:"id" example:"9f86d081884c7d65"` // 이벤트 ID (구독자가 여럿이어도 같음)
+	Event      string      `json:"event" example:"todo.created"`
+	OccurredAt time.Time   `json:"occurred_at"`
+	Data       interface{} `json:"data"`
+}
+
+// Sign: 본문 서명 값 (X-Signature 헤더 값)
+func Sign(secret string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// Verify: 수신 측에서 서명 확인할 때 쓰는 함수 (상수 시간 비교)
+func Verify(secret string, body []byte, signature string) bool {
+	return hmac.Equal([]byte(Sign(secret, body)), []byte(signature))
+}
+
+// Publisher: 이벤트를 구독자별 전송 대기열에 넣음
+type Publisher struct {
+	repo repository.WebhookRepository
+}
+
+// 생성자
+func NewPublisher(repo repository.WebhookRepository) *Publisher {
+	return &Publisher{repo: repo}
+}
+
+// Publish: 이벤트 하나를 구독하는 모든 활성 구독자에게 보낼 준비 (대기열에 넣은 개수 반환)
+func (p *Publisher) Publish(event string, data interface{}) (int, error) {
+	id, err := utils.RandomToken(8)
+	if err != nil {
+		return 0, err
+	}
+	body, err := json.Marshal(Envelope{ID: id, Event: event, OccurredAt: time.Now().UTC(), Data: data})
+	if err != nil {
+		return 0, err
+	}
+	return p.repo.Enqueue(event, body)
+}
+
+// HandleOutbox: outbox.Dispatcher 구독자 (할 일 변경 이벤트를 웹훅 전송 대기열로)
+// outbox 이벤트 ID를 그대로 Envelope ID로 쓰므로, 같은 이벤트가 한 번 더 넘어와도 수신 측에서 걸러낼 수 있습니다.
+func (p *Publisher) HandleOutbox(e model.OutboxEvent) error {
+	body, err := json.Marshal(Envelope{
+		ID:         strconv.FormatUint(uint64(e.ID), 10),
+		Event:      e.Event,
+		OccurredAt: e.CreatedAt.UTC(),
+		Data:       json.RawMessage(e.Payload),
+	})
+	if err != nil {
+		return err
+	}
+	_, err = p.repo.Enqueue(e.Event, body)
+	return err
+}
@@ -0,0 +1,128 @@
+package webhook
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"go_study/model"
+	"go_study/repository"
+
+	"github.com/glebarez/sqlite"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+)
+
+func newTestRepo(t *testing.T) *repository.SQLiteWebhookRepository {
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	db.AutoMigrate(&model.Webhook{}, &model.WebhookDelivery{}, &model.WebhookAttempt{})
+	return repository.NewSQLiteWebhookRepository(db)
+}
+
+func TestSignAndVerify(t *testing.T) {
+	body := []byte(`{"event":"todo.created"}`)
+	sig := Sign("secret", body)
+	assert.Regexp(t, `^sha256=[0-9a-f]{64}$`, sig)
+	assert.True(t, Verify("secret", body, sig))
+	assert.False(t, Verify("other", body, sig))
+	assert.False(t, Verify("secret", []byte(`{}`), sig))
+}
+
+func TestBackoff(t *testing.T) {
+	base, limit := 10*time.Second, time.Minute
+	assert.Equal(t, 10*time.Second, Backoff(base, limit, 1))
+	assert.Equal(t, 20*time.Second, Backoff(base, limit, 2))
+	assert.Equal(t, 40*time.Second, Backoff(base, limit, 3))
+	assert.Equal(t, time.Minute, Backoff(base, limit, 4))
+	assert.Equal(t, time.Minute, Backoff(base, limit, 50))
+}
+
+func TestPublish_OnlySubscribedActiveHooks(t *testing.T) {
+	repo := newTestRepo(t)
+	repo.Create(model.Webhook{URL: "http://a", Events: []string{"*"}, Secret: "s", Active: true})
+	repo.Create(model.Webhook{URL: "http://b", Events: []string{model.EventTodoDeleted}, Secret: "s", Active: true})
+	repo.Create(model.Webhook{URL: "http://c", Events: []string{"*"}, Secret: "s", Active: false})
+
+	n, err := NewPublisher(repo).Publish(model.EventTodoCreated, model.Todo{ID: 1, Task: "A"})
+	assert.NoError(t, err)
+	assert.Equal(t, 1, n)
+	n, _ = NewPublisher(repo).Publish(model.EventTodoDeleted, model.Todo{ID: 1})
+	assert.Equal(t, 2, n)
+}
+
+func TestWorker_DeliversSignedAndRetries(t *testing.T) {
+	var calls atomic.Int32
+	var gotSig, gotEvent string
+	var gotBody []byte
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// 첫 요청은 실패, 두 번째부터 성공
+		if calls.Add(1) == 1 {
+			http.Error(w, "boom", http.StatusInternalServerError)
+			return
+		}
+		gotSig, gotEvent = r.Header.Get(SignatureHeader), r.Header.Get(EventHeader)
+		gotBody, _ = io.ReadAll(r.Body)
+	}))
+	defer server.Close()
+
+	repo := newTestRepo(t)
+	hook, _ := repo.Create(model.Webhook{URL: server.URL, Events: []string{"*"}, Secret: "topsecret", Active: true})
+	NewPublisher(repo).Publish(model.EventTodoCreated, model.Todo{ID: 7, Task: "웹훅"})
+	worker := NewWorker(repo, Options{BaseBackoff: time.Minute})
+
+	// 1. 첫 시도 실패 → 1분 뒤로 미뤄짐
+	now := time.Now()
+	assert.Equal(t, 1, worker.RunOnce(now))
+	deliveries, _ := repo.Deliveries(hook.ID, 10, 0)
+	d := deliveries[0]
+	assert.Equal(t, model.DeliveryPending, d.Status)
+	assert.Equal(t, 1, d.Attempts)
+	assert.Equal(t, 500, d.LastStatusCode)
+	assert.Contains(t, d.LastError, "boom")
+	assert.WithinDuration(t, now.Add(time.Minute), d.NextAttemptAt, time.Millisecond, "다음 시도는 RunOnce에 넘긴 시각 기준")
+	assert.Equal(t, 0, worker.RunOnce(now)) // 아직 차례 아님
+
+	// 2. 백오프가 지나면 다시 보내고 성공
+	assert.Equal(t, 1, worker.RunOnce(now.Add(2*time.Minute)))
+	d, _ = repo.FindDelivery(hook.ID, d.ID)
+	assert.Equal(t, model.DeliverySucceeded, d.Status)
+	if assert.NotNil(t, d.DeliveredAt) {
+		assert.WithinDuration(t, now.Add(2*time.Minute), *d.DeliveredAt, time.Millisecond)
+	}
+	assert.Equal(t, model.EventTodoCreated, gotEvent)
+	assert.True(t, Verify("topsecret", gotBody, gotSig))
+
+	attempts, _ := repo.Attempts(d.ID)
+	assert.Len(t, attempts, 2)
+	assert.Equal(t, []int{500, 200}, []int{attempts[0].StatusCode, attempts[1].StatusCode})
+
+	// 3. 재전송하면 같은 본문을 한 번 더 보냄
+	repo.Redeliver(hook.ID, d.ID)
+	assert.Equal(t, 1, worker.RunOnce(time.Now()))
+	assert.Equal(t, int32(3), calls.Load())
+}
+
+func TestWorker_GivesUpAfterMaxAttempts(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadGateway)
+	}))
+	defer server.Close()
+
+	repo := newTestRepo(t)
+	hook, _ := repo.Create(model.Webhook{URL: server.URL, Events: []string{"*"}, Secret: "s", Active: true})
+	NewPublisher(repo).Publish(model.EventTodoUpdated, model.Todo{ID: 1})
+	worker := NewWorker(repo, Options{MaxAttempts: 2, BaseBackoff: time.Millisecond})
+
+	worker.RunOnce(time.Now())
+	worker.RunOnce(time.Now().Add(time.Second))
+	deliveries, _ := repo.Deliveries(hook.ID, 10, 0)
+	assert.Equal(t, model.DeliveryFailed, deliveries[0].Status)
+	assert.Equal(t, 2, deliveries[0].Attempts)
+	assert.Equal(t, 0, worker.RunOnce(time.Now().Add(time.Hour)))
+}
@@ -0,0 +1,163 @@
+package webhook
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"strconv"
+	"time"
+
+	"go_study/global"
+	"go_study/model"
+	"go_study/repository"
+)
+
+// 한 번에 꺼내서 보낼 최대 전송 수
+const batchSize = 20
+
+// 응답 본문은 오류 메시지용으로 앞부분만 저장
+const maxErrorBody = 512
+
+var logf = log.Printf
+
+// Options: 전송 설정
+type Options struct {
+	MaxAttempts  int           // 이 횟수만큼 실패하면 failed (기본 8)
+	BaseBackoff  time.Duration // 첫 재시도 대기 시간, 이후 2배씩 (기본 10초)
+	MaxBackoff   time.Duration // 재시도 대기 시간 상한 (기본 1시간)
+	Timeout      time.Duration // 요청 하나의 제한 시간 (기본 10초)
+	PollInterval time.Duration // 대기열 확인 주기 (기본 2초)
+}
+
+func (o Options) withDefaults() Options {
+	if o.MaxAttempts <= 0 {
+		o.MaxAttempts = 8
+	}
+	if o.BaseBackoff <= 0 {
+		o.BaseBackoff = 10 * time.Second
+	}
+	if o.MaxBackoff <= 0 {
+		o.MaxBackoff = time.Hour
+	}
+	if o.Timeout <= 0 {
+		o.Timeout = 10 * time.Second
+	}
+	if o.PollInterval <= 0 {
+		o.PollInterval = 2 * time.Second
+	}
+	return o
+}
+
+// Worker: 전송 대기열을 주기적으로 확인해서 구독자에게 보냄
+type Worker struct {
+	repo   repository.WebhookRepository
+	client *http.Client
+	opts   Options
+}
+
+// 생성자
+func NewWorker(repo repository.WebhookRepository, opts Options) *Worker {
+	opts = opts.withDefaults()
+	return &Worker{repo: repo, client: &http.Client{Timeout: opts.Timeout}, opts: opts}
+}
+
+// Start: 백그라운드 전송 시작 (Active 서버에서만 보냄 - Standby는 대기열만 쌓임)
+func (w *Worker) Start() {
+	go func() {
+		ticker := time.NewTicker(w.opts.PollInterval)
+		defer ticker.Stop()
+		for range ticker.C {
+			if !global.IsActive() {
+				continue
+			}
+			w.RunOnce(time.Now())
+		}
+	}()
+}
+
+// RunOnce: 지금 보낼 차례인 전송을 한 번 처리 (처리한 개수 반환)
+func (w *Worker) RunOnce(now time.Time) int {
+	deliveries, err := w.repo.DueDeliveries(now, batchSize)
+	if err != nil {
+		logf("❌ [Webhook] 전송 대기열 조회 실패: %v", err)
+		return 0
+	}
+	for _, d := range deliveries {
+		w.deliver(d, now)
+	}
+	return len(deliveries)
+}
+
+// 한 건 전송 후 결과 저장 (완료 시각과 다음 재시도 시각은 now 기준)
+func (w *Worker) deliver(d model.WebhookDelivery, now time.Time) {
+	hook, err := w.repo.Find(d.WebhookID)
+	if err != nil {
+		logf("❌ [Webhook] 구독자 #%d 조회 실패: %v", d.WebhookID, err)
+		return
+	}
+
+	start := time.Now()
+	status, sendErr := w.send(hook, d, now)
+	attempt := model.WebhookAttempt{StatusCode: status, DurationMs: time.Since(start).Milliseconds()}
+
+	d.Attempts++
+	d.LastStatusCode = status
+	if sendErr == nil {
+		d.Status, d.LastError, d.DeliveredAt = model.DeliverySucceeded, "", &now
+	} else {
+		attempt.Error = sendErr.Error()
+		d.LastError = sendErr.Error()
+		if d.Attempts >= w.opts.MaxAttempts || !hook.Active {
+			d.Status = model.DeliveryFailed
+		} else {
+			d.NextAttemptAt = now.Add(Backoff(w.opts.BaseBackoff, w.opts.MaxBackoff, d.Attempts))
+		}
+	}
+	if err := w.repo.RecordAttempt(d, attempt); err != nil {
+		logf("❌ [Webhook] 전송 결과 저장 실패 (#%d): %v", d.ID, err)
+	}
+}
+
+// 서명해서 POST, 2xx가 아니면 에러
+func (w *Worker) send(hook model.Webhook, d model.WebhookDelivery, now time.Time) (int, error) {
+	if !hook.Active {
+		return 0, fmt.Errorf("webhook is disabled")
+	}
+	body := []byte(d.Payload)
+	req, err := http.NewRequest(http.MethodPost, hook.URL, bytes.NewReader(body))
+	if err != nil {
+		return 0, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "go-todo-api-webhook/1.0")
+	req.Header.Set(SignatureHeader, Sign(hook.Secret, body))
+	req.Header.Set(EventHeader, d.Event)
+	req.Header.Set(DeliveryHeader, strconv.FormatUint(uint64(d.ID), 10))
+	req.Header.Set(TimestampHeader, strconv.FormatInt(now.Unix(), 10))
+
+	resp, err := w.client.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		snippet, _ := io.ReadAll(io.LimitReader(resp.Body, maxErrorBody))
+		return resp.StatusCode, fmt.Errorf("unexpected status %d: %s", resp.StatusCode, bytes.TrimSpace(snippet))
+	}
+	io.Copy(io.Discard, resp.Body) // 연결 재사용을 위해 끝까지 읽음
+	return resp.StatusCode, nil
+}
+
+// Backoff: attempts번 실패한 뒤의 대기 시간 (base, 2*base, 4*base ... 최대 limit)
+func Backoff(base, limit time.Duration, attempts int) time.Duration {
+	d := base
+	for i := 1; i < attempts; i++ {
+		d *= 2
+		if d >= limit {
+			return limit
+		}
+	}
+	return min(d, limit)
+}